@@ -370,6 +370,7 @@ func StartControllers(s *options.ControllerManagerServer,
 	serviceCatalogController, err := controller.NewController(
 		coreClient,
 		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).ServicecatalogV1beta1(),
+		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
@@ -384,6 +385,7 @@ func StartControllers(s *options.ControllerManagerServer,
 		recorder,
 		s.ReconciliationRetryDuration,
 		s.OperationPollingMaximumBackoffDuration,
+		s.BindingRefreshInterval,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
 	)
@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// PodPresetWebhookServer is the main context object for the PodPreset
+// webhook server.
+type PodPresetWebhookServer struct {
+	KubeConfig  string
+	KubeContext string
+
+	// ResyncInterval is the interval on which the PodPreset informer is
+	// resynced.
+	ResyncInterval       time.Duration
+	SecureServingOptions *genericoptions.SecureServingOptions
+}
+
+const (
+	defaultResyncInterval = 5 * time.Minute
+	defaultSecurePort     = 8443
+	defaultCertDirectory  = "/var/run/service-catalog-podpreset-webhook"
+)
+
+// NewPodPresetWebhookServer creates a new PodPresetWebhookServer with a
+// default config.
+func NewPodPresetWebhookServer() *PodPresetWebhookServer {
+	s := PodPresetWebhookServer{
+		ResyncInterval:       defaultResyncInterval,
+		SecureServingOptions: genericoptions.NewSecureServingOptions(),
+	}
+	s.SecureServingOptions.BindPort = defaultSecurePort
+	s.SecureServingOptions.ServerCert.CertDirectory = defaultCertDirectory
+	return &s
+}
+
+// AddFlags adds flags for a PodPresetWebhookServer to the specified FlagSet.
+func (s *PodPresetWebhookServer) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.KubeConfig, "kubeconfig", os.Getenv(clientcmd.RecommendedConfigPathEnvVar), "Path to a kubeconfig used to reach the kubernetes and service-catalog API servers. If unset, the in-cluster config is used")
+	fs.StringVar(&s.KubeContext, "kube-context", "", "Context of the kubeconfig to use. If unset, will use value from 'current-context'")
+	fs.DurationVar(&s.ResyncInterval, "resync-interval", s.ResyncInterval, "The interval on which the PodPreset informer is resynced")
+	s.SecureServingOptions.AddFlags(fs)
+}
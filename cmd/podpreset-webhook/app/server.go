@@ -0,0 +1,128 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	goflag "flag"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
+	"github.com/kubernetes-incubator/service-catalog/pkg/webhook/podpreset"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// MutatePodsPath is the path on which the webhook serves pod admission
+// reviews. It must match the path in the MutatingWebhookConfiguration.
+const MutatePodsPath = "/mutate-pods"
+
+var options *PodPresetWebhookServer
+
+// Execute parses the flags and runs the PodPreset webhook server until it
+// is terminated.
+func Execute() error {
+	pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
+	options = NewPodPresetWebhookServer()
+	options.AddFlags(pflag.CommandLine)
+	pflag.CommandLine.Set("alsologtostderr", "true")
+	defer glog.Flush()
+	return rootCmd.Execute()
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "podpreset-webhook",
+	Short: "podpreset-webhook applies PodPresets to pods at creation time",
+	Long: "podpreset-webhook is a mutating admission webhook server that injects " +
+		"the env, envFrom, volumes and volume mounts of the matching " +
+		"settings.servicecatalog.k8s.io PodPresets into pods as they are created. " +
+		"Pods whose containers conflict with a matching PodPreset are admitted " +
+		"unchanged. Every applied PodPreset is recorded in a pod annotation.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return Run(options, make(chan struct{}))
+	},
+}
+
+// Run starts the PodPreset informer and serves admission reviews until the
+// stop channel is closed.
+func Run(s *PodPresetWebhookServer, stopCh <-chan struct{}) error {
+	config, err := loadConfig(s.KubeConfig, s.KubeContext)
+	if err != nil {
+		return fmt.Errorf("error loading client config: %v", err)
+	}
+	catalogClient, err := clientset.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("error creating service-catalog client: %v", err)
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(catalogClient, s.ResyncInterval)
+	podPresets := informerFactory.Settings().V1alpha1().PodPresets()
+	mutator := podpreset.NewMutator(podPresets.Lister())
+	informerFactory.Start(stopCh)
+
+	glog.V(1).Info("Waiting for PodPreset caches to sync")
+	if !cache.WaitForCacheSync(stopCh, podPresets.Informer().HasSynced) {
+		return fmt.Errorf("timed out waiting for PodPreset caches to sync")
+	}
+
+	// Initialize SSL/TLS configuration. Creates a self signed certificate and key if necessary
+	if err := s.SecureServingOptions.MaybeDefaultWithSelfSignedCerts("" /*AdvertiseAddress*/, nil /*alternateDNS*/, []net.IP{net.ParseIP("127.0.0.1")}); err != nil {
+		return fmt.Errorf("failed to establish SecureServingOptions %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(MutatePodsPath, mutator)
+	healthz.InstallHandler(mux, healthz.PingHealthz)
+
+	server := &http.Server{
+		Addr: net.JoinHostPort(s.SecureServingOptions.BindAddress.String(),
+			strconv.Itoa(s.SecureServingOptions.BindPort)),
+		Handler: mux,
+	}
+	go func() {
+		<-stopCh
+		server.Close()
+	}()
+
+	glog.V(1).Infof("Serving PodPreset admission reviews on port %v", s.SecureServingOptions.BindPort)
+	err = server.ListenAndServeTLS(s.SecureServingOptions.ServerCert.CertKey.CertFile,
+		s.SecureServingOptions.ServerCert.CertKey.KeyFile)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// loadConfig returns the in-cluster config if no kubeconfig is given, or
+// the config of the given context of the kubeconfig otherwise.
+func loadConfig(kubeconfig, context string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return rest.InClusterConfig()
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	).ClientConfig()
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The podpreset-webhook is a mutating admission webhook server that applies
+// settings.servicecatalog.k8s.io PodPresets to pods at creation time.
+package main
+
+import (
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/cmd/podpreset-webhook/app"
+)
+
+func main() {
+	err := app.Execute()
+	glog.Flush()
+	if err != nil {
+		os.Exit(1)
+	}
+}
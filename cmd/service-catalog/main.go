@@ -34,6 +34,7 @@ func main() {
 
 	hk.AddServer(server.NewAPIServer())
 	hk.AddServer(server.NewControllerManager())
+	hk.AddServer(server.NewPodPresetWebhook())
 
 	hk.RunToExit(os.Args)
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type credentialsCmd struct {
+	*command.Namespaced
+	name   string
+	format string
+	key    string
+}
+
+// NewCredentialsCmd builds a "svcat credentials" command
+func NewCredentialsCmd(cxt *command.Context) *cobra.Command {
+	credentialsCmd := &credentialsCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "credentials BINDING",
+		Short: "Print the decoded credentials of a binding",
+		Long: `Print the credentials that the broker returned for a binding, decoded from the
+binding's secret, as shell exports, a dotenv file or JSON. Keys are converted into
+environment variable names, for example db-password becomes DB_PASSWORD.`,
+		Example: command.NormalizeExamples(`
+  eval $(svcat credentials wordpress-mysql-binding)
+  svcat credentials wordpress-mysql-binding --format dotenv > .env
+  svcat credentials wordpress-mysql-binding --key password
+`),
+		PreRunE: command.PreRunE(credentialsCmd),
+		RunE:    command.RunE(credentialsCmd),
+	}
+	credentialsCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&credentialsCmd.format, "format", "env",
+		"The format of the credentials. Valid options are env, dotenv or json")
+	cmd.Flags().StringVar(&credentialsCmd.key, "key", "",
+		"Print only the raw value of this key of the binding's secret")
+	return cmd
+}
+
+func (c *credentialsCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.name = args[0]
+
+	if !output.IsCredentialsFormat(c.format) {
+		return fmt.Errorf("invalid --format %q, allowed values are env, dotenv and json", c.format)
+	}
+
+	return nil
+}
+
+func (c *credentialsCmd) Run() error {
+	creds, err := c.App.RetrieveCredentials(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	if c.key != "" {
+		value, ok := creds[c.key]
+		if !ok {
+			keys := make([]string, 0, len(creds))
+			for key := range creds {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			return fmt.Errorf("key %q not found in the credentials of binding '%s/%s', available keys are: %s",
+				c.key, c.Namespace, c.name, strings.Join(keys, ", "))
+		}
+		c.Output.Write(value)
+		return nil
+	}
+
+	output.WriteCredentials(c.Output, c.format, creds)
+	return nil
+}
@@ -21,13 +21,21 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type describeCmd struct {
 	*command.Namespaced
-	name        string
-	showSecrets bool
+	name         string
+	showSecrets  bool
+	outputFormat string
+}
+
+func (c *describeCmd) SetFormat(format string) {
+	c.outputFormat = format
 }
 
 // NewDescribeCmd builds a "svcat describe binding" command
@@ -42,6 +50,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	command.AddOutputFlags(cmd.Flags())
 	cmd.Flags().BoolVar(
 		&describeCmd.showSecrets,
 		"show-secrets",
@@ -65,15 +74,49 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) describe() error {
-	binding, err := c.App.RetrieveBinding(c.Namespace, c.name)
+	value, err := c.Fetch(c.App)
 	if err != nil {
 		return err
 	}
 
-	output.WriteBindingDetails(c.Output, binding)
+	c.write(value)
+	return nil
+}
 
-	secret, err := c.App.RetrieveSecretByBinding(binding)
-	output.WriteAssociatedSecret(c.Output, secret, err, c.showSecrets)
+// bindingDetails is a binding along with its secret, or the error retrieving
+// the secret.
+type bindingDetails struct {
+	binding   *v1beta1.ServiceBinding
+	secret    *corev1.Secret
+	secretErr error
+}
 
-	return nil
+// Fetch retrieves the binding, along with its secret when printing a table.
+func (c *describeCmd) Fetch(app *svcat.App) (interface{}, error) {
+	binding, err := app.RetrieveBinding(c.Namespace, c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !output.IsTableFormat(c.outputFormat) {
+		return binding, nil
+	}
+
+	secret, err := app.RetrieveSecretByBinding(binding)
+	return bindingDetails{binding: binding, secret: secret, secretErr: err}, nil
+}
+
+func (c *describeCmd) write(value interface{}) {
+	switch v := value.(type) {
+	case *v1beta1.ServiceBinding:
+		output.WriteBinding(c.Output, c.outputFormat, *v)
+	case bindingDetails:
+		output.WriteBindingDetails(c.Output, v.binding)
+		output.WriteAssociatedSecret(c.Output, v.secret, v.secretErr, c.showSecrets)
+	}
+}
+
+// WriteContexts prints the binding retrieved from every context.
+func (c *describeCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextDetails(c.Output, c.outputFormat, results, c.write)
 }
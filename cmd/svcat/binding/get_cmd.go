@@ -19,11 +19,16 @@ package binding
 import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
 	*command.Namespaced
+	*command.Watchable
 	name         string
 	outputFormat string
 }
@@ -34,7 +39,10 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get bindings" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Namespaced: command.NewNamespaced(cxt)}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Watchable:  command.NewWatchable(),
+	}
 	cmd := &cobra.Command{
 		Use:     "bindings [NAME]",
 		Aliases: []string{"binding", "bnd"},
@@ -44,6 +52,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get bindings --all-namespaces
   svcat get binding wordpress-mysql-binding
   svcat get binding -n ci concourse-postgres-binding
+  svcat get bindings --watch
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -51,6 +60,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	command.AddOutputFlags(cmd.Flags())
+	getCmd.AddWatchFlags(cmd)
 	return cmd
 }
 
@@ -77,6 +87,14 @@ func (c *getCmd) getAll() error {
 	}
 
 	output.WriteBindingList(c.Output, c.outputFormat, bindings)
+
+	if c.Watch {
+		listed := make([]runtime.Object, len(bindings.Items))
+		for i := range bindings.Items {
+			listed[i] = &bindings.Items[i]
+		}
+		return c.watch(listed)
+	}
 	return nil
 }
 
@@ -87,5 +105,41 @@ func (c *getCmd) get() error {
 	}
 
 	output.WriteBinding(c.Output, c.outputFormat, *binding)
+
+	if c.Watch {
+		return c.watch([]runtime.Object{binding})
+	}
 	return nil
 }
+
+// watch prints the changes to the bindings until the watch is closed.
+func (c *getCmd) watch(listed []runtime.Object) error {
+	w, err := c.App.WatchBindings(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	return c.WatchChanges(w, listed, func(event watch.Event) {
+		if binding, ok := event.Object.(*v1beta1.ServiceBinding); ok {
+			output.WriteBindingEvent(c.Output, c.outputFormat, event.Type, binding)
+		}
+	})
+}
+
+// Fetch retrieves the bindings from the cluster of a kubeconfig context.
+func (c *getCmd) Fetch(app *svcat.App) (interface{}, error) {
+	if c.name == "" {
+		return app.RetrieveBindings(c.Namespace)
+	}
+
+	binding, err := app.RetrieveBinding(c.Namespace, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta1.ServiceBindingList{Items: []v1beta1.ServiceBinding{*binding}}, nil
+}
+
+// WriteContexts prints the bindings retrieved from every context.
+func (c *getCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextLists(c.Output, c.outputFormat, results)
+}
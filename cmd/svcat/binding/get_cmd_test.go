@@ -132,6 +132,7 @@ func TestGetCommand(t *testing.T) {
 			// Initialize the command arguments
 			cmd := &getCmd{
 				Namespaced: command.NewNamespaced(cxt),
+				Watchable:  command.NewWatchable(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type runCmd struct {
+	*command.Namespaced
+	bindingName string
+	command     []string
+}
+
+// NewRunCmd builds a "svcat run" command
+func NewRunCmd(cxt *command.Context) *cobra.Command {
+	runCmd := &runCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "run --binding NAME -- COMMAND [ARGS...]",
+		Short: "Run a local command with the credentials of a binding",
+		Long: `Run a local command with the credentials of a binding injected as environment
+variables, named as in svcat credentials. svcat exits with the exit code of the command.`,
+		Example: command.NormalizeExamples(`
+  svcat run --binding wordpress-mysql-binding -- npm start
+  svcat run --binding wordpress-mysql-binding -n wordpress -- printenv PASSWORD
+`),
+		PreRunE: command.PreRunE(runCmd),
+		RunE:    command.RunE(runCmd),
+	}
+	runCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&runCmd.bindingName, "binding", "",
+		"The name of the binding whose credentials are injected")
+	cmd.MarkFlagCustom("binding", completion.BindingNames)
+	// Leave the flags of the command to run alone
+	cmd.Flags().SetInterspersed(false)
+	return cmd
+}
+
+func (c *runCmd) Validate(args []string) error {
+	if c.bindingName == "" {
+		return fmt.Errorf("a binding name is required, use --binding")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("a command to run is required")
+	}
+	c.command = args
+
+	return nil
+}
+
+func (c *runCmd) Run() error {
+	creds, err := c.App.RetrieveCredentials(c.Namespace, c.bindingName)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(c.command[0], c.command[1:]...)
+	cmd.Env = append(os.Environ(), servicecatalog.CredentialsEnv(creds)...)
+	cmd.Stdin = c.Input
+	cmd.Stdout = c.Output
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return &command.ExitError{Code: exitCode(exitErr), Err: fmt.Errorf("%s: %v", c.command[0], err)}
+		}
+		return err
+	}
+	return nil
+}
+
+// exitCode returns the exit code of a command that failed, or 1 when the
+// platform does not report it.
+func exitCode(err *exec.ExitError) int {
+	if status, ok := err.Sys().(syscall.WaitStatus); ok && status.ExitStatus() > 0 {
+		return status.ExitStatus()
+	}
+	return command.ExitCodeError
+}
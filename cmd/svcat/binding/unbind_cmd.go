@@ -23,15 +23,17 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 type unbindCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Selectable
 
 	instanceName string
 	bindingNames []string
@@ -42,6 +44,7 @@ func NewUnbindCmd(cxt *command.Context) *cobra.Command {
 	unbindCmd := &unbindCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Waitable:   command.NewWaitable(),
+		Selectable: command.NewSelectable(),
 	}
 	cmd := &cobra.Command{
 		Use:   "unbind INSTANCE_NAME",
@@ -49,6 +52,8 @@ func NewUnbindCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat unbind wordpress-mysql-instance
   svcat unbind --name wordpress-mysql-binding
+  svcat unbind -l app=wordpress -n dev
+  svcat unbind --all -n dev --yes
 `),
 		PreRunE: command.PreRunE(unbindCmd),
 		RunE:    command.RunE(unbindCmd),
@@ -60,12 +65,20 @@ func NewUnbindCmd(cxt *command.Context) *cobra.Command {
 		[]string{},
 		"The name of the binding to remove",
 	)
+	cmd.MarkFlagCustom("name", completion.BindingNames)
 	unbindCmd.AddWaitFlags(cmd)
+	unbindCmd.AddSelectorFlags(cmd, "bindings")
 
 	return cmd
 }
 
 func (c *unbindCmd) Validate(args []string) error {
+	if c.IsBulk() {
+		if len(args) > 0 || len(c.bindingNames) > 0 {
+			return fmt.Errorf("an instance or binding name cannot be used with --selector or --all")
+		}
+		return nil
+	}
 	if len(args) == 0 {
 		if len(c.bindingNames) == 0 {
 			return fmt.Errorf("an instance or binding name is required")
@@ -83,9 +96,17 @@ func (c *unbindCmd) Run() error {
 	var bindings []types.NamespacedName
 	var err error
 
-	if c.instanceName != "" {
+	switch {
+	case c.IsBulk():
+		var selected []types.NamespacedName
+		selected, err = c.selectBindings()
+		if err != nil || len(selected) == 0 {
+			return err
+		}
+		bindings, err = c.App.DeleteBindingsConcurrently(selected, c.Concurrency)
+	case c.instanceName != "":
 		bindings, err = c.App.Unbind(c.Namespace, c.instanceName)
-	} else {
+	default:
 		bindings, err = c.App.DeleteBindings(c.getBindingsToDelete())
 	}
 
@@ -109,6 +130,29 @@ func (c *unbindCmd) Run() error {
 	return nil
 }
 
+// selectBindings returns the bindings selected with --selector or --all,
+// once the user confirmed they should be deleted.
+func (c *unbindCmd) selectBindings() ([]types.NamespacedName, error) {
+	bindings, err := c.App.SelectBindings(c.Namespace, c.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		fmt.Fprintln(c.Output, "No bindings found")
+		return nil, nil
+	}
+
+	fmt.Fprintln(c.Output, "The following bindings will be deleted:")
+	ok, err := c.ConfirmSelection(c.Context, fmt.Sprintf("Delete %d binding(s)?", len(bindings)), bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("unbind canceled")
+	}
+	return bindings, nil
+}
+
 func (c *unbindCmd) getBindingsToDelete() []types.NamespacedName {
 	bindings := []types.NamespacedName{}
 	for _, name := range c.bindingNames {
@@ -143,7 +187,7 @@ func (c *unbindCmd) waitForBindingDeletes(waitMessage string, bindings ...types.
 			mutex.Lock()
 			defer mutex.Unlock()
 
-			if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			if err != nil && !servicecatalog.IsNotFound(err) {
 				hasErrors = true
 				fmt.Fprintln(c.Output, err)
 			} else if c.App.IsBindingFailed(binding) {
@@ -198,6 +198,7 @@ func TestUnbindCommand(t *testing.T) {
 			cmd := &unbindCmd{
 				Namespaced: command.NewNamespaced(cxt),
 				Waitable:   command.NewWaitable(),
+				Selectable: command.NewSelectable(),
 			}
 			cmd.Namespace = ns
 			cmd.bindingNames = tc.bindingNames
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type deregisterCmd struct {
+	*command.Namespaced
+	*command.Scoped
+
+	name string
+}
+
+// NewDeregisterCmd builds a "svcat deregister" command
+func NewDeregisterCmd(cxt *command.Context) *cobra.Command {
+	deregisterCmd := &deregisterCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "deregister NAME",
+		Short: "Deregisters an existing broker with service catalog",
+		Example: command.NormalizeExamples(`
+  svcat deregister mysqlbroker
+  svcat deregister mysqlbroker --scope namespace -n brokers
+`),
+		PreRunE: command.PreRunE(deregisterCmd),
+		RunE:    command.RunE(deregisterCmd),
+	}
+	deregisterCmd.AddNamespaceFlags(cmd.Flags(), false)
+	deregisterCmd.AddScopedFlags(cmd.Flags(), servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+
+	return cmd
+}
+
+func (c *deregisterCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.name = args[0]
+	return nil
+}
+
+func (c *deregisterCmd) Run() error {
+	return c.deregister()
+}
+
+func (c *deregisterCmd) deregister() error {
+	scopeOpts := &servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	err := c.App.Deregister(c.name, scopeOpts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteDeletedResourceName(c.Output, c.name)
+	return nil
+}
@@ -21,27 +21,42 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type describeCmd struct {
-	*command.Context
-	name string
+	*command.Namespaced
+	*command.Scoped
+	name         string
+	outputFormat string
+}
+
+func (c *describeCmd) SetFormat(format string) {
+	c.outputFormat = format
 }
 
 // NewDescribeCmd builds a "svcat describe broker" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "broker NAME",
 		Aliases: []string{"brokers", "brk"},
 		Short:   "Show details of a specific broker",
 		Example: command.NormalizeExamples(`
   svcat describe broker asb
+  svcat describe broker asb --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
+	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -59,11 +74,35 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) Describe() error {
-	broker, err := c.App.RetrieveBroker(c.name)
+	broker, err := c.Fetch(c.App)
 	if err != nil {
 		return err
 	}
 
-	output.WriteBrokerDetails(c.Output, broker)
+	c.write(broker)
 	return nil
 }
+
+// Fetch retrieves the broker from the cluster of a kubeconfig context.
+func (c *describeCmd) Fetch(app *svcat.App) (interface{}, error) {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	return app.RetrieveBroker(c.name, opts)
+}
+
+func (c *describeCmd) write(value interface{}) {
+	broker := value.(servicecatalog.Broker)
+	if !output.IsTableFormat(c.outputFormat) {
+		output.WriteBroker(c.Output, c.outputFormat, broker)
+		return
+	}
+
+	output.WriteBrokerDetails(c.Output, broker)
+}
+
+// WriteContexts prints the broker retrieved from every context.
+func (c *describeCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextDetails(c.Output, c.outputFormat, results, c.write)
+}
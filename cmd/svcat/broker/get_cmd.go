@@ -19,11 +19,17 @@ package broker
 import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
+	*command.Watchable
 	name         string
 	outputFormat string
 }
@@ -34,7 +40,11 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get brokers" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+		Watchable:  command.NewWatchable(),
+	}
 	cmd := &cobra.Command{
 		Use:     "brokers [NAME]",
 		Aliases: []string{"broker", "brk"},
@@ -42,11 +52,17 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat get brokers
   svcat get broker asb
+  svcat get brokers --scope cluster
+  svcat get brokers --scope namespace --namespace dev
+  svcat get brokers --watch
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
 	}
+	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	getCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
 	command.AddOutputFlags(cmd.Flags())
+	getCmd.AddWatchFlags(cmd)
 	return cmd
 }
 
@@ -67,21 +83,83 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	brokers, err := c.App.RetrieveBrokers()
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	brokers, err := c.App.RetrieveBrokers(opts)
 	if err != nil {
 		return err
 	}
 
 	output.WriteBrokerList(c.Output, c.outputFormat, brokers...)
+
+	if c.Watch {
+		return c.watch(brokers)
+	}
 	return nil
 }
 
 func (c *getCmd) get() error {
-	broker, err := c.App.RetrieveBroker(c.name)
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	broker, err := c.App.RetrieveBroker(c.name, opts)
 	if err != nil {
 		return err
 	}
 
-	output.WriteBroker(c.Output, c.outputFormat, *broker)
+	output.WriteBroker(c.Output, c.outputFormat, broker)
+
+	if c.Watch {
+		return c.watch([]servicecatalog.Broker{broker})
+	}
 	return nil
 }
+
+// watch prints the changes to the brokers until the watch is closed.
+func (c *getCmd) watch(brokers []servicecatalog.Broker) error {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	w, err := c.App.WatchBrokers(c.name, opts)
+	if err != nil {
+		return err
+	}
+
+	listed := make([]runtime.Object, 0, len(brokers))
+	for _, broker := range brokers {
+		if obj, ok := broker.(runtime.Object); ok {
+			listed = append(listed, obj)
+		}
+	}
+	return c.WatchChanges(w, listed, func(event watch.Event) {
+		if broker, ok := event.Object.(servicecatalog.Broker); ok {
+			output.WriteBrokerEvent(c.Output, c.outputFormat, event.Type, broker)
+		}
+	})
+}
+
+// Fetch retrieves the brokers from the cluster of a kubeconfig context.
+func (c *getCmd) Fetch(app *svcat.App) (interface{}, error) {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	if c.name == "" {
+		return app.RetrieveBrokers(opts)
+	}
+
+	broker, err := app.RetrieveBroker(c.name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return []servicecatalog.Broker{broker}, nil
+}
+
+// WriteContexts prints the brokers retrieved from every context.
+func (c *getCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextLists(c.Output, c.outputFormat, results)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type registerCmd struct {
+	*command.Namespaced
+	*command.Scoped
+
+	name              string
+	url               string
+	basicSecret       string
+	bearerSecret      string
+	caFile            string
+	relistBehavior    string
+	rawRelistDuration string
+	classRestrictions []string
+	planRestrictions  []string
+	opts              *servicecatalog.RegisterOptions
+}
+
+// NewRegisterCmd builds a "svcat register" command
+func NewRegisterCmd(cxt *command.Context) *cobra.Command {
+	registerCmd := &registerCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "register NAME --url URL",
+		Short: "Registers a new broker with service catalog",
+		Example: command.NormalizeExamples(`
+  svcat register mysqlbroker --url http://mysqlbroker.com
+  svcat register mysqlbroker --url http://mysqlbroker.com --basic-secret mysqlbroker-auth -n brokers
+  svcat register mysqlbroker --url https://mysqlbroker.com --ca-file ca.pem --relist-behavior Duration --relist-duration 30m
+  svcat register mysqlbroker --url http://mysqlbroker.com --class-restriction "name==mysqldb" --scope namespace
+`),
+		PreRunE: command.PreRunE(registerCmd),
+		RunE:    command.RunE(registerCmd),
+	}
+	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
+	registerCmd.AddScopedFlags(cmd.Flags(), servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	cmd.Flags().StringVar(&registerCmd.url, "url", "",
+		"The broker URL (Required)")
+	cmd.MarkFlagRequired("url")
+	cmd.Flags().StringVar(&registerCmd.basicSecret, "basic-secret", "",
+		"A secret containing basic auth (username/password) information to connect to the broker. The secret is looked up in the namespace of the command. Cannot be combined with --bearer-secret")
+	cmd.Flags().StringVar(&registerCmd.bearerSecret, "bearer-secret", "",
+		"A secret containing a bearer token to connect to the broker. The secret is looked up in the namespace of the command. Cannot be combined with --basic-secret")
+	cmd.Flags().StringVar(&registerCmd.caFile, "ca-file", "",
+		"A file containing the CA certificate used to validate the broker's serving certificate")
+	cmd.Flags().StringVar(&registerCmd.relistBehavior, "relist-behavior", "",
+		"Behavior used to relist the broker's catalog. Valid options are Duration or Manual. Defaults to Duration on the server")
+	cmd.Flags().StringVar(&registerCmd.rawRelistDuration, "relist-duration", "",
+		"Interval between catalog relists when --relist-behavior is Duration, specified in human readable format: 30s, 1m, 1h")
+	cmd.Flags().StringSliceVar(&registerCmd.classRestrictions, "class-restriction", nil,
+		"A selector restricting the classes loaded from the broker's catalog, e.g. \"name in (mysqldb, postgresqldb)\"")
+	cmd.Flags().StringSliceVar(&registerCmd.planRestrictions, "plan-restriction", nil,
+		"A selector restricting the plans loaded from the broker's catalog, e.g. \"name!=premium\"")
+
+	return cmd
+}
+
+func (c *registerCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.name = args[0]
+
+	if c.basicSecret != "" && c.bearerSecret != "" {
+		return fmt.Errorf("--basic-secret cannot be used with --bearer-secret")
+	}
+
+	c.opts = &servicecatalog.RegisterOptions{
+		BasicSecret:       c.basicSecret,
+		BearerSecret:      c.bearerSecret,
+		ClassRestrictions: c.classRestrictions,
+		PlanRestrictions:  c.planRestrictions,
+	}
+
+	if c.caFile != "" {
+		caBundle, err := ioutil.ReadFile(c.caFile)
+		if err != nil {
+			return fmt.Errorf("invalid --ca-file value (%s)", err)
+		}
+		c.opts.CABundle = caBundle
+	}
+
+	switch v1beta1.ServiceBrokerRelistBehavior(c.relistBehavior) {
+	case "", v1beta1.ServiceBrokerRelistBehaviorDuration, v1beta1.ServiceBrokerRelistBehaviorManual:
+		c.opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehavior(c.relistBehavior)
+	default:
+		return fmt.Errorf("invalid --relist-behavior value %q, allowed values are Duration and Manual", c.relistBehavior)
+	}
+
+	if c.rawRelistDuration != "" {
+		if c.opts.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorManual {
+			return fmt.Errorf("--relist-duration cannot be used with --relist-behavior Manual")
+		}
+		relistDuration, err := time.ParseDuration(c.rawRelistDuration)
+		if err != nil {
+			return fmt.Errorf("invalid --relist-duration value (%s)", err)
+		}
+		c.opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorDuration
+		c.opts.RelistDuration = &metav1.Duration{Duration: relistDuration}
+	}
+
+	return nil
+}
+
+func (c *registerCmd) Run() error {
+	return c.register()
+}
+
+func (c *registerCmd) register() error {
+	scopeOpts := &servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	broker, err := c.App.Register(c.name, c.url, c.opts, scopeOpts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteRegisteredBroker(c.Output, broker)
+	return nil
+}
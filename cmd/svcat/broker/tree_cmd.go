@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type treeCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	name         string
+	outputFormat string
+}
+
+// NewTreeCmd builds a "svcat tree broker" command
+func NewTreeCmd(cxt *command.Context) *cobra.Command {
+	treeCmd := &treeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "broker NAME",
+		Short: "Show the classes, plans, instances, bindings, secrets and pods of a broker",
+		Example: command.NormalizeExamples(`
+  svcat tree broker asb
+  svcat tree broker asb -o dot | dot -Tpng > asb.png
+`),
+		PreRunE: command.PreRunE(treeCmd),
+		RunE:    command.RunE(treeCmd),
+	}
+	treeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	treeCmd.AddScopedFlags(cmd.Flags(), servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	cmd.Flags().StringVarP(&treeCmd.outputFormat, "output", "o", "tree",
+		"The output format to use. Valid options are tree or dot, for Graphviz")
+	return cmd
+}
+
+func (c *treeCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.name = args[0]
+
+	if !output.IsTreeFormat(c.outputFormat) {
+		return fmt.Errorf("invalid --output format %q, allowed values are tree and dot", c.outputFormat)
+	}
+
+	return nil
+}
+
+func (c *treeCmd) Run() error {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	tree, err := c.App.BrokerTree(c.name, opts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteTreeFormat(c.Output, c.outputFormat, tree)
+	return nil
+}
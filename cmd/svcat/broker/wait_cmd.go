@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type waitCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	*command.WaitFor
+	name string
+}
+
+// NewWaitCmd builds a "svcat wait broker" command
+func NewWaitCmd(cxt *command.Context) *cobra.Command {
+	waitCmd := &waitCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+		WaitFor:    command.NewWaitFor(),
+	}
+	cmd := &cobra.Command{
+		Use:   "broker NAME",
+		Short: "Wait for a broker to meet a condition",
+		Long: `Wait for a broker to become ready, fail, be deleted or have a status condition.
+Exits with 2 when the broker fails instead of meeting the condition, and 3 on timeout.`,
+		Example: command.NormalizeExamples(`
+  svcat wait broker asb
+  svcat wait broker team-broker --scope namespace --namespace dev --timeout 2m
+`),
+		PreRunE: command.PreRunE(waitCmd),
+		RunE:    command.RunE(waitCmd),
+	}
+	waitCmd.AddNamespaceFlags(cmd.Flags(), false)
+	waitCmd.AddScopedFlags(cmd.Flags(), servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	waitCmd.AddWaitForFlags(cmd)
+	return cmd
+}
+
+func (c *waitCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a broker name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *waitCmd) Run() error {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	_, err := c.App.WaitForBrokerCondition(c.name, opts, c.Condition, c.Interval, c.Timeout)
+	if err != nil {
+		return c.WaitError(err)
+	}
+
+	name := c.name
+	if c.Scope == servicecatalog.NamespaceScope {
+		name = c.Namespace + "/" + c.name
+	}
+	fmt.Fprintf(c.Output, "broker '%s' met %s\n", name, c.Condition)
+	return nil
+}
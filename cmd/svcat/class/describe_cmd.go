@@ -21,20 +21,30 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
-	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type describeCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	lookupByUUID bool
 	uuid         string
 	name         string
+	outputFormat string
+}
+
+func (c *describeCmd) SetFormat(format string) {
+	c.outputFormat = format
 }
 
 // NewDescribeCmd builds a "svcat describe class" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "class NAME",
 		Aliases: []string{"classes", "cl"},
@@ -42,6 +52,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat describe class mysqldb
   svcat describe class -uuid 997b8372-8dac-40ac-ae65-758b4a5075a5
+  svcat describe class mysqldb --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -53,6 +64,9 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by UUID (the default is by name)",
 	)
+	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -75,24 +89,60 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) describe() error {
-	var class *v1beta1.ClusterServiceClass
+	value, err := c.Fetch(c.App)
+	if err != nil {
+		return err
+	}
+
+	c.write(value)
+	return nil
+}
+
+// classDetails is a class along with its plans.
+type classDetails struct {
+	class servicecatalog.Class
+	plans []servicecatalog.Plan
+}
+
+// Fetch retrieves the class, along with its plans when printing a table.
+func (c *describeCmd) Fetch(app *svcat.App) (interface{}, error) {
+	var class servicecatalog.Class
 	var err error
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
 	if c.lookupByUUID {
-		class, err = c.App.RetrieveClassByID(c.uuid)
+		class, err = app.RetrieveClassByID(c.uuid, opts)
 	} else {
-		class, err = c.App.RetrieveClassByName(c.name)
+		class, err = app.RetrieveClassByName(c.name, opts)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	output.WriteClassDetails(c.Output, class)
+	if !output.IsTableFormat(c.outputFormat) {
+		return class, nil
+	}
 
-	plans, err := c.App.RetrievePlansByClass(class)
+	plans, err := app.RetrievePlansByClass(class)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	output.WriteAssociatedPlans(c.Output, plans)
+	return classDetails{class: class, plans: plans}, nil
+}
 
-	return nil
+func (c *describeCmd) write(value interface{}) {
+	switch v := value.(type) {
+	case classDetails:
+		output.WriteClassDetails(c.Output, v.class)
+		output.WriteAssociatedPlans(c.Output, v.plans)
+	case servicecatalog.Class:
+		output.WriteClass(c.Output, c.outputFormat, v)
+	}
+}
+
+// WriteContexts prints the class retrieved from every context.
+func (c *describeCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextDetails(c.Output, c.outputFormat, results, c.write)
 }
@@ -19,12 +19,14 @@ package class
 import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
-	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	lookupByUUID bool
 	uuid         string
 	name         string
@@ -37,7 +39,10 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get classes" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "classes [NAME]",
 		Aliases: []string{"class", "cl"},
@@ -46,6 +51,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get classes
   svcat get class mysqldb
   svcat get class --uuid 997b8372-8dac-40ac-ae65-758b4a5075a5
+  svcat get classes --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -57,6 +63,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by UUID (the default is by name)",
 	)
+	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	getCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
 	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
@@ -82,7 +90,11 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	classes, err := c.App.RetrieveClasses()
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	classes, err := c.App.RetrieveClasses(opts)
 	if err != nil {
 		return err
 	}
@@ -92,18 +104,50 @@ func (c *getCmd) getAll() error {
 }
 
 func (c *getCmd) get() error {
-	var class *v1beta1.ClusterServiceClass
+	var class servicecatalog.Class
 	var err error
 
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
 	if c.lookupByUUID {
-		class, err = c.App.RetrieveClassByID(c.uuid)
+		class, err = c.App.RetrieveClassByID(c.uuid, opts)
 	} else if c.name != "" {
-		class, err = c.App.RetrieveClassByName(c.name)
+		class, err = c.App.RetrieveClassByName(c.name, opts)
 	}
 	if err != nil {
 		return err
 	}
 
-	output.WriteClass(c.Output, c.outputFormat, *class)
+	output.WriteClass(c.Output, c.outputFormat, class)
 	return nil
 }
+
+// Fetch retrieves the classes from the cluster of a kubeconfig context.
+func (c *getCmd) Fetch(app *svcat.App) (interface{}, error) {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	if c.uuid == "" && c.name == "" {
+		return app.RetrieveClasses(opts)
+	}
+
+	var class servicecatalog.Class
+	var err error
+	if c.lookupByUUID {
+		class, err = app.RetrieveClassByID(c.uuid, opts)
+	} else {
+		class, err = app.RetrieveClassByName(c.name, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []servicecatalog.Class{class}, nil
+}
+
+// WriteContexts prints the classes retrieved from every context.
+func (c *getCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextLists(c.Output, c.outputFormat, results)
+}
@@ -17,10 +17,10 @@ limitations under the License.
 package command
 
 import (
-	"fmt"
 	"strings"
 	"unicode"
 
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -44,6 +44,9 @@ type FormattedCommand interface {
 // PreRunE validates os args, and then saves them on the svcat command.
 func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd, c); err != nil {
+			return err
+		}
 		if nsCmd, ok := cmd.(HasNamespaceFlags); ok {
 			nsCmd.ApplyNamespaceFlags(c.Flags())
 		}
@@ -54,19 +57,41 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 			}
 			fmtCmd.SetFormat(fmtString)
 		}
+		if scopedCmd, ok := cmd.(HasScopedFlags); ok {
+			err := scopedCmd.ApplyScopedFlags(c.Flags())
+			if err != nil {
+				return err
+			}
+		}
 		if waitCmd, ok := cmd.(HasWaitFlags); ok {
 			err := waitCmd.ApplyWaitFlags()
 			if err != nil {
 				return err
 			}
 		}
+		if selectCmd, ok := cmd.(HasSelectorFlags); ok {
+			err := selectCmd.ApplySelectorFlags()
+			if err != nil {
+				return err
+			}
+		}
+		if waitForCmd, ok := cmd.(HasWaitForFlags); ok {
+			err := waitForCmd.ApplyWaitForFlags()
+			if err != nil {
+				return err
+			}
+		}
 		return cmd.Validate(args)
 	}
 }
 
-// RunE executes a validated svcat command.
+// RunE executes a validated svcat command, against every context selected
+// with --contexts when there are several.
 func RunE(cmd Command) func(*cobra.Command, []string) error {
-	return func(_ *cobra.Command, args []string) error {
+	return func(c *cobra.Command, args []string) error {
+		if cxt, ok := cmd.(multiContext); ok && cxt.IsMultiContext() {
+			return runMultiContext(cmd, cxt, c)
+		}
 		return cmd.Run()
 	}
 }
@@ -77,24 +102,13 @@ func AddOutputFlags(flags *pflag.FlagSet) {
 		"output",
 		"o",
 		"",
-		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table",
+		"The output format to use. Valid options are table, wide, json, yaml, name, jsonpath=TEMPLATE, go-template=TEMPLATE or custom-columns=HEADER:JSONPATH,... If not present, defaults to table",
 	)
 }
 
 func determineOutputFormat(flags *pflag.FlagSet) (string, error) {
 	format, _ := flags.GetString("output")
-	format = strings.ToLower(format)
-
-	switch format {
-	case "", "table":
-		return "table", nil
-	case "json":
-		return "json", nil
-	case "yaml":
-		return "yaml", nil
-	default:
-		return "", fmt.Errorf("invalid --output format %q, allowed values are table, json and yaml", format)
-	}
+	return output.ParseFormat(format)
 }
 
 // NormalizeExamples removes leading and trailing empty lines
@@ -0,0 +1,319 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/homedir"
+)
+
+// ConfigEnvVar is the environment variable that overrides the location of the
+// svcat configuration file.
+const ConfigEnvVar = "SVCAT_CONFIG"
+
+// OfflineAnnotation marks commands that work without connecting to a cluster,
+// such as those managing the svcat configuration file.
+const OfflineAnnotation = "svcat/offline"
+
+// Config is the svcat configuration file, ~/.svcat/config.yaml by default.
+type Config struct {
+	// CurrentProfile is the name of the profile in use, if any.
+	CurrentProfile string `json:"current-profile,omitempty"`
+
+	// Defaults are used by every command, unless overridden by the current
+	// profile or a flag.
+	Defaults Defaults `json:"defaults,omitempty"`
+
+	// Profiles are named sets of defaults, bound to a kubeconfig context.
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+}
+
+// Defaults are the values of common flags used when they are not specified.
+type Defaults struct {
+	Namespace string `json:"namespace,omitempty"`
+	Output    string `json:"output,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Timeout   string `json:"timeout,omitempty"`
+	Interval  string `json:"interval,omitempty"`
+}
+
+// Profile selects a kubeconfig context, along with defaults overriding those
+// of the configuration file.
+type Profile struct {
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	Context    string `json:"context,omitempty"`
+	Defaults
+}
+
+// defaultKeys are the keys of Defaults, which are also the names of the flags
+// they apply to.
+var defaultKeys = []string{"namespace", "output", "scope", "timeout", "interval"}
+
+// profileKeys are the keys of Profile, in addition to defaultKeys.
+var profileKeys = []string{"kubeconfig", "context"}
+
+// DefaultConfigPath returns the location of the svcat configuration file,
+// $SVCAT_CONFIG or ~/.svcat/config.yaml.
+func DefaultConfigPath() string {
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		return path
+	}
+	return filepath.Join(homedir.HomeDir(), ".svcat", "config.yaml")
+}
+
+// LoadConfig reads the svcat configuration file. A missing file is an empty
+// configuration.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("could not read the configuration file %s (%s)", path, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s (%s)", path, err)
+	}
+	if cfg.CurrentProfile != "" {
+		if _, ok := cfg.Profiles[cfg.CurrentProfile]; !ok {
+			return nil, fmt.Errorf("invalid configuration file %s (current profile %q is not defined)", path, cfg.CurrentProfile)
+		}
+	}
+	return cfg, nil
+}
+
+// Save writes the svcat configuration file, creating its directory if needed.
+func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("could not create the configuration directory (%s)", err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("could not write the configuration file %s (%s)", path, err)
+	}
+	return nil
+}
+
+// Profile returns the current profile, if any.
+func (c *Config) Profile() (Profile, bool) {
+	if c.CurrentProfile == "" {
+		return Profile{}, false
+	}
+	p, ok := c.Profiles[c.CurrentProfile]
+	return p, ok
+}
+
+// EffectiveDefaults merges the defaults of the current profile over those of
+// the configuration file.
+func (c *Config) EffectiveDefaults() Defaults {
+	d := c.Defaults
+	p, ok := c.Profile()
+	if !ok {
+		return d
+	}
+	for _, key := range defaultKeys {
+		if value := *p.Defaults.field(key); value != "" {
+			*d.field(key) = value
+		}
+	}
+	return d
+}
+
+// UseProfile switches to an existing profile. An empty name stops using
+// profiles.
+func (c *Config) UseProfile(name string) error {
+	if name != "" {
+		if _, ok := c.Profiles[name]; !ok {
+			return fmt.Errorf("profile %q is not defined, allowed values are: %s", name, strings.Join(c.profileNames(), ", "))
+		}
+	}
+	c.CurrentProfile = name
+	return nil
+}
+
+// Set changes a value of the configuration, an empty value unsets it. Keys
+// are either a default, e.g. namespace, or a value of a profile,
+// e.g. profiles.NAME.context. Setting a value of an unknown profile
+// creates it.
+func (c *Config) Set(key, value string) error {
+	if err := validateConfigValue(key, value); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(key, "profiles.") {
+		field := c.Defaults.field(key)
+		if field == nil {
+			return unknownConfigKey(key)
+		}
+		*field = value
+		return nil
+	}
+
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return unknownConfigKey(key)
+	}
+	name := parts[1]
+	p := c.Profiles[name]
+	field := p.field(parts[2])
+	if field == nil {
+		return unknownConfigKey(key)
+	}
+	*field = value
+
+	if p == (Profile{}) {
+		if c.CurrentProfile == name {
+			c.CurrentProfile = ""
+		}
+		delete(c.Profiles, name)
+		return nil
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[name] = p
+	return nil
+}
+
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *Defaults) field(key string) *string {
+	switch key {
+	case "namespace":
+		return &d.Namespace
+	case "output":
+		return &d.Output
+	case "scope":
+		return &d.Scope
+	case "timeout":
+		return &d.Timeout
+	case "interval":
+		return &d.Interval
+	}
+	return nil
+}
+
+func (p *Profile) field(key string) *string {
+	switch key {
+	case "kubeconfig":
+		return &p.Kubeconfig
+	case "context":
+		return &p.Context
+	}
+	return p.Defaults.field(key)
+}
+
+// validateConfigValue checks a value the way the flag it stands for would.
+func validateConfigValue(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch key[strings.LastIndex(key, ".")+1:] {
+	case "output":
+		if _, err := output.ParseFormat(value); err != nil {
+			return fmt.Errorf("invalid output value (%s)", err)
+		}
+	case "scope":
+		allowed := []servicecatalog.Scope{servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope}
+		for _, scope := range allowed {
+			if value == string(scope) {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid scope (%s), allowed values are: %s", value, joinScopes(allowed, ", ", " and "))
+	case "timeout":
+		if value != "-1" {
+			if _, err := time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid timeout value (%s)", err)
+			}
+		}
+	case "interval":
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid interval value (%s)", err)
+		}
+	}
+	return nil
+}
+
+func unknownConfigKey(key string) error {
+	return fmt.Errorf("unknown configuration key %q, allowed keys are: %s and profiles.NAME.(%s)",
+		key, strings.Join(defaultKeys, ", "), strings.Join(append(profileKeys, defaultKeys...), "|"))
+}
+
+// scopeAllower represents a command that can tell whether it supports a scope.
+type scopeAllower interface {
+	allowsScope(scope string) bool
+}
+
+// applyConfigDefaults sets the flags that were not specified to the defaults
+// of the configuration file. Defaults that a command does not support, e.g.
+// a namespace scope on a command only listing cluster-scoped resources, are
+// ignored, as are offline commands, which manage the configuration itself.
+func applyConfigDefaults(cmd Command, c *cobra.Command) error {
+	cxt, ok := cmd.(configured)
+	if !ok {
+		return nil
+	}
+	if _, offline := c.Annotations[OfflineAnnotation]; offline {
+		return nil
+	}
+	cfg := cxt.config()
+	if cfg == nil {
+		return nil
+	}
+
+	flags := c.Flags()
+	defaults := cfg.EffectiveDefaults()
+	for _, key := range defaultKeys {
+		value := *defaults.field(key)
+		flag := flags.Lookup(key)
+		if value == "" || flag == nil || flag.Changed {
+			continue
+		}
+		if key == "scope" {
+			if scopedCmd, ok := cmd.(scopeAllower); !ok || !scopedCmd.allowsScope(value) {
+				continue
+			}
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return fmt.Errorf("invalid %s default in the configuration file (%s)", key, err)
+		}
+	}
+	return nil
+}
@@ -28,9 +28,32 @@ type Context struct {
 	// Output should be used instead of directly writing to stdout/stderr, to enable unit testing.
 	Output io.Writer
 
+	// Input should be used instead of directly reading from stdin, to enable unit testing.
+	Input io.Reader
+
 	// svcat application, the library behind the cli
 	App *svcat.App
 
+	// Clusters are the kubeconfig contexts selected with --contexts, which
+	// commands supporting it fan out to. App is connected to the first one.
+	Clusters []Cluster
+
 	// Viper configuration
 	Viper *viper.Viper
+
+	// Config is the svcat configuration file, its defaults apply to flags
+	// that are not specified.
+	Config *Config
+
+	// ConfigPath is the location of the svcat configuration file.
+	ConfigPath string
+}
+
+// configured represents a command with access to the svcat configuration.
+type configured interface {
+	config() *Config
+}
+
+func (c *Context) config() *Config {
+	return c.Config
 }
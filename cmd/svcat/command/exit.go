@@ -0,0 +1,49 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+const (
+	// ExitCodeError is returned when a command fails.
+	ExitCodeError = 1
+
+	// ExitCodeFailed is returned when a resource that was waited for failed.
+	ExitCodeFailed = 2
+
+	// ExitCodeTimeout is returned when a command timed out while waiting.
+	ExitCodeTimeout = 3
+)
+
+// ExitError is an error that causes svcat to exit with a specific code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+// ExitCode returns the code svcat exits with after a command returned err.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*ExitError); ok {
+		return exitErr.Code
+	}
+	return ExitCodeError
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/spf13/cobra"
+)
+
+// Cluster is a kubeconfig context along with the svcat application connected
+// to it.
+type Cluster struct {
+	Context string
+	App     *svcat.App
+}
+
+// MultiContextCommand is a command that can retrieve its results from several
+// kubeconfig contexts at once, selected with --contexts.
+type MultiContextCommand interface {
+	Command
+
+	// Fetch retrieves the results of the command from one context.
+	Fetch(app *svcat.App) (interface{}, error)
+
+	// WriteContexts prints the results retrieved from every context.
+	WriteContexts(results []output.ContextResult)
+}
+
+// IsMultiContext returns true when commands should fan out over several
+// kubeconfig contexts.
+func (cxt *Context) IsMultiContext() bool {
+	return len(cxt.Clusters) > 0
+}
+
+// FanOut calls fetch for every cluster concurrently and returns the results
+// in the order of the clusters.
+func (cxt *Context) FanOut(fetch func(app *svcat.App) (interface{}, error)) []output.ContextResult {
+	results := make([]output.ContextResult, len(cxt.Clusters))
+	var wg sync.WaitGroup
+	for i, cluster := range cxt.Clusters {
+		wg.Add(1)
+		go func(i int, cluster Cluster) {
+			defer wg.Done()
+			value, err := fetch(cluster.App)
+			results[i] = output.ContextResult{Context: cluster.Context, Value: value}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, cluster)
+	}
+	wg.Wait()
+	return results
+}
+
+// multiContext is implemented by the commands embedding a Context.
+type multiContext interface {
+	IsMultiContext() bool
+	FanOut(fetch func(app *svcat.App) (interface{}, error)) []output.ContextResult
+}
+
+// runMultiContext runs a command against every context selected with
+// --contexts, and fails when any of them failed.
+func runMultiContext(cmd Command, cxt multiContext, c *cobra.Command) error {
+	mcCmd, ok := cmd.(MultiContextCommand)
+	if !ok {
+		return fmt.Errorf("--contexts is not supported by %s", c.CommandPath())
+	}
+	if watchCmd, ok := cmd.(interface{ IsWatching() bool }); ok && watchCmd.IsWatching() {
+		return fmt.Errorf("--watch cannot be combined with --contexts")
+	}
+
+	results := cxt.FanOut(mcCmd.Fetch)
+	mcCmd.WriteContexts(results)
+
+	var failed []string
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s (%s)", result.Context, result.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to retrieve results from contexts: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
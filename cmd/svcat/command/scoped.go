@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/pflag"
+)
+
+// HasScopedFlags represents a command that can target cluster-scoped or
+// namespaced resources.
+type HasScopedFlags interface {
+	// ApplyScopedFlags validates and persists the scope related flags.
+	//   --scope
+	ApplyScopedFlags(flags *pflag.FlagSet) error
+}
+
+// Scoped adds support to a command for the --scope flag.
+type Scoped struct {
+	rawScope      string
+	allowedScopes []servicecatalog.Scope
+	Scope         servicecatalog.Scope
+}
+
+// NewScoped initializes a new scoped command.
+func NewScoped() *Scoped {
+	return &Scoped{}
+}
+
+// AddScopedFlags adds the scope related flags. The first allowed scope is the
+// default.
+//   --scope
+func (c *Scoped) AddScopedFlags(flags *pflag.FlagSet, allowed ...servicecatalog.Scope) {
+	c.allowedScopes = allowed
+	flags.StringVar(&c.rawScope, "scope", string(allowed[0]),
+		fmt.Sprintf("Limit the command to a particular scope: %s", joinScopes(allowed, ", ", " or ")))
+}
+
+// ApplyScopedFlags validates and persists the scope related flags.
+//   --scope
+func (c *Scoped) ApplyScopedFlags(flags *pflag.FlagSet) error {
+	for _, scope := range c.allowedScopes {
+		if c.rawScope == string(scope) {
+			c.Scope = scope
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --scope (%s), allowed values are: %s", c.rawScope, joinScopes(c.allowedScopes, ", ", " and "))
+}
+
+// allowsScope returns whether the command supports the scope.
+func (c *Scoped) allowsScope(scope string) bool {
+	for _, allowed := range c.allowedScopes {
+		if scope == string(allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinScopes(scopes []servicecatalog.Scope, sep, lastSep string) string {
+	s := ""
+	for i, scope := range scopes {
+		switch {
+		case i == 0:
+		case i == len(scopes)-1:
+			s += lastSep
+		default:
+			s += sep
+		}
+		s += string(scope)
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/prompt"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HasSelectorFlags represents a command that supports --selector and --all.
+type HasSelectorFlags interface {
+	// ApplySelectorFlags validates and persists the selector related flags.
+	//   --selector
+	//   --all
+	//   --yes
+	//   --concurrency
+	ApplySelectorFlags() error
+}
+
+// Selectable adds support to a command for acting on several resources at
+// once, selected with the --selector or --all flags.
+type Selectable struct {
+	Selector    string
+	All         bool
+	Yes         bool
+	Concurrency int
+}
+
+// NewSelectable initializes a new selectable command.
+func NewSelectable() *Selectable {
+	return &Selectable{}
+}
+
+// AddSelectorFlags adds the selector related flags, describing the kind of
+// resources that are selected, for example "instances".
+//   --selector
+//   --all
+//   --yes
+//   --concurrency
+func (c *Selectable) AddSelectorFlags(cmd *cobra.Command, kind string) {
+	cmd.Flags().StringVarP(&c.Selector, "selector", "l", "",
+		fmt.Sprintf("Act on all %s in the namespace matching the label selector, for example: env=dev,team!=web", kind))
+	cmd.Flags().BoolVar(&c.All, "all", false,
+		fmt.Sprintf("Act on all %s in the namespace", kind))
+	cmd.Flags().BoolVarP(&c.Yes, "yes", "y", false,
+		fmt.Sprintf("Do not ask for confirmation before acting on the %s selected with --selector or --all", kind))
+	cmd.Flags().IntVar(&c.Concurrency, "concurrency", servicecatalog.DefaultConcurrency,
+		fmt.Sprintf("Number of %s to act on at the same time with --selector or --all", kind))
+}
+
+// ApplySelectorFlags validates and persists the selector related flags.
+//   --selector
+//   --all
+//   --yes
+//   --concurrency
+func (c *Selectable) ApplySelectorFlags() error {
+	if c.Selector != "" && c.All {
+		return fmt.Errorf("--selector cannot be used with --all")
+	}
+	if _, err := labels.Parse(c.Selector); err != nil {
+		return fmt.Errorf("invalid --selector value (%s)", err)
+	}
+	if c.Concurrency < 1 {
+		return fmt.Errorf("invalid --concurrency value (%d), it must be at least 1", c.Concurrency)
+	}
+	return nil
+}
+
+// IsBulk returns true when the command acts on the resources selected with
+// --selector or --all, instead of the resource named in its arguments.
+func (c *Selectable) IsBulk() bool {
+	return c.Selector != "" || c.All
+}
+
+// ConfirmSelection lists the selected resources and asks the user whether to
+// go on, unless --yes was specified.
+func (c *Selectable) ConfirmSelection(cxt *Context, question string, resources []types.NamespacedName) (bool, error) {
+	for _, resource := range resources {
+		fmt.Fprintf(cxt.Output, "  %s\n", resource)
+	}
+	if c.Yes {
+		return true, nil
+	}
+	return prompt.New(cxt.Input, cxt.Output).Confirm(question, false)
+}
@@ -68,19 +68,27 @@ func (c *Waitable) ApplyWaitFlags() error {
 		return nil
 	}
 
-	if c.rawTimeout != "-1" {
-		timeout, err := time.ParseDuration(c.rawTimeout)
+	var err error
+	c.Timeout, c.Interval, err = parseWaitDurations(c.rawTimeout, c.rawInterval)
+	return err
+}
+
+// parseWaitDurations parses the --timeout and --interval flags. A nil timeout
+// is returned when waiting indefinitely.
+func parseWaitDurations(rawTimeout, rawInterval string) (*time.Duration, time.Duration, error) {
+	var timeout *time.Duration
+	if rawTimeout != "-1" {
+		t, err := time.ParseDuration(rawTimeout)
 		if err != nil {
-			return fmt.Errorf("invalid --timeout value (%s)", err)
+			return nil, 0, fmt.Errorf("invalid --timeout value (%s)", err)
 		}
-		c.Timeout = &timeout
+		timeout = &t
 	}
 
-	interval, err := time.ParseDuration(c.rawInterval)
+	interval, err := time.ParseDuration(rawInterval)
 	if err != nil {
-		return fmt.Errorf("invalid --interval value (%s)", err)
+		return nil, 0, fmt.Errorf("invalid --interval value (%s)", err)
 	}
-	c.Interval = interval
 
-	return nil
+	return timeout, interval, nil
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// HasWaitForFlags represents a command that waits for a condition.
+type HasWaitForFlags interface {
+	// ApplyWaitForFlags validates and persists the wait for related flags.
+	//   --for
+	//   --timeout
+	//   --interval
+	ApplyWaitForFlags() error
+}
+
+// WaitFor adds support to a command for the flags of svcat wait.
+type WaitFor struct {
+	rawCondition string
+	Condition    servicecatalog.WaitCondition
+	rawTimeout   string
+	Timeout      *time.Duration
+	rawInterval  string
+	Interval     time.Duration
+}
+
+// NewWaitFor initializes a new command that waits for a condition.
+func NewWaitFor() *WaitFor {
+	return &WaitFor{}
+}
+
+// AddWaitForFlags adds the wait for related flags.
+//   --for
+//   --timeout
+//   --interval
+func (c *WaitFor) AddWaitForFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&c.rawCondition, "for", servicecatalog.WaitForReady,
+		"The condition to wait for: ready, failed, deleted or condition=TYPE")
+	cmd.Flags().StringVar(&c.rawTimeout, "timeout", "5m",
+		"Timeout, specified in human readable format: 30s, 1m, 1h. Specify -1 to wait indefinitely.")
+	cmd.Flags().StringVar(&c.rawInterval, "interval", "1s",
+		"Poll interval, specified in human readable format: 30s, 1m, 1h")
+}
+
+// ApplyWaitForFlags validates and persists the wait for related flags.
+//   --for
+//   --timeout
+//   --interval
+func (c *WaitFor) ApplyWaitForFlags() error {
+	var err error
+	c.Condition, err = servicecatalog.ParseWaitCondition(c.rawCondition)
+	if err != nil {
+		return fmt.Errorf("invalid --for value (%s)", err)
+	}
+
+	c.Timeout, c.Interval, err = parseWaitDurations(c.rawTimeout, c.rawInterval)
+	return err
+}
+
+// WaitError converts an error returned while waiting for a condition into
+// an error with the matching exit code.
+func (c *WaitFor) WaitError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case servicecatalog.IsTimeout(err):
+		return &ExitError{
+			Code: ExitCodeTimeout,
+			Err:  fmt.Errorf("timed out after %s waiting for %s", c.rawTimeout, c.Condition),
+		}
+	case servicecatalog.IsFailed(err):
+		return &ExitError{Code: ExitCodeFailed, Err: err}
+	}
+	return err
+}
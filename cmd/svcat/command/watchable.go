@@ -0,0 +1,87 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Watchable adds support to a command for the --watch flag.
+type Watchable struct {
+	Watch bool
+}
+
+// NewWatchable initializes a new watchable command.
+func NewWatchable() *Watchable {
+	return &Watchable{}
+}
+
+// IsWatching returns true when --watch was set.
+func (c *Watchable) IsWatching() bool {
+	return c.Watch
+}
+
+// AddWatchFlags adds the watch related flags.
+//   --watch
+func (c *Watchable) AddWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&c.Watch, "watch", "w", false,
+		"After printing the requested resources, watch for changes to them.")
+}
+
+// WatchChanges passes each change received from the watch to handle, until
+// the watch is closed. The listed objects were already printed, so the
+// events replaying their current state when the watch starts are skipped.
+func (c *Watchable) WatchChanges(w watch.Interface, listed []runtime.Object, handle func(watch.Event)) error {
+	defer w.Stop()
+
+	seen := make(map[types.UID]string)
+	for _, obj := range listed {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			seen[accessor.GetUID()] = accessor.GetResourceVersion()
+		}
+	}
+
+	for event := range w.ResultChan() {
+		if event.Type == watch.Error {
+			return fmt.Errorf("watch failed (%s)", apierrors.FromObject(event.Object))
+		}
+
+		accessor, err := meta.Accessor(event.Object)
+		if err != nil {
+			continue
+		}
+		uid := accessor.GetUID()
+		if version, ok := seen[uid]; ok && version == accessor.GetResourceVersion() {
+			continue
+		}
+		if event.Type == watch.Deleted {
+			delete(seen, uid)
+		} else {
+			seen[uid] = accessor.GetResourceVersion()
+		}
+
+		handle(event)
+	}
+	return nil
+}
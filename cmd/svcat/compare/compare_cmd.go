@@ -0,0 +1,103 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compare
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type compareCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	onlyDifferences bool
+	outputFormat    string
+}
+
+func (c *compareCmd) SetFormat(format string) {
+	c.outputFormat = format
+}
+
+// NewCompareCmd builds a "svcat compare" command
+func NewCompareCmd(cxt *command.Context) *cobra.Command {
+	compareCmd := &compareCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the brokers and classes available in several clusters",
+		Long: `Compare the brokers and classes available in the clusters of the kubeconfig
+contexts selected with --contexts, showing the status of each broker and class
+in every context, or - when it is missing from a context.`,
+		Example: command.NormalizeExamples(`
+  svcat compare --contexts staging,production
+  svcat compare --contexts staging,production --only-differences
+  svcat compare --contexts staging,production --scope cluster -o json
+`),
+		PreRunE: command.PreRunE(compareCmd),
+		RunE:    command.RunE(compareCmd),
+	}
+	cmd.Flags().BoolVar(&compareCmd.onlyDifferences, "only-differences", false,
+		"Only show the brokers and classes that are missing or have a different status in some context")
+	compareCmd.AddNamespaceFlags(cmd.Flags(), true)
+	compareCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	command.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *compareCmd) Validate(args []string) error {
+	if len(c.Clusters) < 2 {
+		return fmt.Errorf("at least two kubeconfig contexts are required, select them with --contexts")
+	}
+	return nil
+}
+
+// Run is never called: Validate requires --contexts, which runs the command
+// with Fetch and WriteContexts instead.
+func (c *compareCmd) Run() error {
+	return nil
+}
+
+// Fetch retrieves the brokers and classes from the cluster of a kubeconfig
+// context.
+func (c *compareCmd) Fetch(app *svcat.App) (interface{}, error) {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	brokers, err := app.RetrieveBrokers(opts)
+	if err != nil {
+		return nil, err
+	}
+	classes, err := app.RetrieveClasses(opts)
+	if err != nil {
+		return nil, err
+	}
+	return output.Catalog{Brokers: brokers, Classes: classes}, nil
+}
+
+// WriteContexts prints the comparison of the brokers and classes retrieved
+// from every context.
+func (c *compareCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteCatalogComparison(c.Output, c.outputFormat, results, c.onlyDifferences)
+}
@@ -31,6 +31,11 @@ The shell code must be evaluated to provide interactive
 completion of svcat commands. This can be done by sourcing it from
 the .bash_profile.
 
+Besides commands and flags, the names of instances, bindings, brokers,
+classes and plans are completed from the cluster, honoring the --kubeconfig,
+--context and --namespace flags already typed. Plans are limited to those of
+the class given with --class, or of the instance being updated.
+
 Note: this requires the bash-completion framework, which is not installed
 by default on Mac. This can be installed by using homebrew:
 
@@ -60,6 +65,109 @@ source $HOME/.bash_profile
 `)
 )
 
+// bashCompletionFunction completes the names of resources from the cluster,
+// by calling svcat __names with the flags selecting the cluster and namespace
+// that were already typed.
+const bashCompletionFunction = `
+__svcat_override_flag_list=(--kubeconfig --context --config --namespace -n)
+__svcat_override_flags()
+{
+    local ${__svcat_override_flag_list[*]##*-} two_word_of of var
+    for w in "${words[@]}"; do
+        if [ -n "${two_word_of}" ]; then
+            eval "${two_word_of##*-}=\"${two_word_of}=\${w}\""
+            two_word_of=
+            continue
+        fi
+        for of in "${__svcat_override_flag_list[@]}"; do
+            case "${w}" in
+                ${of}=*)
+                    eval "${of##*-}=\"${w}\""
+                    ;;
+                ${of})
+                    two_word_of="${of}"
+                    ;;
+            esac
+        done
+    done
+    for var in "${__svcat_override_flag_list[@]##*-}"; do
+        if eval "test -n \"\$${var}\""; then
+            eval "echo \${${var}}"
+        fi
+    done
+}
+
+__svcat_get_names()
+{
+    local svcat_out
+    if svcat_out=$(svcat __names "$@" $(__svcat_override_flags) 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${svcat_out[*]}" -- "$cur" ) )
+    fi
+}
+
+__svcat_get_bindings()
+{
+    __svcat_get_names bindings
+}
+
+__svcat_get_classes()
+{
+    __svcat_get_names classes
+}
+
+# Completes the plans of the class given with --class, or of the class of
+# the instance being updated.
+__svcat_get_plans()
+{
+    local w prev_w class
+    for w in "${words[@]}"; do
+        case "${w}" in
+            --class=*)
+                class="${w#--class=}"
+                ;;
+        esac
+        if [[ "${prev_w}" == "--class" ]]; then
+            class="${w}"
+        fi
+        prev_w="${w}"
+    done
+
+    if [[ -n "${class}" ]]; then
+        __svcat_get_names plans --class "${class}"
+    elif [[ ${last_command} == "svcat_update_instance" && ${#nouns[@]} -gt 0 ]]; then
+        __svcat_get_names plans --instance "${nouns[0]}"
+    else
+        __svcat_get_names plans
+    fi
+}
+
+__custom_func()
+{
+    if [[ ${#nouns[@]} -ne 0 ]]; then
+        return
+    fi
+    case ${last_command} in
+        svcat_bind | svcat_deprovision | svcat_unbind | svcat_get_instances | svcat_describe_instance | \
+        svcat_diagnose_instance | svcat_export_instance | svcat_touch_instance | svcat_tree_instance | \
+        svcat_update_instance | svcat_wait_instance)
+            __svcat_get_names instances
+            ;;
+        svcat_credentials | svcat_get_bindings | svcat_describe_binding | svcat_diagnose_binding | svcat_wait_binding)
+            __svcat_get_names bindings
+            ;;
+        svcat_deregister | svcat_get_brokers | svcat_describe_broker | svcat_sync_broker | svcat_tree_broker | svcat_wait_broker)
+            __svcat_get_names brokers
+            ;;
+        svcat_get_classes | svcat_describe_class)
+            __svcat_get_names classes
+            ;;
+        svcat_get_plans | svcat_describe_plan | svcat_explain_plan)
+            __svcat_get_plans
+            ;;
+    esac
+}
+`
+
 var (
 	completionShells = map[string]func(w io.Writer, cmd *cobra.Command) error{
 		"bash": runCompletionBash,
@@ -119,7 +227,15 @@ func (c *completionCmd) Run() error {
 }
 
 func runCompletionBash(w io.Writer, cmd *cobra.Command) error {
-	return cmd.Root().GenBashCompletion(w)
+	return genBashCompletion(w, cmd)
+}
+
+// genBashCompletion generates the bash completion code of svcat, including
+// the completion of resource names.
+func genBashCompletion(w io.Writer, cmd *cobra.Command) error {
+	root := cmd.Root()
+	root.BashCompletionFunction = bashCompletionFunction
+	return root.GenBashCompletion(w)
 }
 
 func runCompletionZsh(out io.Writer, cmd *cobra.Command) error {
@@ -261,7 +377,7 @@ __svcat_convert_bash_to_zsh() {
 	out.Write([]byte(zshInitialization))
 
 	buf := new(bytes.Buffer)
-	cmd.Root().GenBashCompletion(buf)
+	genBashCompletion(buf, cmd)
 	out.Write(buf.Bytes())
 
 	zshTail := `
@@ -0,0 +1,190 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// Bash functions completing the names of resources, for use with
+// cobra.Command.MarkFlagCustom.
+const (
+	// BindingNames completes the names of the bindings in the namespace.
+	BindingNames = "__svcat_get_bindings"
+
+	// ClassNames completes the names of the classes.
+	ClassNames = "__svcat_get_classes"
+
+	// PlanNames completes the names of the plans of the class given with
+	// --class, or of the class of the instance being updated.
+	PlanNames = "__svcat_get_plans"
+)
+
+// namesKinds are the kinds of resources whose names are completed.
+var namesKinds = []string{"bindings", "brokers", "classes", "instances", "plans"}
+
+type namesCmd struct {
+	*command.Namespaced
+	kind     string
+	class    string
+	instance string
+}
+
+// NewNamesCmd builds the hidden "svcat __names" command, which the shell
+// completion code calls to complete the names of resources.
+func NewNamesCmd(cxt *command.Context) *cobra.Command {
+	namesCmd := &namesCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:     "__names KIND",
+		Short:   "List the names of resources, one per line, for shell completion",
+		Hidden:  true,
+		PreRunE: command.PreRunE(namesCmd),
+		RunE:    command.RunE(namesCmd),
+	}
+	namesCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&namesCmd.class, "class", "",
+		"Only list the plans of the class with this name")
+	cmd.Flags().StringVar(&namesCmd.instance, "instance", "",
+		"Only list the plans of the class of the instance with this name")
+	return cmd
+}
+
+func (c *namesCmd) Validate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("a kind is required")
+	}
+	c.kind = args[0]
+	for _, kind := range namesKinds {
+		if c.kind == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid kind (%s), allowed values are: bindings, brokers, classes, instances and plans", c.kind)
+}
+
+func (c *namesCmd) Run() error {
+	names, err := c.names()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fmt.Fprintln(c.Output, name)
+	}
+	return nil
+}
+
+func (c *namesCmd) names() ([]string, error) {
+	var names []string
+	opts := servicecatalog.ScopeOptions{
+		Scope:     servicecatalog.AllScope,
+		Namespace: c.Namespace,
+	}
+
+	switch c.kind {
+	case "bindings":
+		bindings, err := c.App.RetrieveBindings(c.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range bindings.Items {
+			names = append(names, b.Name)
+		}
+	case "brokers":
+		brokers, err := c.App.RetrieveBrokers(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range brokers {
+			names = append(names, b.GetName())
+		}
+	case "classes":
+		classes, err := c.App.RetrieveClasses(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, class := range classes {
+			names = append(names, class.GetExternalName())
+		}
+	case "instances":
+		instances, err := c.App.RetrieveInstances(c.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range instances.Items {
+			names = append(names, i.Name)
+		}
+	case "plans":
+		filter, err := c.planFilter(opts)
+		if err != nil {
+			return nil, err
+		}
+		plans, err := c.App.RetrievePlans(filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range plans {
+			names = append(names, p.GetExternalName())
+		}
+	}
+	return unique(names), nil
+}
+
+// planFilter selects the plans of the class given with --class, or of the
+// class of the instance given with --instance.
+func (c *namesCmd) planFilter(opts servicecatalog.ScopeOptions) (*servicecatalog.FilterOptions, error) {
+	if c.class != "" {
+		class, err := c.App.RetrieveClassByName(c.class, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &servicecatalog.FilterOptions{ClassID: class.GetName()}, nil
+	}
+
+	if c.instance != "" {
+		instance, err := c.App.RetrieveInstance(c.Namespace, c.instance)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case instance.Spec.ClusterServiceClassRef != nil:
+			return &servicecatalog.FilterOptions{ClassID: instance.Spec.ClusterServiceClassRef.Name}, nil
+		case instance.Spec.ServiceClassRef != nil:
+			return &servicecatalog.FilterOptions{ClassID: instance.Spec.ServiceClassRef.Name}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// unique removes the duplicates of a sorted list of names, e.g. plans of
+// different classes sharing a name.
+func unique(names []string) []string {
+	sort.Strings(names)
+	result := names[:0]
+	for i, name := range names {
+		if i == 0 || name != names[i-1] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd builds a "svcat config" command
+func NewConfigCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and edit the svcat configuration file",
+		Long: `View and edit the svcat configuration file, ~/.svcat/config.yaml unless
+overridden by $SVCAT_CONFIG or --config.
+
+The configuration holds defaults for the --namespace, --output, --scope,
+--timeout and --interval flags, used when they are not specified, and named
+profiles. A profile selects a kubeconfig context, and optionally a kubeconfig
+file, along with defaults overriding those of the configuration.`,
+		Annotations: offline(),
+	}
+	cmd.AddCommand(newViewCmd(cxt))
+	cmd.AddCommand(newSetCmd(cxt))
+	cmd.AddCommand(newUseProfileCmd(cxt))
+	return cmd
+}
+
+type viewCmd struct {
+	*command.Context
+	outputFormat string
+}
+
+func newViewCmd(cxt *command.Context) *cobra.Command {
+	viewCmd := &viewCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Show the svcat configuration",
+		Example: command.NormalizeExamples(`
+  svcat config view
+  svcat config view -o json
+`),
+		Annotations: offline(),
+		PreRunE:     command.PreRunE(viewCmd),
+		RunE:        command.RunE(viewCmd),
+	}
+	cmd.Flags().StringVarP(&viewCmd.outputFormat, "output", "o", "",
+		"The output format to use. Valid options are yaml or json. If not present, defaults to yaml")
+	return cmd
+}
+
+func (c *viewCmd) Validate(args []string) error {
+	switch c.outputFormat {
+	case "":
+		c.outputFormat = "yaml"
+	case "yaml", "json":
+	default:
+		return fmt.Errorf("invalid --output format %q, allowed values are yaml and json", c.outputFormat)
+	}
+	return nil
+}
+
+func (c *viewCmd) Run() error {
+	output.WriteConfig(c.Output, c.outputFormat, c.Config)
+	return nil
+}
+
+type setCmd struct {
+	*command.Context
+	key   string
+	value string
+}
+
+func newSetCmd(cxt *command.Context) *cobra.Command {
+	setCmd := &setCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "set KEY [VALUE]",
+		Short: "Set a value of the svcat configuration, omit the value to unset it",
+		Long: `Set a value of the svcat configuration, omit the value to unset it.
+
+Keys are either a default: namespace, output, scope, timeout or interval, or
+a value of a profile: profiles.NAME.KEY, where KEY is kubeconfig, context or
+one of the defaults. Setting a value of an undefined profile creates it, and
+unsetting its last value removes it.`,
+		Example: command.NormalizeExamples(`
+  svcat config set namespace dev
+  svcat config set output wide
+  svcat config set profiles.prod.context prod-cluster
+  svcat config set profiles.prod.namespace payments
+  svcat config set profiles.prod.timeout 10m
+  svcat config set namespace
+`),
+		Annotations: offline(),
+		PreRunE:     command.PreRunE(setCmd),
+		RunE:        command.RunE(setCmd),
+	}
+	return cmd
+}
+
+func (c *setCmd) Validate(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("a key and an optional value are required")
+	}
+	c.key = args[0]
+	if len(args) == 2 {
+		c.value = args[1]
+	}
+	return nil
+}
+
+func (c *setCmd) Run() error {
+	if err := c.Config.Set(c.key, c.value); err != nil {
+		return err
+	}
+	if err := c.Config.Save(c.ConfigPath); err != nil {
+		return err
+	}
+	output.WriteConfigUpdated(c.Output, c.ConfigPath)
+	return nil
+}
+
+type useProfileCmd struct {
+	*command.Context
+	name string
+	none bool
+}
+
+func newUseProfileCmd(cxt *command.Context) *cobra.Command {
+	useProfileCmd := &useProfileCmd{Context: cxt}
+	cmd := &cobra.Command{
+		Use:   "use-profile NAME",
+		Short: "Switch to a profile of the svcat configuration",
+		Example: command.NormalizeExamples(`
+  svcat config use-profile prod
+  svcat config use-profile --none
+`),
+		Annotations: offline(),
+		PreRunE:     command.PreRunE(useProfileCmd),
+		RunE:        command.RunE(useProfileCmd),
+	}
+	cmd.Flags().BoolVar(&useProfileCmd.none, "none", false,
+		"Stop using a profile, only the defaults of the configuration apply")
+	return cmd
+}
+
+func (c *useProfileCmd) Validate(args []string) error {
+	if c.none {
+		if len(args) > 0 {
+			return fmt.Errorf("a profile name cannot be used with --none")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("a profile name is required")
+	}
+	c.name = args[0]
+	return nil
+}
+
+func (c *useProfileCmd) Run() error {
+	if err := c.Config.UseProfile(c.name); err != nil {
+		return err
+	}
+	if err := c.Config.Save(c.ConfigPath); err != nil {
+		return err
+	}
+	output.WriteProfileSwitched(c.Output, c.name)
+	return nil
+}
+
+// offline marks a command as not connecting to a cluster.
+func offline() map[string]string {
+	return map[string]string{command.OfflineAnnotation: "true"}
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package instance
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
@@ -29,6 +32,7 @@ import (
 type deprovisonCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Selectable
 
 	instanceName string
 }
@@ -38,23 +42,36 @@ func NewDeprovisionCmd(cxt *command.Context) *cobra.Command {
 	deprovisonCmd := &deprovisonCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Waitable:   command.NewWaitable(),
+		Selectable: command.NewSelectable(),
 	}
 	cmd := &cobra.Command{
 		Use:   "deprovision NAME",
 		Short: "Deletes an instance of a service",
+		Long: `Deletes an instance of a service, or all the instances in the namespace
+matching --selector or selected with --all, after listing them and asking for
+confirmation.`,
 		Example: command.NormalizeExamples(`
   svcat deprovision wordpress-mysql-instance
+  svcat deprovision -l env=dev -n dev --wait
+  svcat deprovision --all -n dev --yes --concurrency 10
 `),
 		PreRunE: command.PreRunE(deprovisonCmd),
 		RunE:    command.RunE(deprovisonCmd),
 	}
 	deprovisonCmd.AddNamespaceFlags(cmd.Flags(), false)
 	deprovisonCmd.AddWaitFlags(cmd)
+	deprovisonCmd.AddSelectorFlags(cmd, "instances")
 
 	return cmd
 }
 
 func (c *deprovisonCmd) Validate(args []string) error {
+	if c.IsBulk() {
+		if len(args) > 0 {
+			return fmt.Errorf("an instance name cannot be used with --selector or --all")
+		}
+		return nil
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("an instance name is required")
 	}
@@ -64,6 +81,9 @@ func (c *deprovisonCmd) Validate(args []string) error {
 }
 
 func (c *deprovisonCmd) Run() error {
+	if c.IsBulk() {
+		return c.deprovisionSelected()
+	}
 	return c.deprovision()
 }
 
@@ -90,3 +110,64 @@ func (c *deprovisonCmd) deprovision() error {
 	}
 	return err
 }
+
+// deprovisionSelected deletes the instances selected with --selector or --all.
+func (c *deprovisonCmd) deprovisionSelected() error {
+	instances, err := c.App.SelectInstances(c.Namespace, c.Selector)
+	if err != nil {
+		return err
+	}
+	if len(instances) == 0 {
+		fmt.Fprintln(c.Output, "No instances found")
+		return nil
+	}
+
+	fmt.Fprintln(c.Output, "The following instances will be deprovisioned:")
+	ok, err := c.ConfirmSelection(c.Context, fmt.Sprintf("Deprovision %d instance(s)?", len(instances)), instances)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("deprovision canceled")
+	}
+
+	// Indicates an error occurred and that a non-zero exit code should be used
+	var hasErrors bool
+
+	deprovisioned, err := c.App.DeprovisionInstances(instances, c.Concurrency)
+	if err != nil {
+		// Do not return immediately as we still need to wait for or print the deprovisioned instances
+		hasErrors = true
+		fmt.Fprintln(c.Output, err)
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the instances to be deleted...")
+		deprovisioned, err = servicecatalog.ForEach(deprovisioned, c.Concurrency, c.waitForDelete)
+		if err != nil {
+			hasErrors = true
+			fmt.Fprintln(c.Output, err)
+		}
+	}
+
+	for _, instance := range deprovisioned {
+		output.WriteDeletedResourceName(c.Output, instance.Name)
+	}
+
+	if hasErrors {
+		return errors.New("could not deprovision all instances")
+	}
+	return nil
+}
+
+// waitForDelete waits for an instance to be deleted.
+func (c *deprovisonCmd) waitForDelete(instance types.NamespacedName) error {
+	result, err := c.App.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
+	if err != nil {
+		return fmt.Errorf("%s: %s", instance, err)
+	}
+	if c.App.IsInstanceFailed(result) {
+		return fmt.Errorf("could not delete instance %s", instance)
+	}
+	return nil
+}
@@ -21,12 +21,19 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/spf13/cobra"
 )
 
 type describeCmd struct {
 	*command.Namespaced
-	name string
+	name         string
+	outputFormat string
+}
+
+func (c *describeCmd) SetFormat(format string) {
+	c.outputFormat = format
 }
 
 // NewDescribeCmd builds a "svcat describe instance" command
@@ -43,6 +50,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -60,18 +68,51 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) describe() error {
-	instance, err := c.App.RetrieveInstance(c.Namespace, c.name)
+	value, err := c.Fetch(c.App)
 	if err != nil {
 		return err
 	}
 
-	output.WriteInstanceDetails(c.Output, instance)
+	c.write(value)
+	return nil
+}
 
-	bindings, err := c.App.RetrieveBindingsByInstance(instance)
+// instanceDetails is an instance along with its bindings.
+type instanceDetails struct {
+	instance *v1beta1.ServiceInstance
+	bindings []v1beta1.ServiceBinding
+}
+
+// Fetch retrieves the instance, along with its bindings when printing a
+// table.
+func (c *describeCmd) Fetch(app *svcat.App) (interface{}, error) {
+	instance, err := app.RetrieveInstance(c.Namespace, c.name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	output.WriteAssociatedBindings(c.Output, bindings)
 
-	return nil
+	if !output.IsTableFormat(c.outputFormat) {
+		return instance, nil
+	}
+
+	bindings, err := app.RetrieveBindingsByInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+	return instanceDetails{instance: instance, bindings: bindings}, nil
+}
+
+func (c *describeCmd) write(value interface{}) {
+	switch v := value.(type) {
+	case *v1beta1.ServiceInstance:
+		output.WriteInstance(c.Output, c.outputFormat, *v)
+	case instanceDetails:
+		output.WriteInstanceDetails(c.Output, v.instance)
+		output.WriteAssociatedBindings(c.Output, v.bindings)
+	}
+}
+
+// WriteContexts prints the instance retrieved from every context.
+func (c *describeCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextDetails(c.Output, c.outputFormat, results, c.write)
 }
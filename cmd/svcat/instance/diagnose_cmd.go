@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type diagnoseCmd struct {
+	*command.Namespaced
+	name          string
+	retryDuration time.Duration
+	outputFormat  string
+}
+
+// NewDiagnoseCmd builds a "svcat diagnose instance" command
+func NewDiagnoseCmd(cxt *command.Context) *cobra.Command {
+	diagnoseCmd := &diagnoseCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Explain why a instance is not ready and what to do about it",
+		Long: `Inspect the conditions, current operation and recent events of a instance,
+along with the state of its class, plan and broker, and explain in plain language
+what is going on and what to do next.`,
+		Example: command.NormalizeExamples(`
+  svcat diagnose instance wordpress-mysql-instance
+  svcat diagnose instance wordpress-mysql-instance --retry-duration 24h
+`),
+		PreRunE: command.PreRunE(diagnoseCmd),
+		RunE:    command.RunE(diagnoseCmd),
+	}
+	diagnoseCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().DurationVar(&diagnoseCmd.retryDuration, "retry-duration", servicecatalog.DefaultReconciliationRetryDuration,
+		"The --reconciliation-retry-duration of the controller manager, after which it stops retrying an operation")
+	cmd.Flags().StringVarP(&diagnoseCmd.outputFormat, "output", "o", "",
+		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table")
+	return cmd
+}
+
+func (c *diagnoseCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	format, err := output.ParseFormat(c.outputFormat)
+	if err != nil || (format != "table" && format != "json" && format != "yaml") {
+		return fmt.Errorf("invalid --output format %q, allowed values are table, json and yaml", c.outputFormat)
+	}
+	c.outputFormat = format
+
+	return nil
+}
+
+func (c *diagnoseCmd) Run() error {
+	opts := servicecatalog.DiagnoseOptions{RetryDuration: c.retryDuration}
+	diagnosis, err := c.App.DiagnoseInstance(c.Namespace, c.name, opts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteDiagnosis(c.Output, c.outputFormat, diagnosis)
+	return nil
+}
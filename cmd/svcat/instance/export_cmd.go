@@ -0,0 +1,74 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type exportCmd struct {
+	*command.Namespaced
+	name         string
+	withBindings bool
+}
+
+// NewExportCmd builds a "svcat export instance" command
+func NewExportCmd(cxt *command.Context) *cobra.Command {
+	exportCmd := &exportCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Export an instance as a manifest",
+		Long: `Export an instance as YAML that can be applied again with svcat apply or kubectl.
+Status, references resolved by the controller, user information and defaulted fields are removed.`,
+		Example: command.NormalizeExamples(`
+  svcat export instance wordpress-mysql-instance
+  svcat export instance wordpress-mysql-instance --with-bindings > wordpress-mysql.yaml
+`),
+		PreRunE: command.PreRunE(exportCmd),
+		RunE:    command.RunE(exportCmd),
+	}
+	exportCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().BoolVar(&exportCmd.withBindings, "with-bindings", false,
+		"Include the bindings of the instance in the manifest")
+	return cmd
+}
+
+func (c *exportCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *exportCmd) Run() error {
+	manifest, err := c.App.ExportInstance(c.Namespace, c.name, c.withBindings)
+	if err != nil {
+		return err
+	}
+
+	out, err := manifest.Marshal()
+	if err != nil {
+		return fmt.Errorf("unable to render the manifest (%s)", err)
+	}
+	_, err = c.Output.Write(out)
+	return err
+}
@@ -19,11 +19,16 @@ package instance
 import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
 	*command.Namespaced
+	*command.Watchable
 	name         string
 	outputFormat string
 }
@@ -34,7 +39,10 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get instances" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Namespaced: command.NewNamespaced(cxt)}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Watchable:  command.NewWatchable(),
+	}
 	cmd := &cobra.Command{
 		Use:     "instances [NAME]",
 		Aliases: []string{"instance", "inst"},
@@ -44,12 +52,14 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get instances --all-namespaces
   svcat get instance wordpress-mysql-instance
   svcat get instance -n ci concourse-postgres-instance
+  svcat get instances --watch
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
 	}
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	command.AddOutputFlags(cmd.Flags())
+	getCmd.AddWatchFlags(cmd)
 	return cmd
 }
 
@@ -76,6 +86,14 @@ func (c *getCmd) getAll() error {
 	}
 
 	output.WriteInstanceList(c.Output, c.outputFormat, instances)
+
+	if c.Watch {
+		listed := make([]runtime.Object, len(instances.Items))
+		for i := range instances.Items {
+			listed[i] = &instances.Items[i]
+		}
+		return c.watch(listed)
+	}
 	return nil
 }
 
@@ -87,5 +105,40 @@ func (c *getCmd) get() error {
 
 	output.WriteInstance(c.Output, c.outputFormat, *instance)
 
+	if c.Watch {
+		return c.watch([]runtime.Object{instance})
+	}
 	return nil
 }
+
+// watch prints the changes to the instances until the watch is closed.
+func (c *getCmd) watch(listed []runtime.Object) error {
+	w, err := c.App.WatchInstances(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	return c.WatchChanges(w, listed, func(event watch.Event) {
+		if instance, ok := event.Object.(*v1beta1.ServiceInstance); ok {
+			output.WriteInstanceEvent(c.Output, c.outputFormat, event.Type, instance)
+		}
+	})
+}
+
+// Fetch retrieves the instances from the cluster of a kubeconfig context.
+func (c *getCmd) Fetch(app *svcat.App) (interface{}, error) {
+	if c.name == "" {
+		return app.RetrieveInstances(c.Namespace)
+	}
+
+	instance, err := app.RetrieveInstance(c.Namespace, c.name)
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta1.ServiceInstanceList{Items: []v1beta1.ServiceInstance{*instance}}, nil
+}
+
+// WriteContexts prints the instances retrieved from every context.
+func (c *getCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextLists(c.Output, c.outputFormat, results)
+}
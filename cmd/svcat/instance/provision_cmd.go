@@ -18,16 +18,20 @@ package instance
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type provisonCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Scoped
 
 	instanceName string
 	externalID   string
@@ -38,6 +42,7 @@ type provisonCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+	interactive  bool
 }
 
 // NewProvisionCmd builds a "svcat provision" command
@@ -45,6 +50,7 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	provisionCmd := &provisonCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Waitable:   command.NewWaitable(),
+		Scoped:     command.NewScoped(),
 	}
 	cmd := &cobra.Command{
 		Use:   "provision NAME --plan PLAN --class CLASS",
@@ -53,6 +59,8 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --scope namespace
+  svcat provision --interactive
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -68,30 +76,54 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 		RunE:    command.RunE(provisionCmd),
 	}
 	provisionCmd.AddNamespaceFlags(cmd.Flags(), false)
+	provisionCmd.AddScopedFlags(cmd.Flags(), servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
 	cmd.Flags().StringVar(&provisionCmd.externalID, "external-id", "",
 		"The ID of the instance for use with the OSB SB API (Optional)")
 	cmd.Flags().StringVar(&provisionCmd.className, "class", "",
-		"The class name (Required)")
-	cmd.MarkFlagRequired("class")
+		"The class name (Required unless --interactive is specified)")
 	cmd.Flags().StringVar(&provisionCmd.planName, "plan", "",
-		"The plan name (Required)")
-	cmd.MarkFlagRequired("plan")
+		"The plan name (Required unless --interactive is specified)")
+	cmd.MarkFlagCustom("class", completion.ClassNames)
+	cmd.MarkFlagCustom("plan", completion.PlanNames)
 	cmd.Flags().StringSliceVarP(&provisionCmd.rawParams, "param", "p", nil,
 		"Additional parameter to use when provisioning the service, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
 	cmd.Flags().StringSliceVarP(&provisionCmd.rawSecrets, "secret", "s", nil,
 		"Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&provisionCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVarP(&provisionCmd.interactive, "interactive", "i", false,
+		"Prompt for the class, plan and parameters of the instance, and confirm before provisioning")
 	provisionCmd.AddWaitFlags(cmd)
 
 	return cmd
 }
 
 func (c *provisonCmd) Validate(args []string) error {
-	if len(args) == 0 {
+	if len(args) > 0 {
+		c.instanceName = args[0]
+	}
+
+	if c.interactive {
+		if c.jsonParams != "" || len(c.rawParams) > 0 || len(c.rawSecrets) > 0 {
+			return fmt.Errorf("--interactive cannot be used with --param, --params-json or --secret")
+		}
+		return nil
+	}
+
+	if c.instanceName == "" {
 		return fmt.Errorf("an instance name is required")
 	}
-	c.instanceName = args[0]
+
+	var missing []string
+	if c.className == "" {
+		missing = append(missing, "class")
+	}
+	if c.planName == "" {
+		missing = append(missing, "plan")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(`required flag(s) "%s" not set`, strings.Join(missing, `", "`))
+	}
 
 	var err error
 
@@ -120,11 +152,17 @@ func (c *provisonCmd) Validate(args []string) error {
 }
 
 func (c *provisonCmd) Run() error {
+	if c.interactive {
+		ok, err := c.promptProvision()
+		if err != nil || !ok {
+			return err
+		}
+	}
 	return c.Provision()
 }
 
 func (c *provisonCmd) Provision() error {
-	instance, err := c.App.Provision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets)
+	instance, err := c.App.Provision(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets, c.Scope)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,319 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/prompt"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// promptProvision asks the user for anything needed to provision the
+// instance that wasn't specified with flags, and then for confirmation
+// before submitting the request.
+func (c *provisonCmd) promptProvision() (bool, error) {
+	p := prompt.New(c.Input, c.Output)
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+
+	for c.instanceName == "" {
+		var err error
+		c.instanceName, err = p.Input("Instance name", "")
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if c.className == "" {
+		classes, err := c.App.RetrieveClasses(opts)
+		if err != nil {
+			return false, err
+		}
+		names := make([]string, 0, len(classes))
+		for _, class := range classes {
+			names = append(names, class.GetExternalName())
+		}
+		sort.Strings(names)
+		c.className, err = p.Select("Class", names, "")
+		if err != nil {
+			return false, err
+		}
+	}
+	class, err := c.App.RetrieveClassByName(c.className, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if c.planName == "" {
+		plans, err := c.App.RetrievePlansByClass(class)
+		if err != nil {
+			return false, err
+		}
+		names := make([]string, 0, len(plans))
+		for _, plan := range plans {
+			names = append(names, plan.GetExternalName())
+		}
+		sort.Strings(names)
+		c.planName, err = p.Select("Plan", names, "")
+		if err != nil {
+			return false, err
+		}
+	}
+	plan, err := c.App.RetrievePlanByClassAndPlanNames(c.className, c.planName, opts)
+	if err != nil {
+		return false, err
+	}
+
+	schema, err := servicecatalog.ParseParameterSchema(plan.GetSpec().ServiceInstanceCreateParameterSchema)
+	if err != nil {
+		return false, err
+	}
+	params, err := c.promptParameters(p, schema)
+	if err != nil {
+		return false, err
+	}
+	c.params = params
+
+	c.secrets, err = c.promptSecrets(p)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(c.Output, "\nEquivalent command:\n  %s\n\n", c.equivalentCommand(params))
+	ok, err := p.Confirm("Provision the instance?", true)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		fmt.Fprintln(c.Output, "Provisioning cancelled")
+	}
+	return ok, nil
+}
+
+// promptParameters asks for a value for each property of the schema, or for
+// free form parameters when the plan doesn't define a schema.
+func (c *provisonCmd) promptParameters(p *prompt.Prompter, schema *servicecatalog.ParameterSchema) (map[string]interface{}, error) {
+	if schema == nil || len(schema.Properties) == 0 {
+		var rawParams []string
+		for {
+			answer, err := p.Input("Parameter (NAME=VALUE, leave blank to finish)", "")
+			if err != nil {
+				return nil, err
+			}
+			if answer == "" {
+				return parameters.ParseVariableAssignments(rawParams)
+			}
+			if _, err := parameters.ParseVariableAssignments([]string{answer}); err != nil {
+				fmt.Fprintln(c.Output, err)
+				continue
+			}
+			rawParams = append(rawParams, answer)
+		}
+	}
+
+	// Ask for the required parameters first
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := schema.IsRequired(names[i]), schema.IsRequired(names[j])
+		if ri != rj {
+			return ri
+		}
+		return names[i] < names[j]
+	})
+
+	params := make(map[string]interface{})
+	for _, name := range names {
+		prop := schema.Properties[name]
+		if prop == nil {
+			continue
+		}
+		required := schema.IsRequired(name)
+		value, err := c.promptParameter(p, name, prop, required)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			params[name] = value
+		}
+	}
+	return params, nil
+}
+
+// promptParameter asks for the value of a single parameter until it is
+// valid. A nil value is returned when an optional parameter is skipped.
+func (c *provisonCmd) promptParameter(p *prompt.Prompter, name string, prop *servicecatalog.ParameterSchema, required bool) (interface{}, error) {
+	var details []string
+	if prop.Type != "" {
+		details = append(details, prop.Type)
+	}
+	if required {
+		details = append(details, "required")
+	}
+	label := name
+	if len(details) > 0 {
+		label = fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+	}
+	if prop.Description != "" {
+		fmt.Fprintf(c.Output, "\n%s\n", prop.Description)
+	}
+
+	def := ""
+	if prop.Default != nil {
+		def = formatParameterValue(prop.Default)
+	}
+
+	for {
+		var answer string
+		var err error
+		if len(prop.Enum) > 0 {
+			options := make([]string, len(prop.Enum))
+			for i, v := range prop.Enum {
+				options[i] = formatParameterValue(v)
+			}
+			answer, err = p.Select(label, options, def)
+		} else {
+			answer, err = p.Input(label, def)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if answer == "" {
+			if !required {
+				return nil, nil
+			}
+			fmt.Fprintf(c.Output, "%s is required\n", name)
+			continue
+		}
+
+		value, err := parseParameterValue(prop, answer)
+		if err == nil {
+			err = prop.ValidateValue(name, value)
+		}
+		if err != nil {
+			fmt.Fprintln(c.Output, err)
+			continue
+		}
+		return value, nil
+	}
+}
+
+// promptSecrets asks for secrets holding additional parameters.
+func (c *provisonCmd) promptSecrets(p *prompt.Prompter) (map[string]string, error) {
+	ok, err := p.Confirm("Add parameters stored in a secret?", false)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var rawSecrets []string
+	for {
+		answer, err := p.Input("Secret (SECRET[KEY], leave blank to finish)", "")
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return parameters.ParseKeyMaps(rawSecrets)
+		}
+		if _, err := parameters.ParseKeyMaps([]string{answer}); err != nil {
+			fmt.Fprintln(c.Output, err)
+			continue
+		}
+		rawSecrets = append(rawSecrets, answer)
+	}
+}
+
+// equivalentCommand builds the non-interactive command that provisions the
+// same instance.
+func (c *provisonCmd) equivalentCommand(params map[string]interface{}) string {
+	args := []string{"svcat", "provision", c.instanceName,
+		"--namespace", c.Namespace,
+		"--class", c.className,
+		"--plan", c.planName,
+	}
+	if c.Scope == servicecatalog.NamespaceScope {
+		args = append(args, "--scope", string(c.Scope))
+	}
+	if c.externalID != "" {
+		args = append(args, "--external-id", c.externalID)
+	}
+	if len(params) > 0 {
+		j, err := json.Marshal(params)
+		if err == nil {
+			args = append(args, "--params-json", "'"+string(j)+"'")
+		}
+	}
+
+	secrets := make([]string, 0, len(c.secrets))
+	for secret, key := range c.secrets {
+		secrets = append(secrets, fmt.Sprintf("%s[%s]", secret, key))
+	}
+	sort.Strings(secrets)
+	for _, secret := range secrets {
+		args = append(args, "--secret", secret)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// parseParameterValue converts an answer to the type of the parameter.
+func parseParameterValue(prop *servicecatalog.ParameterSchema, answer string) (interface{}, error) {
+	switch prop.Type {
+	case "number", "integer":
+		value, err := strconv.ParseFloat(answer, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s (%s)", prop.Type, answer)
+		}
+		return value, nil
+	case "boolean":
+		value, err := strconv.ParseBool(answer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean (%s)", answer)
+		}
+		return value, nil
+	case "object", "array":
+		var value interface{}
+		if err := json.Unmarshal([]byte(answer), &value); err != nil {
+			return nil, fmt.Errorf("invalid %s, must be JSON (%s)", prop.Type, err)
+		}
+		return value, nil
+	}
+	return answer, nil
+}
+
+func formatParameterValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		j, err := json.Marshal(value)
+		if err == nil {
+			return string(j)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -17,36 +17,52 @@ limitations under the License.
 package instance
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/spf13/cobra"
 )
 
 type touchInstanceCmd struct {
 	*command.Namespaced
+	*command.Selectable
 	name string
 }
 
 // NewTouchCommand builds a "svcat touch instance" command.
 func NewTouchCommand(cxt *command.Context) *cobra.Command {
-	touchInstanceCmd := &touchInstanceCmd{Namespaced: command.NewNamespaced(cxt)}
+	touchInstanceCmd := &touchInstanceCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Selectable: command.NewSelectable(),
+	}
 	cmd := &cobra.Command{
 		Use:   "instance",
 		Short: "Touch an instance to make service-catalog try to process the spec again",
 		Long: `Touch instance will increment the updateRequests field on the instance. 
 Then, service catalog will process the instance's spec again. It might do an update, a delete, or 
-nothing.`,
-		Example: command.NormalizeExamples(`svcat touch instance wordpress-mysql-instance --namespace mynamespace`),
+nothing. Use --selector or --all to touch several instances at once.`,
+		Example: command.NormalizeExamples(`
+  svcat touch instance wordpress-mysql-instance --namespace mynamespace
+  svcat touch instance -l team=web --namespace mynamespace --yes
+`),
 		PreRunE: command.PreRunE(touchInstanceCmd),
 		RunE:    command.RunE(touchInstanceCmd),
 	}
 	touchInstanceCmd.AddNamespaceFlags(cmd.Flags(), false)
+	touchInstanceCmd.AddSelectorFlags(cmd, "instances")
 
 	return cmd
 }
 
 func (c *touchInstanceCmd) Validate(args []string) error {
+	if c.IsBulk() {
+		if len(args) > 0 {
+			return fmt.Errorf("an instance name cannot be used with --selector or --all")
+		}
+		return nil
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("an instance name is required")
 	}
@@ -57,5 +73,39 @@ func (c *touchInstanceCmd) Validate(args []string) error {
 
 func (c *touchInstanceCmd) Run() error {
 	const retries = 3
+	if c.IsBulk() {
+		return c.touchSelected(retries)
+	}
 	return c.App.TouchInstance(c.Namespace, c.name, retries)
 }
+
+// touchSelected touches the instances selected with --selector or --all.
+func (c *touchInstanceCmd) touchSelected(retries int) error {
+	instances, err := c.App.SelectInstances(c.Namespace, c.Selector)
+	if err != nil {
+		return err
+	}
+	if len(instances) == 0 {
+		fmt.Fprintln(c.Output, "No instances found")
+		return nil
+	}
+
+	fmt.Fprintln(c.Output, "The following instances will be touched:")
+	ok, err := c.ConfirmSelection(c.Context, fmt.Sprintf("Touch %d instance(s)?", len(instances)), instances)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("touch canceled")
+	}
+
+	touched, err := c.App.TouchInstances(instances, retries, c.Concurrency)
+	for _, instance := range touched {
+		output.WriteTouchedResourceName(c.Output, instance.Name)
+	}
+	if err != nil {
+		fmt.Fprintln(c.Output, err)
+		return errors.New("could not touch all instances")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type treeCmd struct {
+	*command.Namespaced
+	name         string
+	outputFormat string
+}
+
+// NewTreeCmd builds a "svcat tree instance" command
+func NewTreeCmd(cxt *command.Context) *cobra.Command {
+	treeCmd := &treeCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Show the broker, class and plan of an instance with its bindings, secrets and pods",
+		Long: `Show the broker, class and plan of an instance with its bindings, their secrets
+and the pods that consume those secrets, either directly or through a PodPreset.`,
+		Example: command.NormalizeExamples(`
+  svcat tree instance wordpress-mysql-instance
+  svcat tree instance wordpress-mysql-instance -o dot | dot -Tsvg > wordpress.svg
+`),
+		PreRunE: command.PreRunE(treeCmd),
+		RunE:    command.RunE(treeCmd),
+	}
+	treeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVarP(&treeCmd.outputFormat, "output", "o", "tree",
+		"The output format to use. Valid options are tree or dot, for Graphviz")
+	return cmd
+}
+
+func (c *treeCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	if !output.IsTreeFormat(c.outputFormat) {
+		return fmt.Errorf("invalid --output format %q, allowed values are tree and dot", c.outputFormat)
+	}
+
+	return nil
+}
+
+func (c *treeCmd) Run() error {
+	tree, err := c.App.InstanceTree(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	output.WriteTreeFormat(c.Output, c.outputFormat, tree)
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type updateCmd struct {
+	*command.Namespaced
+	*command.Waitable
+	*command.Selectable
+
+	instanceName string
+	planName     string
+	rawParams    []string
+	jsonParams   string
+	params       map[string]interface{}
+	rawSecrets   []string
+	secrets      map[string]string
+}
+
+// NewUpdateCmd builds a "svcat update instance" command
+func NewUpdateCmd(cxt *command.Context) *cobra.Command {
+	updateCmd := &updateCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+		Selectable: command.NewSelectable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Change the plan or parameters of an instance",
+		Long: `Update instance changes the plan and/or the parameters of an existing instance.
+Parameters that are specified replace all of the instance's current parameters.
+Use --selector or --all to make the same change to several instances at once.`,
+		Example: command.NormalizeExamples(`
+  svcat update instance wordpress-mysql-instance --plan premium
+  svcat update instance wordpress-mysql-instance -p sslEnforcement=enabled
+  svcat update instance wordpress-mysql-instance --plan premium -s mysecret[dbparams] --wait
+  svcat update instance -l env=dev --plan basic --yes
+`),
+		PreRunE: command.PreRunE(updateCmd),
+		RunE:    command.RunE(updateCmd),
+	}
+	updateCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&updateCmd.planName, "plan", "",
+		"The name of the plan to switch to")
+	cmd.MarkFlagCustom("plan", completion.PlanNames)
+	cmd.Flags().StringSliceVarP(&updateCmd.rawParams, "param", "p", nil,
+		"Parameter to replace the instance's parameters with, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
+	cmd.Flags().StringSliceVarP(&updateCmd.rawSecrets, "secret", "s", nil,
+		"Parameter, whose value is stored in a secret, to replace the instance's parameters from secrets with, format: SECRET[KEY]")
+	cmd.Flags().StringVar(&updateCmd.jsonParams, "params-json", "",
+		"Parameters to replace the instance's parameters with, provided as a JSON object. Cannot be combined with --param")
+	updateCmd.AddWaitFlags(cmd)
+	updateCmd.AddSelectorFlags(cmd, "instances")
+
+	return cmd
+}
+
+func (c *updateCmd) Validate(args []string) error {
+	if c.IsBulk() {
+		if len(args) > 0 {
+			return fmt.Errorf("an instance name cannot be used with --selector or --all")
+		}
+	} else {
+		if len(args) == 0 {
+			return fmt.Errorf("an instance name is required")
+		}
+		c.instanceName = args[0]
+	}
+
+	var err error
+
+	if c.jsonParams != "" && len(c.rawParams) > 0 {
+		return fmt.Errorf("--params-json cannot be used with --param")
+	}
+
+	if c.jsonParams != "" {
+		c.params, err = parameters.ParseVariableJSON(c.jsonParams)
+		if err != nil {
+			return fmt.Errorf("invalid --params-json value (%s)", err)
+		}
+	} else {
+		c.params, err = parameters.ParseVariableAssignments(c.rawParams)
+		if err != nil {
+			return fmt.Errorf("invalid --param value (%s)", err)
+		}
+	}
+
+	c.secrets, err = parameters.ParseKeyMaps(c.rawSecrets)
+	if err != nil {
+		return fmt.Errorf("invalid --secret value (%s)", err)
+	}
+
+	if c.planName == "" && len(c.params) == 0 && len(c.secrets) == 0 {
+		return fmt.Errorf("nothing to update, specify --plan, --param, --params-json or --secret")
+	}
+
+	return nil
+}
+
+func (c *updateCmd) Run() error {
+	if c.IsBulk() {
+		return c.updateSelected()
+	}
+	return c.Update()
+}
+
+func (c *updateCmd) updateOptions() *servicecatalog.UpdateInstanceOptions {
+	return &servicecatalog.UpdateInstanceOptions{
+		PlanName: c.planName,
+		Params:   c.params,
+		Secrets:  c.secrets,
+	}
+}
+
+func (c *updateCmd) Update() error {
+	const retries = 3
+	instance, err := c.App.UpdateInstance(c.Namespace, c.instanceName, c.updateOptions(), retries)
+	if err != nil {
+		return err
+	}
+
+	if c.Wait {
+		fmt.Fprintln(c.Output, "Waiting for the instance to be updated...")
+		finalInstance, err := c.App.WaitForInstanceGeneration(instance.Namespace, instance.Name, instance.Generation, c.Interval, c.Timeout)
+		if err == nil {
+			instance = finalInstance
+		}
+
+		// Always print the instance because the update did succeed,
+		// and just print any errors that occurred while polling
+		output.WriteInstanceDetails(c.Output, instance)
+		return err
+	}
+
+	output.WriteInstanceDetails(c.Output, instance)
+	return nil
+}
+
+// updateSelected updates the instances selected with --selector or --all.
+func (c *updateCmd) updateSelected() error {
+	const retries = 3
+	selected, err := c.App.SelectInstances(c.Namespace, c.Selector)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(c.Output, "No instances found")
+		return nil
+	}
+
+	fmt.Fprintln(c.Output, "The following instances will be updated:")
+	ok, err := c.ConfirmSelection(c.Context, fmt.Sprintf("Update %d instance(s)?", len(selected)), selected)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("update canceled")
+	}
+
+	// Indicates an error occurred and that a non-zero exit code should be used
+	var hasErrors bool
+
+	instances, err := c.App.UpdateInstances(selected, c.updateOptions(), retries, c.Concurrency)
+	if err != nil {
+		// Do not return immediately as we still need to wait for or print the updated instances
+		hasErrors = true
+		fmt.Fprintln(c.Output, err)
+	}
+
+	if c.Wait && len(instances) > 0 {
+		fmt.Fprintln(c.Output, "Waiting for the instances to be updated...")
+		instances, err = c.waitForUpdates(instances)
+		if err != nil {
+			hasErrors = true
+			fmt.Fprintln(c.Output, err)
+		}
+	}
+
+	list := &v1beta1.ServiceInstanceList{}
+	for _, instance := range instances {
+		list.Items = append(list.Items, *instance)
+	}
+	output.WriteInstanceList(c.Output, "table", list)
+
+	if hasErrors {
+		return errors.New("could not update all instances")
+	}
+	return nil
+}
+
+// waitForUpdates waits for the instances to complete their update, returning
+// the updated instances along with the errors of those that did not.
+func (c *updateCmd) waitForUpdates(instances []*v1beta1.ServiceInstance) ([]*v1beta1.ServiceInstance, error) {
+	generations := map[types.NamespacedName]int64{}
+	names := make([]types.NamespacedName, 0, len(instances))
+	for _, instance := range instances {
+		name := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+		generations[name] = instance.Generation
+		names = append(names, name)
+	}
+
+	var mutex sync.Mutex
+	updated := map[types.NamespacedName]*v1beta1.ServiceInstance{}
+	_, err := servicecatalog.ForEach(names, c.Concurrency, func(name types.NamespacedName) error {
+		instance, err := c.App.WaitForInstanceGeneration(name.Namespace, name.Name, generations[name], c.Interval, c.Timeout)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		if c.App.IsInstanceFailed(instance) {
+			return fmt.Errorf("could not update instance %s", name)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		updated[name] = instance
+		return nil
+	})
+
+	// Keep printing every updated instance, with its latest known state
+	results := make([]*v1beta1.ServiceInstance, 0, len(instances))
+	for i, name := range names {
+		if instance, ok := updated[name]; ok {
+			results = append(results, instance)
+		} else {
+			results = append(results, instances[i])
+		}
+	}
+	return results, err
+}
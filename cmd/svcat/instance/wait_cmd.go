@@ -0,0 +1,73 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type waitCmd struct {
+	*command.Namespaced
+	*command.WaitFor
+	name string
+}
+
+// NewWaitCmd builds a "svcat wait instance" command
+func NewWaitCmd(cxt *command.Context) *cobra.Command {
+	waitCmd := &waitCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		WaitFor:    command.NewWaitFor(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Wait for an instance to meet a condition",
+		Long: `Wait for an instance to become ready, fail, be deleted or have a status condition.
+Exits with 2 when the instance fails instead of meeting the condition, and 3 on timeout.`,
+		Example: command.NormalizeExamples(`
+  svcat wait instance wordpress-mysql-instance
+  svcat wait instance wordpress-mysql-instance --for deleted --timeout 10m
+  svcat wait instance wordpress-mysql-instance --for condition=OrphanMitigation
+`),
+		PreRunE: command.PreRunE(waitCmd),
+		RunE:    command.RunE(waitCmd),
+	}
+	waitCmd.AddNamespaceFlags(cmd.Flags(), false)
+	waitCmd.AddWaitForFlags(cmd)
+	return cmd
+}
+
+func (c *waitCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *waitCmd) Run() error {
+	_, err := c.App.WaitForInstanceCondition(c.Namespace, c.name, c.Condition, c.Interval, c.Timeout)
+	if err != nil {
+		return c.WaitError(err)
+	}
+
+	fmt.Fprintf(c.Output, "instance '%s/%s' met %s\n", c.Namespace, c.name, c.Condition)
+	return nil
+}
@@ -30,8 +30,12 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/broker"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/class"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/compare"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/config"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/instance"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/manifest"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/marketplace"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/plan"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/plugin"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/versions"
@@ -57,7 +61,7 @@ func main() {
 	}
 	cmd := buildRootCommand(cxt)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(command.ExitCode(err))
 	}
 }
 
@@ -70,8 +74,10 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 
 	// root command flags
 	var opts struct {
-		KubeConfig  string
-		KubeContext string
+		KubeConfig   string
+		KubeContext  string
+		KubeContexts []string
+		ConfigFile   string
 	}
 
 	cmd := &cobra.Command{
@@ -83,12 +89,55 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 			if cxt.Output == nil {
 				cxt.Output = cmd.OutOrStdout()
 			}
+			if cxt.Input == nil {
+				cxt.Input = os.Stdin
+			}
 
 			// Initialize flags from kubectl plugin environment variables
 			if plugin.IsPlugin() {
 				plugin.BindEnvironmentVariables(cxt.Viper, cmd)
 			}
 
+			// Load the svcat configuration file if not already configured (by tests)
+			if cxt.Config == nil {
+				if opts.ConfigFile == "" {
+					opts.ConfigFile = command.DefaultConfigPath()
+				}
+				cfg, err := command.LoadConfig(opts.ConfigFile)
+				if err != nil {
+					return err
+				}
+				cxt.Config = cfg
+				cxt.ConfigPath = opts.ConfigFile
+			}
+
+			// Commands managing the configuration do not need a cluster
+			if _, offline := cmd.Annotations[command.OfflineAnnotation]; offline {
+				return nil
+			}
+
+			// Use the kubeconfig context of the current profile, unless one is specified
+			if profile, ok := cxt.Config.Profile(); ok && !plugin.IsPlugin() {
+				if opts.KubeConfig == "" {
+					opts.KubeConfig = profile.Kubeconfig
+				}
+				if opts.KubeContext == "" && len(opts.KubeContexts) == 0 {
+					opts.KubeContext = profile.Context
+				}
+			}
+
+			// Connect to every context selected with --contexts
+			if len(opts.KubeContexts) > 0 && cxt.Clusters == nil {
+				clusters, err := getClusters(opts.KubeConfig, opts.KubeContext, opts.KubeContexts)
+				if err != nil {
+					return err
+				}
+				cxt.Clusters = clusters
+				if cxt.App == nil {
+					cxt.App = clusters[0].App
+				}
+			}
+
 			// Initialize the context if not already configured (by tests)
 			if cxt.App == nil {
 				k8sClient, svcatClient, namespace, err := getClients(opts.KubeConfig, opts.KubeContext)
@@ -113,21 +162,43 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringVar(&opts.KubeContext, "context", "", "name of the kubeconfig context to use.")
+	cmd.PersistentFlags().StringSliceVar(&opts.KubeContexts, "contexts", nil,
+		"comma-separated names of kubeconfig contexts that get, describe and compare commands fan out to.")
 	cmd.PersistentFlags().StringVar(&opts.KubeConfig, "kubeconfig", "", "path to kubeconfig file. Overrides $KUBECONFIG")
+	cmd.PersistentFlags().StringVar(&opts.ConfigFile, "config", "",
+		"path to the svcat configuration file. Overrides $SVCAT_CONFIG, defaults to ~/.svcat/config.yaml")
+	if plugin.IsExecutablePlugin() {
+		plugin.AddExecutablePluginFlags(cmd)
+	}
 
 	cmd.AddCommand(newGetCmd(cxt))
 	cmd.AddCommand(newDescribeCmd(cxt))
+	cmd.AddCommand(newExplainCmd(cxt))
+	cmd.AddCommand(marketplace.NewMarketplaceCmd(cxt))
 	cmd.AddCommand(instance.NewProvisionCmd(cxt))
 	cmd.AddCommand(instance.NewDeprovisionCmd(cxt))
 	cmd.AddCommand(binding.NewBindCmd(cxt))
 	cmd.AddCommand(binding.NewUnbindCmd(cxt))
+	cmd.AddCommand(binding.NewCredentialsCmd(cxt))
+	cmd.AddCommand(binding.NewRunCmd(cxt))
+	cmd.AddCommand(broker.NewRegisterCmd(cxt))
+	cmd.AddCommand(broker.NewDeregisterCmd(cxt))
 	cmd.AddCommand(newSyncCmd(cxt))
 	if !plugin.IsPlugin() {
 		cmd.AddCommand(newInstallCmd(cxt))
 	}
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(newUpdateCmd(cxt))
+	cmd.AddCommand(newWaitCmd(cxt))
+	cmd.AddCommand(newExportCmd(cxt))
+	cmd.AddCommand(newTreeCmd(cxt))
+	cmd.AddCommand(newDiagnoseCmd(cxt))
+	cmd.AddCommand(compare.NewCompareCmd(cxt))
+	cmd.AddCommand(manifest.NewApplyCmd(cxt))
 	cmd.AddCommand(versions.NewVersionCmd(cxt))
+	cmd.AddCommand(config.NewConfigCmd(cxt))
 	cmd.AddCommand(newCompletionCmd(cxt))
+	cmd.AddCommand(completion.NewNamesCmd(cxt))
 
 	return cmd
 }
@@ -171,6 +242,16 @@ func newDescribeCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newExplainCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the parameters of a resource",
+	}
+	cmd.AddCommand(plan.NewExplainCmd(cxt))
+
+	return cmd
+}
+
 func newInstallCmd(cxt *command.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install",
@@ -190,10 +271,83 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newUpdateCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update an existing resource",
+	}
+	cmd.AddCommand(instance.NewUpdateCmd(cxt))
+	return cmd
+}
+
+func newWaitCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a resource to meet a condition",
+	}
+	cmd.AddCommand(binding.NewWaitCmd(cxt))
+	cmd.AddCommand(broker.NewWaitCmd(cxt))
+	cmd.AddCommand(instance.NewWaitCmd(cxt))
+	return cmd
+}
+
+func newExportCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a resource as a manifest that can be applied again",
+	}
+	cmd.AddCommand(instance.NewExportCmd(cxt))
+	return cmd
+}
+
+func newTreeCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show the hierarchy of resources around a broker or an instance",
+	}
+	cmd.AddCommand(broker.NewTreeCmd(cxt))
+	cmd.AddCommand(instance.NewTreeCmd(cxt))
+	return cmd
+}
+
+func newDiagnoseCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Explain why an instance or binding is not ready",
+	}
+	cmd.AddCommand(instance.NewDiagnoseCmd(cxt))
+	cmd.AddCommand(binding.NewDiagnoseCmd(cxt))
+	return cmd
+}
+
 func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
 
+// getClusters connects to each of the kubeconfig contexts.
+func getClusters(kubeConfig, kubeContext string, kubeContexts []string) ([]command.Cluster, error) {
+	if kubeContext != "" {
+		return nil, fmt.Errorf("--context and --contexts cannot be used together")
+	}
+	if plugin.IsPlugin() {
+		return nil, fmt.Errorf("--contexts is not supported when svcat runs as a kubectl plugin")
+	}
+
+	clusters := make([]command.Cluster, 0, len(kubeContexts))
+	for _, name := range kubeContexts {
+		k8sClient, svcatClient, namespace, err := getClients(kubeConfig, name)
+		if err != nil {
+			return nil, err
+		}
+		app, err := svcat.NewApp(k8sClient, svcatClient, namespace)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, command.Cluster{Context: name, App: app})
+	}
+	return clusters, nil
+}
+
 // getClients loads api clients based on the plugin context if present, otherwise the specified kube config.
 func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, err error) {
 	var restConfig *rest.Config
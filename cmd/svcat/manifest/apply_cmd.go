@@ -0,0 +1,155 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type applyCmd struct {
+	*command.Namespaced
+	*command.Waitable
+	filename string
+}
+
+// NewApplyCmd builds a "svcat apply" command
+func NewApplyCmd(cxt *command.Context) *cobra.Command {
+	applyCmd := &applyCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Waitable:   command.NewWaitable(),
+	}
+	cmd := &cobra.Command{
+		Use:   "apply -f FILENAME",
+		Short: "Create or update instances and bindings from a manifest",
+		Long: `Create or update the instances and bindings in a manifest, such as one made by svcat export.
+Instances are applied before bindings. With --wait, the bindings of an instance are only
+created once it is ready, and svcat waits for every resource to be ready before exiting.`,
+		Example: command.NormalizeExamples(`
+  svcat apply -f wordpress-mysql.yaml
+  svcat apply -f wordpress-mysql.yaml --wait
+  svcat export instance wordpress-mysql-instance | svcat apply -n staging -f -
+`),
+		PreRunE: command.PreRunE(applyCmd),
+		RunE:    command.RunE(applyCmd),
+	}
+	applyCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVarP(&applyCmd.filename, "filename", "f", "",
+		"The manifest to apply, or - to read it from stdin")
+	applyCmd.AddWaitFlags(cmd)
+	return cmd
+}
+
+func (c *applyCmd) Validate(args []string) error {
+	if c.filename == "" {
+		return fmt.Errorf("a manifest is required, specify it with --filename")
+	}
+
+	return nil
+}
+
+func (c *applyCmd) Run() error {
+	manifest, err := c.readManifest()
+	if err != nil {
+		return err
+	}
+	if len(manifest.Instances) == 0 && len(manifest.Bindings) == 0 {
+		return fmt.Errorf("no instances or bindings found in %s", c.filename)
+	}
+
+	const retries = 3
+	applied := make(map[string]*v1beta1.ServiceInstance)
+	for i := range manifest.Instances {
+		instance, result, err := c.App.ApplyInstance(&manifest.Instances[i], retries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Output, "instance '%s/%s' %s\n", instance.Namespace, instance.Name, result)
+		applied[instance.Namespace+"/"+instance.Name] = instance
+	}
+
+	for i := range manifest.Bindings {
+		binding := &manifest.Bindings[i]
+		key := binding.Namespace + "/" + binding.Spec.ServiceInstanceRef.Name
+		if instance, ok := applied[key]; ok && c.Wait {
+			if err := c.waitForInstance(instance); err != nil {
+				return err
+			}
+			delete(applied, key)
+		}
+
+		result, status, err := c.App.ApplyBinding(binding)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.Output, "binding '%s/%s' %s\n", result.Namespace, result.Name, status)
+	}
+
+	if !c.Wait {
+		return nil
+	}
+	for _, instance := range applied {
+		if err := c.waitForInstance(instance); err != nil {
+			return err
+		}
+	}
+	for _, binding := range manifest.Bindings {
+		fmt.Fprintf(c.Output, "Waiting for binding '%s/%s' to be ready...\n", binding.Namespace, binding.Name)
+		result, err := c.App.WaitForBinding(binding.Namespace, binding.Name, c.Interval, c.Timeout)
+		if err != nil {
+			return err
+		}
+		if c.App.IsBindingFailed(result) {
+			return fmt.Errorf("binding '%s/%s' failed", binding.Namespace, binding.Name)
+		}
+	}
+	return nil
+}
+
+func (c *applyCmd) readManifest() (*servicecatalog.Manifest, error) {
+	var r io.Reader
+	if c.filename == "-" {
+		r = c.Input
+	} else {
+		f, err := os.Open(c.filename)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read manifest (%s)", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return servicecatalog.ParseManifest(r, c.Namespace)
+}
+
+func (c *applyCmd) waitForInstance(instance *v1beta1.ServiceInstance) error {
+	fmt.Fprintf(c.Output, "Waiting for instance '%s/%s' to be ready...\n", instance.Namespace, instance.Name)
+	result, err := c.App.WaitForInstanceGeneration(instance.Namespace, instance.Name, instance.Generation, c.Interval, c.Timeout)
+	if err != nil {
+		return err
+	}
+	if c.App.IsInstanceFailed(result) {
+		return fmt.Errorf("instance '%s/%s' failed", instance.Namespace, instance.Name)
+	}
+	return nil
+}
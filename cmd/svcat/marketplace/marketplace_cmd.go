@@ -0,0 +1,88 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marketplace
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+type marketplaceCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	filter       servicecatalog.MarketplaceOptions
+	outputFormat string
+}
+
+func (c *marketplaceCmd) SetFormat(format string) {
+	c.outputFormat = format
+}
+
+// NewMarketplaceCmd builds a "svcat marketplace" command
+func NewMarketplaceCmd(cxt *command.Context) *cobra.Command {
+	marketplaceCmd := &marketplaceCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:     "marketplace",
+		Aliases: []string{"m"},
+		Short:   "List the classes and plans that can be provisioned",
+		Long: `List the classes that can be provisioned, each followed by its plans, showing
+whether plans are free and bindable along with the tags and a short description.`,
+		Example: command.NormalizeExamples(`
+  svcat marketplace
+  svcat marketplace --search mysql
+  svcat marketplace --tag database --free-only
+  svcat marketplace --broker asb --scope cluster
+`),
+		PreRunE: command.PreRunE(marketplaceCmd),
+		RunE:    command.RunE(marketplaceCmd),
+	}
+	cmd.Flags().StringVar(&marketplaceCmd.filter.Search, "search", "",
+		"Only show classes and plans with the text in their name, description or tags")
+	cmd.Flags().StringSliceVar(&marketplaceCmd.filter.Tags, "tag", nil,
+		"Only show classes with the tag, may be repeated to require several tags")
+	cmd.Flags().BoolVar(&marketplaceCmd.filter.FreeOnly, "free-only", false,
+		"Only show free plans")
+	cmd.Flags().StringVar(&marketplaceCmd.filter.Broker, "broker", "",
+		"Only show classes provided by the broker")
+	marketplaceCmd.AddNamespaceFlags(cmd.Flags(), true)
+	marketplaceCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	command.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *marketplaceCmd) Validate(args []string) error {
+	return nil
+}
+
+func (c *marketplaceCmd) Run() error {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	offerings, err := c.App.RetrieveMarketplace(c.filter, opts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteMarketplace(c.Output, c.outputFormat, offerings)
+	return nil
+}
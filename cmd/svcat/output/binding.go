@@ -24,6 +24,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func getBindingStatusShort(status v1beta1.ServiceBindingStatus) string {
@@ -36,50 +37,72 @@ func getBindingStatusFull(status v1beta1.ServiceBindingStatus) string {
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
 
-func writeBindingListTable(w io.Writer, bindingList *v1beta1.ServiceBindingList) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+func bindingListHeaders(wide bool) []string {
+	headers := []string{
 		"Name",
 		"Namespace",
 		"Instance",
 		"Status",
-	})
+	}
+	if wide {
+		headers = append(headers, "Secret", "External ID", "Last Operation")
+	}
+	return headers
+}
+
+func writeBindingListTable(w io.Writer, bindingList *v1beta1.ServiceBindingList, wide bool) {
+	t := NewListTable(w)
+	t.SetHeader(bindingListHeaders(wide))
 
 	for _, binding := range bindingList.Items {
-		t.Append([]string{
-			binding.Name,
-			binding.Namespace,
-			binding.Spec.ServiceInstanceRef.Name,
-			getBindingStatusShort(binding.Status),
-		})
+		t.Append(bindingListRow(binding, wide))
 	}
 	t.Render()
 }
 
+func bindingListRow(binding v1beta1.ServiceBinding, wide bool) []string {
+	row := []string{
+		binding.Name,
+		binding.Namespace,
+		binding.Spec.ServiceInstanceRef.Name,
+		getBindingStatusShort(binding.Status),
+	}
+	if wide {
+		row = append(row,
+			binding.Spec.SecretName,
+			binding.Spec.ExternalID,
+			stringValue(binding.Status.LastOperation),
+		)
+	}
+	return row
+}
+
 // WriteBindingList prints a list of bindings in the specified output format.
 func WriteBindingList(w io.Writer, outputFormat string, bindingList *v1beta1.ServiceBindingList) {
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, bindingList)
-	case formatYAML:
-		writeYAML(w, bindingList, 0)
-	case formatTable:
-		writeBindingListTable(w, bindingList)
+	case formatTable, formatWide:
+		writeBindingListTable(w, bindingList, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, bindingList)
 	}
 }
 
+// WriteBindingEvent prints a change to a binding, received while watching
+// bindings, in the specified output format.
+func WriteBindingEvent(w io.Writer, outputFormat string, eventType watch.EventType, binding *v1beta1.ServiceBinding) {
+	writeEvent(w, outputFormat, eventType, binding, bindingListRow(*binding, outputFormat == formatWide), 3)
+}
+
 // WriteBinding prints a single bindings in the specified output format.
 func WriteBinding(w io.Writer, outputFormat string, binding v1beta1.ServiceBinding) {
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, binding)
-	case formatYAML:
-		writeYAML(w, binding, 0)
-	case formatTable:
+	case formatTable, formatWide:
 		l := v1beta1.ServiceBindingList{
 			Items: []v1beta1.ServiceBinding{binding},
 		}
-		writeBindingListTable(w, &l)
+		writeBindingListTable(w, &l, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, binding)
 	}
 }
 
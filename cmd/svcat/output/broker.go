@@ -21,89 +21,131 @@ import (
 	"io"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
-func getBrokerStatusCondition(status v1beta1.ClusterServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
+func getBrokerStatusCondition(status v1beta1.CommonServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
 	if len(status.Conditions) > 0 {
 		return status.Conditions[len(status.Conditions)-1]
 	}
 	return v1beta1.ServiceBrokerCondition{}
 }
 
-func getBrokerStatusShort(status v1beta1.ClusterServiceBrokerStatus) string {
+func getBrokerStatusShort(status v1beta1.CommonServiceBrokerStatus) string {
 	lastCond := getBrokerStatusCondition(status)
 	return formatStatusShort(string(lastCond.Type), lastCond.Status, lastCond.Reason)
 }
 
-func getBrokerStatusFull(status v1beta1.ClusterServiceBrokerStatus) string {
+func getBrokerStatusFull(status v1beta1.CommonServiceBrokerStatus) string {
 	lastCond := getBrokerStatusCondition(status)
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
 
-func writeBrokerListTable(w io.Writer, brokers []v1beta1.ClusterServiceBroker) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+func brokerListHeaders(wide bool) []string {
+	headers := []string{
 		"Name",
+		"Namespace",
 		"URL",
 		"Status",
-	})
+	}
+	if wide {
+		headers = append(headers, "Relist Behavior", "Last Catalog Retrieval")
+	}
+	return headers
+}
+
+func writeBrokerListTable(w io.Writer, brokers []svcatsdk.Broker, wide bool) {
+	t := NewListTable(w)
+	t.SetHeader(brokerListHeaders(wide))
 	for _, broker := range brokers {
-		t.Append([]string{
-			broker.Name,
-			broker.Spec.URL,
-			getBrokerStatusShort(broker.Status),
-		})
+		t.Append(brokerListRow(broker, wide))
 	}
 	t.Render()
 }
 
+func brokerListRow(broker svcatsdk.Broker, wide bool) []string {
+	row := []string{
+		broker.GetName(),
+		broker.GetNamespace(),
+		broker.GetURL(),
+		getBrokerStatusShort(broker.GetStatus()),
+	}
+	if wide {
+		retrieved := ""
+		if t := broker.GetStatus().LastCatalogRetrievalTime; t != nil {
+			retrieved = t.UTC().String()
+		}
+		row = append(row, string(broker.GetSpec().RelistBehavior), retrieved)
+	}
+	return row
+}
+
 // WriteBrokerList prints a list of brokers in the specified output format.
-func WriteBrokerList(w io.Writer, outputFormat string, brokers ...v1beta1.ClusterServiceBroker) {
-	l := v1beta1.ClusterServiceBrokerList{
-		Items: brokers,
+func WriteBrokerList(w io.Writer, outputFormat string, brokers ...svcatsdk.Broker) {
+	l := list{
+		Items: append([]svcatsdk.Broker{}, brokers...),
 	}
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, l)
-	case formatYAML:
-		writeYAML(w, l, 0)
-	case formatTable:
-		writeBrokerListTable(w, brokers)
+	case formatTable, formatWide:
+		writeBrokerListTable(w, brokers, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, l)
 	}
 }
 
+// WriteBrokerEvent prints a change to a broker, received while watching
+// brokers, in the specified output format.
+func WriteBrokerEvent(w io.Writer, outputFormat string, eventType watch.EventType, broker svcatsdk.Broker) {
+	writeEvent(w, outputFormat, eventType, broker, brokerListRow(broker, outputFormat == formatWide), 3)
+}
+
 // WriteBroker prints a broker in the specified output format.
-func WriteBroker(w io.Writer, outputFormat string, broker v1beta1.ClusterServiceBroker) {
+func WriteBroker(w io.Writer, outputFormat string, broker svcatsdk.Broker) {
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, broker)
-	case formatYAML:
-		writeYAML(w, broker, 0)
-	case formatTable:
-		writeBrokerListTable(w, []v1beta1.ClusterServiceBroker{broker})
+	case formatTable, formatWide:
+		writeBrokerListTable(w, []svcatsdk.Broker{broker}, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, broker)
 	}
 }
 
 // WriteParentBroker prints identifying information for a parent broker.
-func WriteParentBroker(w io.Writer, broker *v1beta1.ClusterServiceBroker) {
+func WriteParentBroker(w io.Writer, broker svcatsdk.Broker) {
 	fmt.Fprintln(w, "\nBroker:")
 	t := NewDetailsTable(w)
-	t.AppendBulk([][]string{
-		{"Name:", broker.Name},
-		{"Status:", getBrokerStatusShort(broker.Status)},
-	})
+	t.Append([]string{"Name:", broker.GetName()})
+	if broker.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", broker.GetNamespace()})
+	}
+	t.Append([]string{"Status:", getBrokerStatusShort(broker.GetStatus())})
 	t.Render()
 }
 
 // WriteBrokerDetails prints details for a single broker.
-func WriteBrokerDetails(w io.Writer, broker *v1beta1.ClusterServiceBroker) {
+func WriteBrokerDetails(w io.Writer, broker svcatsdk.Broker) {
 	t := NewDetailsTable(w)
 
+	t.Append([]string{"Name:", broker.GetName()})
+	if broker.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", broker.GetNamespace()})
+	}
 	t.AppendBulk([][]string{
-		{"Name:", broker.Name},
-		{"URL:", broker.Spec.URL},
-		{"Status:", getBrokerStatusFull(broker.Status)},
+		{"URL:", broker.GetURL()},
+		{"Status:", getBrokerStatusFull(broker.GetStatus())},
 	})
 
 	t.Render()
 }
+
+// WriteRegisteredBroker prints identifying information for a newly
+// registered broker.
+func WriteRegisteredBroker(w io.Writer, broker svcatsdk.Broker) {
+	t := NewDetailsTable(w)
+	t.Append([]string{"Name:", broker.GetName()})
+	if broker.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", broker.GetNamespace()})
+	}
+	t.Append([]string{"URL:", broker.GetURL()})
+	t.Render()
+}
@@ -21,67 +21,85 @@ import (
 	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 )
 
-func getClassStatusText(status v1beta1.ClusterServiceClassStatus) string {
+func getClassStatusText(status v1beta1.CommonServiceClassStatus) string {
 	if status.RemovedFromBrokerCatalog {
 		return statusDeprecated
 	}
 	return statusActive
 }
 
-func writeClassListTable(w io.Writer, classes []v1beta1.ClusterServiceClass) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+func classListHeaders(wide bool) []string {
+	headers := []string{
 		"Name",
+		"Namespace",
 		"Description",
-	})
+	}
+	if wide {
+		headers = append(headers, "External ID", "Broker")
+	}
+	return headers
+}
+
+func classListRow(class svcatsdk.Class, wide bool) []string {
+	row := []string{
+		class.GetExternalName(),
+		class.GetNamespace(),
+		class.GetDescription(),
+	}
+	if wide {
+		row = append(row, class.GetSpec().ExternalID, class.GetServiceBrokerName())
+	}
+	return row
+}
+
+func writeClassListTable(w io.Writer, classes []svcatsdk.Class, wide bool) {
+	t := NewListTable(w)
+	t.SetHeader(classListHeaders(wide))
 	for _, class := range classes {
-		t.Append([]string{
-			class.Spec.ExternalName,
-			class.Spec.Description,
-		})
+		t.Append(classListRow(class, wide))
 	}
 	t.Render()
 }
 
 // WriteClassList prints a list of classes in the specified output format.
-func WriteClassList(w io.Writer, outputFormat string, classes ...v1beta1.ClusterServiceClass) {
-	classList := v1beta1.ClusterServiceClassList{
-		Items: classes,
+func WriteClassList(w io.Writer, outputFormat string, classes ...svcatsdk.Class) {
+	classList := list{
+		Items: append([]svcatsdk.Class{}, classes...),
 	}
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, classList)
-	case formatYAML:
-		writeYAML(w, classList, 0)
-	case formatTable:
-		writeClassListTable(w, classes)
+	case formatTable, formatWide:
+		writeClassListTable(w, classes, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, classList)
 	}
 }
 
 // WriteClass prints a single class in the specified output format.
-func WriteClass(w io.Writer, outputFormat string, class v1beta1.ClusterServiceClass) {
+func WriteClass(w io.Writer, outputFormat string, class svcatsdk.Class) {
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, class)
-	case formatYAML:
-		writeYAML(w, class, 0)
-	case formatTable:
-		writeClassListTable(w, []v1beta1.ClusterServiceClass{class})
+	case formatTable, formatWide:
+		writeClassListTable(w, []svcatsdk.Class{class}, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, class)
 	}
 }
 
 // WriteClassDetails prints details for a single class.
-func WriteClassDetails(w io.Writer, class *v1beta1.ClusterServiceClass) {
+func WriteClassDetails(w io.Writer, class svcatsdk.Class) {
 	t := NewDetailsTable(w)
+	t.Append([]string{"Name:", class.GetExternalName()})
+	if class.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", class.GetNamespace()})
+	}
 	t.AppendBulk([][]string{
-		{"Name:", class.Spec.ExternalName},
-		{"Description:", class.Spec.Description},
-		{"UUID:", string(class.Name)},
-		{"Status:", getClassStatusText(class.Status)},
-		{"Tags:", strings.Join(class.Spec.Tags, ", ")},
-		{"Broker:", class.Spec.ClusterServiceBrokerName},
+		{"Description:", class.GetDescription()},
+		{"UUID:", class.GetName()},
+		{"Status:", getClassStatusText(class.GetStatus())},
+		{"Tags:", strings.Join(class.GetSpec().Tags, ", ")},
+		{"Broker:", class.GetServiceBrokerName()},
 	})
 	t.Render()
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"io"
+	"sort"
+
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// statusMissing marks a broker or class that is not defined in a context.
+const statusMissing = "-"
+
+// Catalog is the brokers and classes of a cluster, compared across kubeconfig
+// contexts by svcat compare.
+type Catalog struct {
+	Brokers []svcatsdk.Broker `json:"brokers"`
+	Classes []svcatsdk.Class  `json:"classes"`
+}
+
+// comparedItem identifies a broker or class in every context.
+type comparedItem struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// WriteCatalogComparison prints which brokers and classes are available in
+// each kubeconfig context. With onlyDifferences, the brokers and classes with
+// the same status everywhere are left out. Contexts that failed are left out,
+// the command reports their errors.
+func WriteCatalogComparison(w io.Writer, outputFormat string, results []ContextResult, onlyDifferences bool) {
+	if !IsTableFormat(outputFormat) {
+		writeContextResults(w, outputFormat, results)
+		return
+	}
+
+	var contexts []string
+	statuses := map[comparedItem]map[string]string{}
+	set := func(item comparedItem, context, status string) {
+		if statuses[item] == nil {
+			statuses[item] = map[string]string{}
+		}
+		statuses[item][context] = status
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		contexts = append(contexts, result.Context)
+		catalog := result.Value.(Catalog)
+		for _, broker := range catalog.Brokers {
+			item := comparedItem{"Broker", broker.GetNamespace(), broker.GetName()}
+			set(item, result.Context, getBrokerStatusShort(broker.GetStatus()))
+		}
+		for _, class := range catalog.Classes {
+			item := comparedItem{"Class", class.GetNamespace(), class.GetExternalName()}
+			set(item, result.Context, getClassStatusText(class.GetStatus()))
+		}
+	}
+
+	items := make([]comparedItem, 0, len(statuses))
+	for item := range statuses {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].kind != items[j].kind {
+			return items[i].kind < items[j].kind
+		}
+		if items[i].namespace != items[j].namespace {
+			return items[i].namespace < items[j].namespace
+		}
+		return items[i].name < items[j].name
+	})
+
+	t := NewListTable(w)
+	t.SetHeader(append([]string{"Kind", "Name", "Namespace"}, contexts...))
+	for _, item := range items {
+		var row []string
+		for _, context := range contexts {
+			status, ok := statuses[item][context]
+			if !ok {
+				status = statusMissing
+			}
+			row = append(row, status)
+		}
+		if onlyDifferences && allEqual(row) {
+			continue
+		}
+		t.Append(append([]string{item.kind, item.name, item.namespace}, row...))
+	}
+	t.Render()
+}
+
+func allEqual(values []string) bool {
+	for _, value := range values {
+		if value != values[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteCatalogComparison(t *testing.T) {
+	class := func(name string, removed bool) svcatsdk.Class {
+		c := &v1beta1.ClusterServiceClass{}
+		c.Spec.ExternalName = name
+		c.Status.RemovedFromBrokerCatalog = removed
+		return c
+	}
+	broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "broker"}}
+	broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{
+		{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue},
+	}
+	results := []ContextResult{
+		{Context: "east", Value: Catalog{
+			Brokers: []svcatsdk.Broker{broker},
+			Classes: []svcatsdk.Class{class("mysql", false), class("redis", false)},
+		}},
+		{Context: "west", Value: Catalog{
+			Brokers: []svcatsdk.Broker{broker},
+			Classes: []svcatsdk.Class{class("mysql", true)},
+		}},
+		{Context: "north", Error: "connection refused"},
+	}
+
+	testcases := []struct {
+		name            string   // Test name
+		onlyDifferences bool     // Only print the differences
+		rows            []string // Expected rows, their columns separated by a single space
+	}{
+		{"All", false, []string{
+			"KIND NAME NAMESPACE EAST WEST",
+			"Broker broker Ready Ready",
+			"Class mysql Active Deprecated",
+			"Class redis Active -",
+		}},
+		{"Only differences", true, []string{
+			"KIND NAME NAMESPACE EAST WEST",
+			"Class mysql Active Deprecated",
+			"Class redis Active -",
+		}},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		WriteCatalogComparison(output, formatTable, results, tc.onlyDifferences)
+
+		var rows []string
+		for _, line := range strings.Split(output.String(), "\n") {
+			if line = strings.Join(strings.Fields(line), " "); line != "" && !strings.HasPrefix(line, "+") {
+				rows = append(rows, line)
+			}
+		}
+		if strings.Join(tc.rows, "\n") != strings.Join(rows, "\n") {
+			t.Errorf("%v: Output mismatch: expected %q, actual %q", tc.name, tc.rows, rows)
+		}
+	}
+}
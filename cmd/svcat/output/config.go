@@ -0,0 +1,48 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteConfig prints the svcat configuration file in the specified output
+// format, yaml or json.
+func WriteConfig(w io.Writer, outputFormat string, config interface{}) {
+	switch outputFormat {
+	case formatJSON:
+		writeJSON(w, config)
+	default:
+		writeYAML(w, config, 0)
+	}
+}
+
+// WriteConfigUpdated prints a confirmation that the configuration file was
+// saved.
+func WriteConfigUpdated(w io.Writer, path string) {
+	fmt.Fprintf(w, "Updated %s\n", path)
+}
+
+// WriteProfileSwitched prints a confirmation that the current profile changed.
+func WriteProfileSwitched(w io.Writer, profile string) {
+	if profile == "" {
+		fmt.Fprintln(w, "No longer using a profile")
+		return
+	}
+	fmt.Fprintf(w, "Switched to profile %q\n", profile)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// ContextResult is what a command retrieved from one kubeconfig context, when
+// it fans out over several contexts with --contexts.
+type ContextResult struct {
+	Context string      `json:"context"`
+	Value   interface{} `json:"value,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// PlanList is a list of plans along with their classes, which name the class
+// column of the plans table.
+type PlanList struct {
+	Items   []svcatsdk.Plan  `json:"items"`
+	Classes []svcatsdk.Class `json:"-"`
+}
+
+// listTable returns the table headers and rows of a list of resources.
+func listTable(value interface{}, wide bool) ([]string, [][]string) {
+	var rows [][]string
+	switch v := value.(type) {
+	case *v1beta1.ServiceInstanceList:
+		for _, instance := range v.Items {
+			rows = append(rows, instanceListRow(instance, wide))
+		}
+		return instanceListHeaders(wide), rows
+	case *v1beta1.ServiceBindingList:
+		for _, binding := range v.Items {
+			rows = append(rows, bindingListRow(binding, wide))
+		}
+		return bindingListHeaders(wide), rows
+	case []svcatsdk.Broker:
+		for _, broker := range v {
+			rows = append(rows, brokerListRow(broker, wide))
+		}
+		return brokerListHeaders(wide), rows
+	case []svcatsdk.Class:
+		for _, class := range v {
+			rows = append(rows, classListRow(class, wide))
+		}
+		return classListHeaders(wide), rows
+	case PlanList:
+		return planListHeaders(wide), planListRows(v.Items, getClassNames(v.Classes), wide)
+	default:
+		return []string{"Error"}, [][]string{{fmt.Sprintf("unexpected list %T", value)}}
+	}
+}
+
+// writeContextResults prints the results of every context in a format that
+// does not depend on the kind of resource.
+func writeContextResults(w io.Writer, outputFormat string, results []ContextResult) {
+	if outputFormat == formatName {
+		for _, result := range results {
+			if result.Error == "" {
+				writeNames(w, result.Value)
+			}
+		}
+		return
+	}
+	writeFormatted(w, outputFormat, list{Items: results})
+}
+
+// WriteContextLists prints the lists of resources retrieved from several
+// kubeconfig contexts, merged into a single table with a context column.
+// Contexts that failed are left out, the command reports their errors.
+func WriteContextLists(w io.Writer, outputFormat string, results []ContextResult) {
+	switch outputFormat {
+	case formatTable, formatWide:
+		t := NewListTable(w)
+		var headers []string
+		for _, result := range results {
+			if result.Error != "" {
+				continue
+			}
+			h, rows := listTable(result.Value, outputFormat == formatWide)
+			headers = h
+			for _, row := range rows {
+				t.Append(append([]string{result.Context}, row...))
+			}
+		}
+		if headers == nil {
+			return
+		}
+		t.SetHeader(append([]string{"Context"}, headers...))
+		t.Render()
+	default:
+		writeContextResults(w, outputFormat, results)
+	}
+}
+
+// WriteContextDetails prints the details of a resource retrieved from several
+// kubeconfig contexts, one context after the other. Tables are printed by
+// writeDetails.
+func WriteContextDetails(w io.Writer, outputFormat string, results []ContextResult, writeDetails func(value interface{})) {
+	if !IsTableFormat(outputFormat) {
+		writeContextResults(w, outputFormat, results)
+		return
+	}
+
+	first := true
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+		fmt.Fprintf(w, "Context: %s\n", result.Context)
+		writeDetails(result.Value)
+	}
+}
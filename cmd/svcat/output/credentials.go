@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+const (
+	credentialsEnv    = "env"
+	credentialsDotenv = "dotenv"
+	credentialsJSON   = "json"
+)
+
+// IsCredentialsFormat returns true when the format is supported by
+// WriteCredentials.
+func IsCredentialsFormat(format string) bool {
+	switch format {
+	case credentialsEnv, credentialsDotenv, credentialsJSON:
+		return true
+	}
+	return false
+}
+
+// sortedKeys returns the keys of the credentials in a stable order.
+func sortedKeys(creds map[string][]byte) []string {
+	keys := make([]string, 0, len(creds))
+	for key := range creds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteShell quotes a value with single quotes so that a POSIX shell reads it
+// verbatim.
+func quoteShell(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// quoteDotenv quotes a value with double quotes, escaping the characters
+// that dotenv parsers interpret.
+func quoteDotenv(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+	return `"` + r.Replace(value) + `"`
+}
+
+// WriteCredentials prints the credentials of a binding as shell exports,
+// a dotenv file or a JSON object.
+func WriteCredentials(w io.Writer, format string, creds map[string][]byte) {
+	switch format {
+	case credentialsJSON:
+		values := make(map[string]string, len(creds))
+		for key, value := range creds {
+			values[key] = string(value)
+		}
+		writeJSON(w, values)
+	case credentialsDotenv:
+		for _, key := range sortedKeys(creds) {
+			fmt.Fprintf(w, "%s=%s\n", svcatsdk.CredentialEnvName(key), quoteDotenv(string(creds[key])))
+		}
+	default:
+		for _, key := range sortedKeys(creds) {
+			fmt.Fprintf(w, "export %s=%s\n", svcatsdk.CredentialEnvName(key), quoteShell(string(creds[key])))
+		}
+	}
+}
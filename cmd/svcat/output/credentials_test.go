@@ -0,0 +1,47 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCredentials(t *testing.T) {
+	creds := map[string][]byte{
+		"db-password": []byte(`it's "$ecret"`),
+		"uri":         []byte("line1\nline2"),
+	}
+
+	testcases := []struct {
+		name   string // Test name
+		format string // Format tested
+		output string // Expected output
+	}{
+		{"Shell exports", credentialsEnv, "export DB_PASSWORD='it'\\''s \"$ecret\"'\nexport URI='line1\nline2'\n"},
+		{"Dotenv", credentialsDotenv, "DB_PASSWORD=\"it's \\\"\\$ecret\\\"\"\nURI=\"line1\\nline2\"\n"},
+		{"JSON", credentialsJSON, "{\n   \"db-password\": \"it's \\\"$ecret\\\"\",\n   \"uri\": \"line1\\nline2\"\n}"},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		WriteCredentials(output, tc.format, creds)
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected %q, actual %q", tc.name, tc.output, output.String())
+		}
+	}
+}
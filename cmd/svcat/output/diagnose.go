@@ -0,0 +1,85 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+func writeDiagnosisText(w io.Writer, d *svcatsdk.Diagnosis) {
+	status := d.Reason
+	if d.Message != "" {
+		status = fmt.Sprintf("%s - %s", d.Reason, d.Message)
+	}
+	t := NewDetailsTable(w)
+	t.AppendBulk([][]string{
+		{"Name:", d.Name},
+		{"Namespace:", d.Namespace},
+		{"Kind:", d.Kind},
+		{"Status:", status},
+	})
+	t.Render()
+
+	fmt.Fprintln(w, "\nDiagnosis:")
+	for _, f := range d.Findings {
+		fmt.Fprintf(w, "  [%s] %s\n", f.Severity, f.Summary)
+		if f.Suggestion != "" {
+			fmt.Fprintf(w, "    -> %s\n", f.Suggestion)
+		}
+	}
+	if !d.HasProblems() {
+		fmt.Fprintln(w, "  No problems found.")
+	}
+
+	fmt.Fprintln(w, "\nEvents:")
+	if len(d.Events) == 0 {
+		fmt.Fprintln(w, "No recent events")
+		return
+	}
+	t = NewListTable(w)
+	t.SetAutoWrapText(false)
+	t.SetHeader([]string{
+		"Type",
+		"Reason",
+		"Count",
+		"Message",
+	})
+	for _, event := range d.Events {
+		t.Append([]string{
+			event.Type,
+			event.Reason,
+			strconv.Itoa(int(event.Count)),
+			event.Message,
+		})
+	}
+	t.Render()
+}
+
+// WriteDiagnosis prints the diagnosis of an instance or binding in the
+// specified output format.
+func WriteDiagnosis(w io.Writer, outputFormat string, d *svcatsdk.Diagnosis) {
+	switch outputFormat {
+	case formatTable, formatWide:
+		writeDiagnosisText(w, d)
+	default:
+		writeFormatted(w, outputFormat, d)
+	}
+}
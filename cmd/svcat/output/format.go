@@ -0,0 +1,289 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	formatWide          = "wide"
+	formatName          = "name"
+	formatJSONPath      = "jsonpath"
+	formatGoTemplate    = "go-template"
+	formatCustomColumns = "custom-columns"
+)
+
+// ParseFormat validates an output format and returns it in its canonical
+// form. The templates of the jsonpath, go-template and custom-columns formats
+// are parsed so that mistakes are reported before any request is made.
+//
+// Valid formats are table, wide, json, yaml, name, jsonpath=TEMPLATE,
+// go-template=TEMPLATE and custom-columns=HEADER:JSONPATH[,HEADER:JSONPATH].
+func ParseFormat(format string) (string, error) {
+	name, arg := splitFormat(format)
+	switch name {
+	case "", formatTable:
+		return formatTable, nil
+	case formatWide, formatJSON, formatYAML, formatName:
+		return name, nil
+	case formatJSONPath, formatGoTemplate, formatCustomColumns:
+		if arg == "" {
+			return "", fmt.Errorf("missing template for --output format %s, use %s=TEMPLATE", name, name)
+		}
+		canonical := name + "=" + arg
+		if _, err := newTemplatePrinter(canonical); err != nil {
+			return "", err
+		}
+		return canonical, nil
+	default:
+		return "", fmt.Errorf("invalid --output format %q, allowed values are table, wide, json, yaml, name, jsonpath=..., go-template=... and custom-columns=...", format)
+	}
+}
+
+// IsTableFormat returns true when the output format is a table, where each
+// kind of resource picks its own columns. No format defaults to a table.
+func IsTableFormat(format string) bool {
+	return format == "" || format == formatTable || format == formatWide
+}
+
+// splitFormat splits a format such as jsonpath={.metadata.name} into its
+// case insensitive name and its argument.
+func splitFormat(format string) (string, string) {
+	parts := strings.SplitN(format, "=", 2)
+	name := strings.ToLower(parts[0])
+	if len(parts) == 1 {
+		return name, ""
+	}
+	return name, parts[1]
+}
+
+// writeFormatted prints a resource, or a list of resources, in any of the
+// output formats that do not depend on the kind of the resource.
+func writeFormatted(w io.Writer, outputFormat string, obj interface{}) {
+	switch outputFormat {
+	case formatJSON:
+		writeJSON(w, obj)
+	case formatYAML:
+		writeYAML(w, obj, 0)
+	case formatName:
+		writeNames(w, obj)
+	default:
+		p, err := newTemplatePrinter(outputFormat)
+		if err != nil {
+			fmt.Fprintf(w, "err parsing output format: %v\n", err)
+			return
+		}
+		if err := p(w, obj); err != nil {
+			fmt.Fprintf(w, "err printing output: %v\n", err)
+		}
+	}
+}
+
+// templatePrinter prints a resource with a user supplied template.
+type templatePrinter func(w io.Writer, obj interface{}) error
+
+func newTemplatePrinter(outputFormat string) (templatePrinter, error) {
+	name, arg := splitFormat(outputFormat)
+	switch name {
+	case formatJSONPath:
+		j := jsonpath.New("output")
+		if err := j.Parse(arg); err != nil {
+			return nil, fmt.Errorf("invalid jsonpath template %q (%s)", arg, err)
+		}
+		return func(w io.Writer, obj interface{}) error {
+			data, err := toGeneric(obj)
+			if err != nil {
+				return err
+			}
+			return j.Execute(w, data)
+		}, nil
+	case formatGoTemplate:
+		t, err := template.New("output").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid go-template %q (%s)", arg, err)
+		}
+		return func(w io.Writer, obj interface{}) error {
+			data, err := toGeneric(obj)
+			if err != nil {
+				return err
+			}
+			return t.Execute(w, data)
+		}, nil
+	case formatCustomColumns:
+		columns, err := parseCustomColumns(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer, obj interface{}) error {
+			return writeCustomColumns(w, columns, obj)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+}
+
+// toGeneric converts a resource to maps and slices keyed by the json field
+// names, which is what templates are written against.
+func toGeneric(obj interface{}) (interface{}, error) {
+	j, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	err = json.Unmarshal(j, &data)
+	return data, err
+}
+
+// customColumn is a column of the custom-columns format.
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// parseCustomColumns parses HEADER:JSONPATH pairs separated by commas. The
+// braces around the jsonpath expressions are optional.
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	var columns []customColumn
+	for _, field := range strings.Split(spec, ",") {
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:JSONPATH", field)
+		}
+		expr := parts[1]
+		if !strings.HasPrefix(expr, "{") {
+			if !strings.HasPrefix(expr, ".") {
+				expr = "." + expr
+			}
+			expr = "{" + expr + "}"
+		}
+		j := jsonpath.New(parts[0]).AllowMissingKeys(true)
+		if err := j.Parse(expr); err != nil {
+			return nil, fmt.Errorf("invalid custom column %q (%s)", field, err)
+		}
+		columns = append(columns, customColumn{header: parts[0], path: j})
+	}
+	return columns, nil
+}
+
+func writeCustomColumns(w io.Writer, columns []customColumn, obj interface{}) error {
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+
+	t := NewListTable(w)
+	var headers []string
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	t.SetHeader(headers)
+	for _, item := range genericItems(data) {
+		var row []string
+		for _, c := range columns {
+			results, err := c.path.FindResults(item)
+			if err != nil {
+				return err
+			}
+			var values []string
+			for _, r := range results {
+				for _, v := range r {
+					values = append(values, fmt.Sprint(v.Interface()))
+				}
+			}
+			if len(values) == 0 {
+				values = []string{"<none>"}
+			}
+			row = append(row, strings.Join(values, ","))
+		}
+		t.Append(row)
+	}
+	t.Render()
+	return nil
+}
+
+// genericItems returns the items of a generic list, or the generic resource
+// itself.
+func genericItems(data interface{}) []interface{} {
+	if m, ok := data.(map[string]interface{}); ok {
+		if items, ok := m["items"].([]interface{}); ok {
+			return items
+		}
+	}
+	return []interface{}{data}
+}
+
+// writeNames prints kind.group/name for a resource, or for each resource in
+// a list.
+func writeNames(w io.Writer, obj interface{}) {
+	var buf bytes.Buffer
+	for _, item := range typedItems(obj) {
+		if offering, ok := item.(svcatsdk.Offering); ok {
+			item = offering.Class
+		}
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			fmt.Fprintf(w, "err getting the name of %T: %v\n", item, err)
+			continue
+		}
+		kind := reflect.Indirect(reflect.ValueOf(item)).Type().Name()
+		fmt.Fprintf(&buf, "%s.%s/%s\n", strings.ToLower(kind), v1beta1.GroupName, accessor.GetName())
+	}
+	w.Write(buf.Bytes())
+}
+
+// typedItems returns the items of a list, which have an Items field, or the
+// resource itself.
+func typedItems(obj interface{}) []interface{} {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() == reflect.Struct {
+		if items := v.FieldByName("Items"); items.IsValid() {
+			if items.Kind() == reflect.Interface {
+				items = items.Elem()
+			}
+			if items.Kind() == reflect.Slice {
+				var results []interface{}
+				for i := 0; i < items.Len(); i++ {
+					item := items.Index(i)
+					if item.Kind() != reflect.Interface && item.Kind() != reflect.Ptr && item.CanAddr() {
+						item = item.Addr()
+					}
+					results = append(results, item.Interface())
+				}
+				return results
+			}
+		}
+	}
+	if v.Kind() == reflect.Struct && reflect.ValueOf(obj).Kind() != reflect.Ptr {
+		// The metadata accessors need a pointer
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return []interface{}{p.Interface()}
+	}
+	return []interface{}{obj}
+}
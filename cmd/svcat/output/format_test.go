@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseFormat(t *testing.T) {
+	testcases := []struct {
+		name    string // Test name
+		format  string // Format tested
+		want    string // Expected canonical format
+		wantErr bool   // Whether the format is rejected
+	}{
+		{"Default", "", formatTable, false},
+		{"Upper case", "JSON", formatJSON, false},
+		{"Wide", "wide", formatWide, false},
+		{"JSONPath", "jsonpath={.metadata.name}", "jsonpath={.metadata.name}", false},
+		{"Missing template", "go-template", "", true},
+		{"Invalid column", "custom-columns=NAME", "", true},
+		{"Unknown format", "xml", "", true},
+	}
+
+	for _, tc := range testcases {
+		got, err := ParseFormat(tc.format)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%v: Format mismatch: expected %q, actual %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestWriteFormatted(t *testing.T) {
+	list := &v1beta1.ServiceInstanceList{
+		Items: []v1beta1.ServiceInstance{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "ns"}},
+		},
+	}
+
+	testcases := []struct {
+		name   string // Test name
+		format string // Format tested
+		output string // Expected output
+	}{
+		{"Name", formatName, "serviceinstance.servicecatalog.k8s.io/foo\nserviceinstance.servicecatalog.k8s.io/bar\n"},
+		{"JSONPath", "jsonpath={.items[*].metadata.name}", "foo bar"},
+		{"Go template", "go-template={{range .items}}{{.metadata.namespace}}/{{.metadata.name}} {{end}}", "ns/foo ns/bar "},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		writeFormatted(output, tc.format, list)
+		if tc.output != output.String() {
+			t.Errorf("%v: Output mismatch: expected %q, actual %q", tc.name, tc.output, output.String())
+		}
+	}
+}
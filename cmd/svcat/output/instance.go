@@ -21,6 +21,7 @@ import (
 	"io"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func getInstanceStatusCondition(status v1beta1.ServiceInstanceStatus) v1beta1.ServiceInstanceCondition {
@@ -40,53 +41,93 @@ func getInstanceStatusShort(status v1beta1.ServiceInstanceStatus) string {
 	return formatStatusShort(string(lastCond.Type), lastCond.Status, lastCond.Reason)
 }
 
-func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceList) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+// getInstanceClass returns the class specified by an instance, whether it
+// is a cluster-scoped or a namespaced class.
+func getInstanceClass(spec v1beta1.ServiceInstanceSpec) string {
+	if spec.ClusterServiceClassSpecified() {
+		return spec.GetSpecifiedClusterServiceClass()
+	}
+	return spec.GetSpecifiedServiceClass()
+}
+
+// getInstancePlan returns the plan specified by an instance, whether it is
+// a cluster-scoped or a namespaced plan.
+func getInstancePlan(spec v1beta1.ServiceInstanceSpec) string {
+	if spec.ClusterServicePlanSpecified() {
+		return spec.GetSpecifiedClusterServicePlan()
+	}
+	return spec.GetSpecifiedServicePlan()
+}
+
+func instanceListHeaders(wide bool) []string {
+	headers := []string{
 		"Name",
 		"Namespace",
 		"Class",
 		"Plan",
 		"Status",
-	})
+	}
+	if wide {
+		headers = append(headers, "External ID", "Last Operation", "Dashboard URL")
+	}
+	return headers
+}
+
+func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceList, wide bool) {
+	t := NewListTable(w)
+	t.SetHeader(instanceListHeaders(wide))
 
 	for _, instance := range instanceList.Items {
-		t.Append([]string{
-			instance.Name,
-			instance.Namespace,
-			instance.Spec.GetSpecifiedClusterServiceClass(),
-			instance.Spec.GetSpecifiedClusterServicePlan(),
-			getInstanceStatusShort(instance.Status),
-		})
+		t.Append(instanceListRow(instance, wide))
 	}
 
 	t.Render()
 }
 
+func instanceListRow(instance v1beta1.ServiceInstance, wide bool) []string {
+	row := []string{
+		instance.Name,
+		instance.Namespace,
+		getInstanceClass(instance.Spec),
+		getInstancePlan(instance.Spec),
+		getInstanceStatusShort(instance.Status),
+	}
+	if wide {
+		row = append(row,
+			instance.Spec.ExternalID,
+			stringValue(instance.Status.LastOperation),
+			stringValue(instance.Status.DashboardURL),
+		)
+	}
+	return row
+}
+
 // WriteInstanceList prints a list of instances.
 func WriteInstanceList(w io.Writer, outputFormat string, instanceList *v1beta1.ServiceInstanceList) {
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, instanceList)
-	case formatYAML:
-		writeYAML(w, instanceList, 0)
-	case formatTable:
-		writeInstanceListTable(w, instanceList)
+	case formatTable, formatWide:
+		writeInstanceListTable(w, instanceList, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, instanceList)
 	}
 }
 
+// WriteInstanceEvent prints a change to an instance, received while
+// watching instances, in the specified output format.
+func WriteInstanceEvent(w io.Writer, outputFormat string, eventType watch.EventType, instance *v1beta1.ServiceInstance) {
+	writeEvent(w, outputFormat, eventType, instance, instanceListRow(*instance, outputFormat == formatWide), 4)
+}
+
 // WriteInstance prints a single instance
 func WriteInstance(w io.Writer, outputFormat string, instance v1beta1.ServiceInstance) {
 	switch outputFormat {
-	case "json":
-		writeJSON(w, instance)
-	case "yaml":
-		writeYAML(w, instance, 0)
-	case "table":
+	case formatTable, formatWide:
 		p := v1beta1.ServiceInstanceList{
 			Items: []v1beta1.ServiceInstance{instance},
 		}
-		writeInstanceListTable(w, &p)
+		writeInstanceListTable(w, &p, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, instance)
 	}
 }
 
@@ -133,8 +174,8 @@ func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 		{"Name:", instance.Name},
 		{"Namespace:", instance.Namespace},
 		{"Status:", getInstanceStatusFull(instance.Status)},
-		{"Class:", instance.Spec.GetSpecifiedClusterServiceClass()},
-		{"Plan:", instance.Spec.GetSpecifiedClusterServicePlan()},
+		{"Class:", getInstanceClass(instance.Spec)},
+		{"Plan:", getInstancePlan(instance.Spec)},
 	})
 	t.Render()
 
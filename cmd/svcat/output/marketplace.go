@@ -0,0 +1,94 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"io"
+	"strings"
+
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+)
+
+// maxMarketplaceDescription is the length after which descriptions are cut
+// short in the marketplace table.
+const maxMarketplaceDescription = 60
+
+func writeMarketplaceTable(w io.Writer, offerings []svcatsdk.Offering) {
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Namespace",
+		"Free",
+		"Bindable",
+		"Tags",
+		"Description",
+	})
+	t.SetAutoWrapText(false)
+	for _, offering := range offerings {
+		class := offering.Class
+		t.Append([]string{
+			class.GetExternalName(),
+			class.GetNamespace(),
+			"",
+			"",
+			strings.Join(class.GetSpec().Tags, ", "),
+			shortDescription(class.GetDescription()),
+		})
+		for _, plan := range offering.Plans {
+			t.Append([]string{
+				"  " + plan.GetExternalName(),
+				"",
+				formatYesNo(plan.GetSpec().Free),
+				formatYesNo(svcatsdk.IsPlanBindable(class, plan)),
+				"",
+				shortDescription(plan.GetDescription()),
+			})
+		}
+	}
+	t.Render()
+}
+
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// shortDescription keeps the first line of a description, cut short to fit
+// in a table.
+func shortDescription(description string) string {
+	description = strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+	if len(description) > maxMarketplaceDescription {
+		description = strings.TrimSpace(description[:maxMarketplaceDescription-3]) + "..."
+	}
+	return description
+}
+
+// WriteMarketplace prints the offerings of the marketplace in the specified
+// output format.
+func WriteMarketplace(w io.Writer, outputFormat string, offerings []svcatsdk.Offering) {
+	marketplace := list{
+		Items: append([]svcatsdk.Offering{}, offerings...),
+	}
+	switch outputFormat {
+	case formatTable, formatWide:
+		writeMarketplaceTable(w, offerings)
+	default:
+		writeFormatted(w, outputFormat, marketplace)
+	}
+}
@@ -23,11 +23,13 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 const (
 	statusActive     = "Active"
 	statusDeprecated = "Deprecated"
+	statusDeleted    = "Deleted"
 )
 
 const (
@@ -53,7 +55,55 @@ func formatStatusFull(condition string, conditionStatus v1beta1.ConditionStatus,
 	return fmt.Sprintf("%s - %s @ %s", status, message, timestamp.UTC())
 }
 
+// list is the serialized form of a list of resources which may mix
+// cluster-scoped and namespaced kinds.
+type list struct {
+	v1.ListMeta `json:"metadata"`
+	Items       interface{} `json:"items"`
+}
+
 // WriteDeletedResourceName prints the name of a deleted resource
 func WriteDeletedResourceName(w io.Writer, resourceName string) {
 	fmt.Fprintf(w, "deleted %s\n", resourceName)
 }
+
+// WriteTouchedResourceName prints the name of a touched resource.
+func WriteTouchedResourceName(w io.Writer, resourceName string) {
+	fmt.Fprintf(w, "touched %s\n", resourceName)
+}
+
+// writeEvent prints a change received while watching resources. Tables get
+// a row without headers, with the status column of deleted resources
+// replaced, and the other formats get a document for each change.
+func writeEvent(w io.Writer, outputFormat string, eventType watch.EventType, obj interface{}, row []string, statusColumn int) {
+	switch outputFormat {
+	case formatJSON:
+		// The previous document isn't terminated by a newline
+		fmt.Fprintln(w)
+		writeJSON(w, obj)
+	case formatYAML:
+		fmt.Fprintln(w, "---")
+		writeYAML(w, obj, 0)
+	case formatTable, formatWide:
+		if eventType == watch.Deleted {
+			row[statusColumn] = statusDeleted
+		}
+		t := NewListTable(w)
+		t.Append(row)
+		t.Render()
+	case formatName:
+		writeFormatted(w, outputFormat, obj)
+	default:
+		writeFormatted(w, outputFormat, obj)
+		// Templates usually don't end with a newline
+		fmt.Fprintln(w)
+	}
+}
+
+// stringValue returns the value of an optional string.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
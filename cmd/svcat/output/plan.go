@@ -17,24 +17,34 @@ limitations under the License.
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/olekukonko/tablewriter"
 )
 
-func getPlanStatusShort(status v1beta1.ClusterServicePlanStatus) string {
+func getPlanStatusShort(status v1beta1.CommonServicePlanStatus) string {
 	if status.RemovedFromBrokerCatalog {
 		return statusDeprecated
 	}
 	return statusActive
 }
 
-// ByAge implements sort.Interface for []Person based on
-// the Age field.
-type byClass []v1beta1.ClusterServicePlan
+// classKey identifies a class by its namespace and name, because cluster
+// and namespaced classes may share the same name (UUID).
+func classKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// byClass implements sort.Interface for []svcatsdk.Plan based on
+// the namespace and name of their class.
+type byClass []svcatsdk.Plan
 
 func (a byClass) Len() int {
 	return len(a)
@@ -43,65 +53,88 @@ func (a byClass) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 func (a byClass) Less(i, j int) bool {
-	return a[i].Spec.ClusterServiceClassRef.Name < a[j].Spec.ClusterServiceClassRef.Name
+	return classKey(a[i].GetNamespace(), a[i].GetClassID()) < classKey(a[j].GetNamespace(), a[j].GetClassID())
 }
 
-func writePlanListTable(w io.Writer, plans []v1beta1.ClusterServicePlan, classNames map[string]string) {
-
-	sort.Sort(byClass(plans))
-
-	t := NewListTable(w)
-	t.SetHeader([]string{
+func planListHeaders(wide bool) []string {
+	headers := []string{
 		"Name",
+		"Namespace",
 		"Class",
 		"Description",
-	})
+	}
+	if wide {
+		headers = append(headers, "External ID", "Broker", "Free")
+	}
+	return headers
+}
+
+// planListRows returns the table rows of plans, sorted by class.
+func planListRows(plans []svcatsdk.Plan, classNames map[string]string, wide bool) [][]string {
+	sort.Sort(byClass(plans))
+
+	rows := make([][]string, 0, len(plans))
 	for _, plan := range plans {
-		t.Append([]string{
-			plan.Spec.ExternalName,
-			classNames[plan.Spec.ClusterServiceClassRef.Name],
-			plan.Spec.Description,
-		})
+		row := []string{
+			plan.GetExternalName(),
+			plan.GetNamespace(),
+			classNames[classKey(plan.GetNamespace(), plan.GetClassID())],
+			plan.GetDescription(),
+		}
+		if wide {
+			row = append(row, plan.GetSpec().ExternalID, plan.GetServiceBrokerName(), formatYesNo(plan.GetSpec().Free))
+		}
+		rows = append(rows, row)
 	}
+	return rows
+}
+
+func writePlanListTable(w io.Writer, plans []svcatsdk.Plan, classNames map[string]string, wide bool) {
+	t := NewListTable(w)
+	t.SetHeader(planListHeaders(wide))
+	t.AppendBulk(planListRows(plans, classNames, wide))
 	t.Render()
 }
 
-// WritePlanList prints a list of plans in the specified output format.
-func WritePlanList(w io.Writer, outputFormat string, plans []v1beta1.ClusterServicePlan, classes []v1beta1.ClusterServiceClass) {
+// getClassNames maps the classes, by namespace and name, to their external
+// names.
+func getClassNames(classes []svcatsdk.Class) map[string]string {
 	classNames := map[string]string{}
 	for _, class := range classes {
-		classNames[class.Name] = class.Spec.ExternalName
+		classNames[classKey(class.GetNamespace(), class.GetName())] = class.GetExternalName()
 	}
-	list := v1beta1.ClusterServicePlanList{
-		Items: plans,
+	return classNames
+}
+
+// WritePlanList prints a list of plans in the specified output format.
+func WritePlanList(w io.Writer, outputFormat string, plans []svcatsdk.Plan, classes []svcatsdk.Class) {
+	classNames := getClassNames(classes)
+	planList := list{
+		Items: append([]svcatsdk.Plan{}, plans...),
 	}
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, list)
-	case formatYAML:
-		writeYAML(w, list, 0)
-	case formatTable:
-		writePlanListTable(w, plans, classNames)
+	case formatTable, formatWide:
+		writePlanListTable(w, plans, classNames, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, planList)
 	}
 }
 
 // WritePlan prints a single plan in the specified output format.
-func WritePlan(w io.Writer, outputFormat string, plan v1beta1.ClusterServicePlan, class v1beta1.ClusterServiceClass) {
+func WritePlan(w io.Writer, outputFormat string, plan svcatsdk.Plan, class svcatsdk.Class) {
 
 	switch outputFormat {
-	case formatJSON:
-		writeJSON(w, plan)
-	case formatYAML:
-		writeYAML(w, plan, 0)
-	case formatTable:
+	case formatTable, formatWide:
 		classNames := map[string]string{}
-		classNames[class.Name] = class.Spec.ExternalName
-		writePlanListTable(w, []v1beta1.ClusterServicePlan{plan}, classNames)
+		classNames[classKey(class.GetNamespace(), class.GetName())] = class.GetExternalName()
+		writePlanListTable(w, []svcatsdk.Plan{plan}, classNames, outputFormat == formatWide)
+	default:
+		writeFormatted(w, outputFormat, plan)
 	}
 }
 
 // WriteAssociatedPlans prints a list of plans associated with a class.
-func WriteAssociatedPlans(w io.Writer, plans []v1beta1.ClusterServicePlan) {
+func WriteAssociatedPlans(w io.Writer, plans []svcatsdk.Plan) {
 	fmt.Fprintln(w, "\nPlans:")
 	if len(plans) == 0 {
 		fmt.Fprintln(w, "No plans defined")
@@ -115,46 +148,50 @@ func WriteAssociatedPlans(w io.Writer, plans []v1beta1.ClusterServicePlan) {
 	})
 	for _, plan := range plans {
 		t.Append([]string{
-			plan.Spec.ExternalName,
-			plan.Spec.Description,
+			plan.GetExternalName(),
+			plan.GetDescription(),
 		})
 	}
 	t.Render()
 }
 
 // WriteParentPlan prints identifying information for a parent class.
-func WriteParentPlan(w io.Writer, plan *v1beta1.ClusterServicePlan) {
+func WriteParentPlan(w io.Writer, plan svcatsdk.Plan) {
 	fmt.Fprintln(w, "\nPlan:")
 	t := NewDetailsTable(w)
 	t.AppendBulk([][]string{
-		{"Name:", plan.Spec.ExternalName},
-		{"UUID:", string(plan.Name)},
-		{"Status:", getPlanStatusShort(plan.Status)},
+		{"Name:", plan.GetExternalName()},
+		{"UUID:", plan.GetName()},
+		{"Status:", getPlanStatusShort(plan.GetStatus())},
 	})
 	t.Render()
 }
 
 // WritePlanDetails prints details for a single plan.
-func WritePlanDetails(w io.Writer, plan *v1beta1.ClusterServicePlan, class *v1beta1.ClusterServiceClass) {
+func WritePlanDetails(w io.Writer, plan svcatsdk.Plan, class svcatsdk.Class) {
 	t := NewDetailsTable(w)
 
+	t.Append([]string{"Name:", plan.GetExternalName()})
+	if plan.GetNamespace() != "" {
+		t.Append([]string{"Namespace:", plan.GetNamespace()})
+	}
 	t.AppendBulk([][]string{
-		{"Name:", plan.Spec.ExternalName},
-		{"Description:", plan.Spec.Description},
-		{"UUID:", string(plan.Name)},
-		{"Status:", getPlanStatusShort(plan.Status)},
-		{"Free:", strconv.FormatBool(plan.Spec.Free)},
-		{"Class:", class.Spec.ExternalName},
+		{"Description:", plan.GetDescription()},
+		{"UUID:", plan.GetName()},
+		{"Status:", getPlanStatusShort(plan.GetStatus())},
+		{"Free:", strconv.FormatBool(plan.GetSpec().Free)},
+		{"Class:", class.GetExternalName()},
 	})
 
 	t.Render()
 }
 
 // WritePlanSchemas prints the schemas for a single plan.
-func WritePlanSchemas(w io.Writer, plan *v1beta1.ClusterServicePlan) {
-	instanceCreateSchema := plan.Spec.ServiceInstanceCreateParameterSchema
-	instanceUpdateSchema := plan.Spec.ServiceInstanceUpdateParameterSchema
-	bindingCreateSchema := plan.Spec.ServiceBindingCreateParameterSchema
+func WritePlanSchemas(w io.Writer, plan svcatsdk.Plan) {
+	spec := plan.GetSpec()
+	instanceCreateSchema := spec.ServiceInstanceCreateParameterSchema
+	instanceUpdateSchema := spec.ServiceInstanceUpdateParameterSchema
+	bindingCreateSchema := spec.ServiceBindingCreateParameterSchema
 
 	if instanceCreateSchema != nil {
 		fmt.Fprintln(w, "\nInstance Create Parameter Schema:")
@@ -171,3 +208,101 @@ func WritePlanSchemas(w io.Writer, plan *v1beta1.ClusterServicePlan) {
 		writeYAML(w, bindingCreateSchema, 2)
 	}
 }
+
+// WriteParameterSchema prints a table of the parameters described by a
+// schema, flattening nested objects into dotted names.
+func WriteParameterSchema(w io.Writer, title string, schema *svcatsdk.ParameterSchema) {
+	fmt.Fprintf(w, "\n%s:\n", title)
+	if schema == nil || len(schema.Properties) == 0 {
+		fmt.Fprintln(w, "No parameters defined")
+		return
+	}
+
+	t := NewListTable(w)
+	t.SetHeader([]string{
+		"Name",
+		"Type",
+		"Required",
+		"Default",
+		"Allowed Values",
+		"Description",
+	})
+	appendParameterRows(t, "", schema)
+	t.Render()
+}
+
+func appendParameterRows(t *tablewriter.Table, prefix string, schema *svcatsdk.ParameterSchema) {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop := schema.Properties[name]
+		if prop == nil {
+			continue
+		}
+		t.Append([]string{
+			prefix + name,
+			formatParameterType(prop),
+			strconv.FormatBool(schema.IsRequired(name)),
+			formatParameterValue(prop.Default),
+			formatParameterEnum(prop.Enum),
+			prop.Description,
+		})
+
+		switch {
+		case len(prop.Properties) > 0:
+			appendParameterRows(t, prefix+name+".", prop)
+		case prop.Items != nil && len(prop.Items.Properties) > 0:
+			appendParameterRows(t, prefix+name+"[].", prop.Items)
+		}
+	}
+}
+
+func formatParameterType(schema *svcatsdk.ParameterSchema) string {
+	if schema.Type == "array" && schema.Items != nil && schema.Items.Type != "" {
+		return "[]" + schema.Items.Type
+	}
+	return schema.Type
+}
+
+func formatParameterValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		j, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(j)
+	}
+	return fmt.Sprint(value)
+}
+
+func formatParameterEnum(values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatParameterValue(v)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// WriteParametersExample prints a skeleton of the parameters described by a
+// schema in the specified format.
+func WriteParametersExample(w io.Writer, outputFormat string, schema *svcatsdk.ParameterSchema) {
+	example := schema.Example()
+	if example == nil {
+		example = map[string]interface{}{}
+	}
+
+	switch outputFormat {
+	case formatJSON:
+		writeJSON(w, example)
+		fmt.Fprintln(w)
+	case formatYAML:
+		writeYAML(w, example, 0)
+	}
+}
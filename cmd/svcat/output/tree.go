@@ -0,0 +1,157 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatsdk "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	formatTree = "tree"
+	formatDot  = "dot"
+)
+
+// treeNode is how a resource in a tree is displayed.
+type treeNode struct {
+	// id uniquely identifies the resource, so that pods consuming several
+	// secrets are drawn once in a graph.
+	id     string
+	name   string
+	status string
+}
+
+func describeTreeNode(obj interface{}) treeNode {
+	switch o := obj.(type) {
+	case svcatsdk.Broker:
+		return newTreeNode("broker", o.GetNamespace(), o.GetName(), o.GetName(), getBrokerStatusShort(o.GetStatus()))
+	case svcatsdk.Class:
+		return newTreeNode("class", o.GetNamespace(), o.GetName(), o.GetExternalName(), getClassStatusText(o.GetStatus()))
+	case svcatsdk.Plan:
+		return newTreeNode("plan", o.GetNamespace(), o.GetName(), o.GetExternalName(), getPlanStatusShort(o.GetStatus()))
+	case *v1beta1.ServiceInstance:
+		return newTreeNode("instance", o.Namespace, o.Name, o.Name, getInstanceStatusShort(o.Status))
+	case *v1beta1.ServiceBinding:
+		return newTreeNode("binding", o.Namespace, o.Name, o.Name, getBindingStatusShort(o.Status))
+	case *corev1.Secret:
+		return newTreeNode("secret", o.Namespace, o.Name, o.Name, "")
+	case *corev1.Pod:
+		return newTreeNode("pod", o.Namespace, o.Name, o.Name, string(o.Status.Phase))
+	default:
+		return newTreeNode("unknown", "", fmt.Sprintf("%T", obj), fmt.Sprintf("%T", obj), "")
+	}
+}
+
+func newTreeNode(kind, namespace, name, displayName, status string) treeNode {
+	label := kind + "/" + displayName
+	if namespace != "" {
+		label = kind + "/" + namespace + "/" + displayName
+	}
+	return treeNode{
+		id:     kind + "/" + namespace + "/" + name,
+		name:   label,
+		status: status,
+	}
+}
+
+func (n treeNode) String() string {
+	if n.status == "" {
+		return n.name
+	}
+	return fmt.Sprintf("%s (%s)", n.name, n.status)
+}
+
+// WriteTree prints a hierarchy of resources as an indented tree.
+func WriteTree(w io.Writer, tree *svcatsdk.Tree) {
+	fmt.Fprintln(w, describeTreeNode(tree.Object))
+	writeTreeChildren(w, tree.Children, "")
+}
+
+func writeTreeChildren(w io.Writer, children []*svcatsdk.Tree, prefix string) {
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s\n", prefix, branch, describeTreeNode(child.Object))
+		writeTreeChildren(w, child.Children, prefix+indent)
+	}
+}
+
+// WriteTreeDot prints a hierarchy of resources as a Graphviz digraph.
+func WriteTreeDot(w io.Writer, tree *svcatsdk.Tree) {
+	fmt.Fprintln(w, "digraph svcat {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box];")
+	written := make(map[string]bool)
+	writeDotNode(w, tree, written)
+	writeDotEdges(w, tree, make(map[string]bool))
+	fmt.Fprintln(w, "}")
+}
+
+func writeDotNode(w io.Writer, tree *svcatsdk.Tree, written map[string]bool) {
+	n := describeTreeNode(tree.Object)
+	if !written[n.id] {
+		written[n.id] = true
+		label := n.name
+		if n.status != "" {
+			label += `\n` + n.status
+		}
+		fmt.Fprintf(w, "  %s [label=%s];\n", dotQuote(n.id), dotQuote(label))
+	}
+	for _, child := range tree.Children {
+		writeDotNode(w, child, written)
+	}
+}
+
+func writeDotEdges(w io.Writer, tree *svcatsdk.Tree, written map[string]bool) {
+	parent := describeTreeNode(tree.Object).id
+	for _, child := range tree.Children {
+		edge := fmt.Sprintf("%s -> %s", dotQuote(parent), dotQuote(describeTreeNode(child.Object).id))
+		if !written[edge] {
+			written[edge] = true
+			fmt.Fprintf(w, "  %s;\n", edge)
+		}
+		writeDotEdges(w, child, written)
+	}
+}
+
+// dotQuote quotes an identifier for Graphviz, leaving \n line breaks intact.
+func dotQuote(s string) string {
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// IsTreeFormat reports whether format is a valid output format for a tree.
+func IsTreeFormat(format string) bool {
+	return format == formatTree || format == formatDot
+}
+
+// WriteTreeFormat prints a hierarchy of resources in the specified output
+// format, tree or dot.
+func WriteTreeFormat(w io.Writer, outputFormat string, tree *svcatsdk.Tree) {
+	switch outputFormat {
+	case formatDot:
+		WriteTreeDot(w, tree)
+	default:
+		WriteTree(w, tree)
+	}
+}
@@ -23,20 +23,31 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type describeCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	lookupByUUID bool
 	showSchemas  bool
 	uuid         string
 	name         string
+	outputFormat string
+}
+
+func (c *describeCmd) SetFormat(format string) {
+	c.outputFormat = format
 }
 
 // NewDescribeCmd builds a "svcat describe plan" command
 func NewDescribeCmd(cxt *command.Context) *cobra.Command {
-	describeCmd := &describeCmd{Context: cxt}
+	describeCmd := &describeCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "plan NAME",
 		Aliases: []string{"plans", "pl"},
@@ -44,6 +55,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat describe plan standard800
   svcat describe plan --uuid 08e4b43a-36bc-447e-a81f-8202b13e339c
+  svcat describe plan standard800 --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -62,6 +74,9 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		true,
 		"Whether or not to show instance and binding parameter schemas",
 	)
+	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	describeCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -84,40 +99,81 @@ func (c *describeCmd) Run() error {
 }
 
 func (c *describeCmd) describe() error {
-	var plan *v1beta1.ClusterServicePlan
-	var err error
-	if c.lookupByUUID {
-		plan, err = c.App.RetrievePlanByID(c.uuid)
-	} else if strings.Contains(c.name, "/") {
-		names := strings.Split(c.name, "/")
-		if len(names) != 2 {
-			return fmt.Errorf("failed to parse class/plan name combination '%s'", c.name)
-		}
-		plan, err = c.App.RetrievePlanByClassAndPlanNames(names[0], names[1])
-	} else {
-		plan, err = c.App.RetrievePlanByName(c.name)
-	}
+	value, err := c.Fetch(c.App)
 	if err != nil {
 		return err
 	}
 
+	c.write(value)
+	return nil
+}
+
+// planDetails is a plan along with its class and instances.
+type planDetails struct {
+	plan      servicecatalog.Plan
+	class     servicecatalog.Class
+	instances []v1beta1.ServiceInstance
+}
+
+// Fetch retrieves the plan and its class, along with its instances when
+// printing a table.
+func (c *describeCmd) Fetch(app *svcat.App) (interface{}, error) {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	plan, err := retrievePlan(app, c.lookupByUUID, c.uuid, c.name, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Retrieve the class as well because plans don't have the external class name
-	class, err := c.App.RetrieveClassByPlan(plan)
+	class, err := app.RetrieveClassByPlan(plan)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	output.WritePlanDetails(c.Output, plan, class)
+	if !output.IsTableFormat(c.outputFormat) {
+		return output.PlanList{Items: []servicecatalog.Plan{plan}, Classes: []servicecatalog.Class{class}}, nil
+	}
 
-	instances, err := c.App.RetrieveInstancesByPlan(plan)
+	instances, err := app.RetrieveInstancesByPlan(plan)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	output.WriteAssociatedInstances(c.Output, instances)
+	return planDetails{plan: plan, class: class, instances: instances}, nil
+}
 
-	if c.showSchemas {
-		output.WritePlanSchemas(c.Output, plan)
+func (c *describeCmd) write(value interface{}) {
+	switch v := value.(type) {
+	case output.PlanList:
+		output.WritePlan(c.Output, c.outputFormat, v.Items[0], v.Classes[0])
+	case planDetails:
+		output.WritePlanDetails(c.Output, v.plan, v.class)
+		output.WriteAssociatedInstances(c.Output, v.instances)
+		if c.showSchemas {
+			output.WritePlanSchemas(c.Output, v.plan)
+		}
 	}
+}
 
-	return nil
+// WriteContexts prints the plan retrieved from every context.
+func (c *describeCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextDetails(c.Output, c.outputFormat, results, c.write)
+}
+
+// retrievePlan gets a plan by its uuid, its name or its class/plan name
+// combination.
+func retrievePlan(app *svcat.App, lookupByUUID bool, uuid, name string, opts servicecatalog.ScopeOptions) (servicecatalog.Plan, error) {
+	if lookupByUUID {
+		return app.RetrievePlanByID(uuid, opts)
+	}
+	if strings.Contains(name, "/") {
+		names := strings.Split(name, "/")
+		if len(names) != 2 {
+			return nil, fmt.Errorf("failed to parse class/plan name combination '%s'", name)
+		}
+		return app.RetrievePlanByClassAndPlanNames(names[0], names[1], opts)
+	}
+	return app.RetrievePlanByName(name, opts)
 }
@@ -0,0 +1,175 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	schemaInstanceCreate = "instance-create"
+	schemaInstanceUpdate = "instance-update"
+	schemaBindingCreate  = "binding-create"
+)
+
+type explainCmd struct {
+	*command.Namespaced
+	*command.Scoped
+	lookupByUUID  bool
+	uuid          string
+	name          string
+	schema        string
+	exampleFormat string
+}
+
+// NewExplainCmd builds a "svcat explain plan" command
+func NewExplainCmd(cxt *command.Context) *cobra.Command {
+	explainCmd := &explainCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
+	cmd := &cobra.Command{
+		Use:     "plan NAME",
+		Aliases: []string{"plans", "pl"},
+		Short:   "Explain the parameters accepted by a plan",
+		Long: `Explain plan lists the parameters defined by the instance and binding parameter
+schemas of a plan, along with their type, default value and allowed values.
+Use --example to print a skeleton of the parameters that can be edited and then
+passed to provision, update or bind with --params-json.`,
+		Example: command.NormalizeExamples(`
+  svcat explain plan mysqldb/standard800
+  svcat explain plan --uuid 08e4b43a-36bc-447e-a81f-8202b13e339c
+  svcat explain plan mysqldb/standard800 --example json
+  svcat explain plan mysqldb/standard800 --schema binding-create --example yaml
+`),
+		PreRunE: command.PreRunE(explainCmd),
+		RunE:    command.RunE(explainCmd),
+	}
+	cmd.Flags().BoolVarP(
+		&explainCmd.lookupByUUID,
+		"uuid",
+		"u",
+		false,
+		"Whether or not to get the plan by UUID (the default is by name)",
+	)
+	cmd.Flags().StringVar(
+		&explainCmd.schema,
+		"schema",
+		"",
+		"Only explain the specified schema, allowed values are: instance-create, instance-update and binding-create",
+	)
+	cmd.Flags().StringVar(
+		&explainCmd.exampleFormat,
+		"example",
+		"",
+		"Print an example of the parameters, in json or yaml, instead of the table. Uses the instance-create schema unless --schema is specified",
+	)
+	explainCmd.AddNamespaceFlags(cmd.Flags(), false)
+	explainCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
+	return cmd
+}
+
+func (c *explainCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a plan name or uuid is required")
+	}
+
+	if c.lookupByUUID {
+		c.uuid = args[0]
+	} else {
+		c.name = args[0]
+	}
+
+	switch c.schema {
+	case "", schemaInstanceCreate, schemaInstanceUpdate, schemaBindingCreate:
+	default:
+		return fmt.Errorf("invalid --schema (%s), allowed values are: %s, %s and %s",
+			c.schema, schemaInstanceCreate, schemaInstanceUpdate, schemaBindingCreate)
+	}
+
+	switch c.exampleFormat {
+	case "", "json", "yaml":
+	default:
+		return fmt.Errorf("invalid --example (%s), allowed values are: json and yaml", c.exampleFormat)
+	}
+
+	return nil
+}
+
+func (c *explainCmd) Run() error {
+	return c.explain()
+}
+
+func (c *explainCmd) explain() error {
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
+	plan, err := retrievePlan(c.App, c.lookupByUUID, c.uuid, c.name, opts)
+	if err != nil {
+		return err
+	}
+
+	spec := plan.GetSpec()
+	schemas := []struct {
+		name  string
+		title string
+		raw   *runtime.RawExtension
+	}{
+		{schemaInstanceCreate, "Instance Create Parameters", spec.ServiceInstanceCreateParameterSchema},
+		{schemaInstanceUpdate, "Instance Update Parameters", spec.ServiceInstanceUpdateParameterSchema},
+		{schemaBindingCreate, "Binding Create Parameters", spec.ServiceBindingCreateParameterSchema},
+	}
+
+	if c.exampleFormat != "" {
+		selected := c.schema
+		if selected == "" {
+			selected = schemaInstanceCreate
+		}
+		for _, s := range schemas {
+			if s.name != selected {
+				continue
+			}
+			schema, err := servicecatalog.ParseParameterSchema(s.raw)
+			if err != nil {
+				return err
+			}
+			output.WriteParametersExample(c.Output, c.exampleFormat, schema)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(c.Output, "Plan: %s\n", plan.GetExternalName())
+	for _, s := range schemas {
+		if c.schema != "" && s.name != c.schema {
+			continue
+		}
+		schema, err := servicecatalog.ParseParameterSchema(s.raw)
+		if err != nil {
+			return err
+		}
+		output.WriteParameterSchema(c.Output, s.title, schema)
+	}
+
+	return nil
+}
@@ -21,14 +21,16 @@ import (
 	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/completion"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
-	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
+	*command.Scoped
 	lookupByUUID bool
 	uuid         string
 	name         string
@@ -45,7 +47,10 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get plans" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Scoped:     command.NewScoped(),
+	}
 	cmd := &cobra.Command{
 		Use:     "plans [NAME]",
 		Aliases: []string{"plan", "pl"},
@@ -59,6 +64,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get plan --class CLASS_NAME PLAN_NAME
   svcat get plans --uuid --class CLASS_UUID
   svcat get plan --uuid --class CLASS_UUID PLAN_UUID
+  svcat get plans --scope namespace --namespace dev
 `),
 		PreRunE: command.PreRunE(getCmd),
 		RunE:    command.RunE(getCmd),
@@ -77,6 +83,9 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		"",
 		"Filter plans based on class. When --uuid is specified, the class name is interpreted as a uuid.",
 	)
+	cmd.MarkFlagCustom("class", completion.ClassNames)
+	getCmd.AddNamespaceFlags(cmd.Flags(), true)
+	getCmd.AddScopedFlags(cmd.Flags(), servicecatalog.AllScope, servicecatalog.ClusterScope, servicecatalog.NamespaceScope)
 	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
@@ -116,63 +125,108 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
+	plans, err := c.listPlans(c.App)
+	if err != nil {
+		return err
+	}
+
+	output.WritePlanList(c.Output, c.outputFormat, plans.Items, plans.Classes)
+	return nil
+}
 
-	var opts *servicecatalog.FilterOptions
+// listPlans retrieves the plans, along with the classes naming them.
+func (c *getCmd) listPlans(app *svcat.App) (output.PlanList, error) {
+	var filter *servicecatalog.FilterOptions
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
 
 	// Retrieve the classes as well because plans don't have the external class name
-	classes, err := c.App.RetrieveClasses()
+	classes, err := app.RetrieveClasses(opts)
 	if err != nil {
-		return fmt.Errorf("unable to list classes (%s)", err)
+		return output.PlanList{}, fmt.Errorf("unable to list classes (%s)", err)
 	}
 
 	if c.classFilter != "" {
+		classUUID := c.classUUID
 		if !c.lookupByUUID {
 			// Map the external class name to the class name.
 			for _, class := range classes {
-				if c.className == class.Spec.ExternalName {
-					c.classUUID = class.Name
+				if c.className == class.GetExternalName() {
+					classUUID = class.GetName()
 					break
 				}
 			}
 		}
-		opts = &servicecatalog.FilterOptions{
-			ClassID: c.classUUID,
+		filter = &servicecatalog.FilterOptions{
+			ClassID: classUUID,
 		}
 	}
 
-	plans, err := c.App.RetrievePlans(opts)
+	plans, err := app.RetrievePlans(filter, opts)
 	if err != nil {
-		return fmt.Errorf("unable to list plans (%s)", err)
+		return output.PlanList{}, fmt.Errorf("unable to list plans (%s)", err)
 	}
 
-	output.WritePlanList(c.Output, c.outputFormat, plans, classes)
-	return nil
+	return output.PlanList{Items: plans, Classes: classes}, nil
 }
 
 func (c *getCmd) get() error {
-	var plan *v1beta1.ClusterServicePlan
+	plan, class, err := c.getPlan(c.App)
+	if err != nil {
+		return err
+	}
+
+	output.WritePlan(c.Output, c.outputFormat, plan, class)
+
+	return nil
+}
+
+// getPlan retrieves the requested plan along with its class.
+func (c *getCmd) getPlan(app *svcat.App) (servicecatalog.Plan, servicecatalog.Class, error) {
+	var plan servicecatalog.Plan
 	var err error
+	opts := servicecatalog.ScopeOptions{
+		Scope:     c.Scope,
+		Namespace: c.Namespace,
+	}
 	switch {
 	case c.lookupByUUID:
-		plan, err = c.App.RetrievePlanByID(c.uuid)
+		plan, err = app.RetrievePlanByID(c.uuid, opts)
 
 	case c.className != "":
-		plan, err = c.App.RetrievePlanByClassAndPlanNames(c.className, c.name)
+		plan, err = app.RetrievePlanByClassAndPlanNames(c.className, c.name, opts)
 
 	default:
-		plan, err = c.App.RetrievePlanByName(c.name)
+		plan, err = app.RetrievePlanByName(c.name, opts)
 
 	}
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	// Retrieve the class as well because plans don't have the external class name
-	class, err := c.App.RetrieveClassByID(plan.Spec.ClusterServiceClassRef.Name)
+	class, err := app.RetrieveClassByPlan(plan)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	return plan, class, nil
+}
+
+// Fetch retrieves the plans from the cluster of a kubeconfig context.
+func (c *getCmd) Fetch(app *svcat.App) (interface{}, error) {
+	if c.uuid == "" && c.name == "" {
+		return c.listPlans(app)
 	}
 
-	output.WritePlan(c.Output, c.outputFormat, *plan, *class)
+	plan, class, err := c.getPlan(app)
+	if err != nil {
+		return nil, err
+	}
+	return output.PlanList{Items: []servicecatalog.Plan{plan}, Classes: []servicecatalog.Class{class}}, nil
+}
 
-	return nil
+// WriteContexts prints the plans retrieved from every context.
+func (c *getCmd) WriteContexts(results []output.ContextResult) {
+	output.WriteContextLists(c.Output, c.outputFormat, results)
 }
@@ -29,9 +29,22 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	// ModePath installs svcat as kubectl-svcat, an executable that kubectl 1.12
+	// and later finds on the PATH.
+	ModePath = "path"
+
+	// ModeLegacy installs svcat along with a plugin.yaml manifest in the
+	// plugins directory read by kubectl 1.11 and earlier.
+	ModeLegacy = "legacy"
+)
+
 type installCmd struct {
 	*command.Context
+	mode     string
 	path     string
+	binDir   string
+	copy     bool
 	svcatCmd *cobra.Command
 }
 
@@ -41,24 +54,92 @@ func NewInstallCmd(cxt *command.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "plugin",
 		Short: "Install svcat as a kubectl plugin",
+		Long: `Install svcat as a kubectl plugin, run with "kubectl svcat".
+
+By default svcat is installed as kubectl-svcat, a symlink to svcat next to it
+on the PATH, which is how kubectl 1.12 and later find plugins. Use
+--mode legacy for kubectl 1.11 and earlier, to copy svcat and its plugin.yaml
+manifest to the kubectl plugins directory instead.`,
 		Example: command.NormalizeExamples(`
   svcat install plugin
-  svcat install plugin --plugins-path /tmp/kube/plugins
+  svcat install plugin --bin-dir ~/bin --copy
+  svcat install plugin --mode legacy
+  svcat install plugin --mode legacy --plugins-path /tmp/kube/plugins
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return installCmd.run(cmd)
 		},
 	}
+	cmd.Flags().StringVar(&installCmd.mode, "mode", ModePath,
+		"How kubectl finds the plugin. Allowed values: path (kubectl 1.12 and later), legacy (kubectl 1.11 and earlier)")
 	cmd.Flags().StringVarP(&installCmd.path, "plugins-path", "p", "",
-		"The installation path. Defaults to KUBECTL_PLUGINS_PATH, if defined, otherwise the plugins directory under the KUBECONFIG dir. In most cases, this is ~/.kube/plugins.")
+		"The installation path with --mode legacy. Defaults to KUBECTL_PLUGINS_PATH, if defined, otherwise the plugins directory under the KUBECONFIG dir. In most cases, this is ~/.kube/plugins.")
 	cxt.Viper.BindEnv("plugins-path", EnvPluginPath)
+	cmd.Flags().StringVar(&installCmd.binDir, "bin-dir", "",
+		"The directory on the PATH where kubectl-svcat is installed with --mode path. Defaults to the directory of svcat.")
+	cmd.Flags().BoolVar(&installCmd.copy, "copy", false,
+		"Copy svcat to kubectl-svcat with --mode path, instead of creating a symlink. Always enabled on Windows.")
 
 	return cmd
 }
 
 func (c *installCmd) run(cmd *cobra.Command) error {
 	c.svcatCmd = cmd.Root()
-	return c.install()
+	switch c.mode {
+	case ModePath:
+		return c.installExecutable()
+	case ModeLegacy:
+		return c.install()
+	default:
+		return fmt.Errorf("invalid --mode (%s), allowed values are: %s and %s", c.mode, ModePath, ModeLegacy)
+	}
+}
+
+// installExecutable installs svcat as kubectl-svcat, next to svcat unless
+// another directory is requested.
+func (c *installCmd) installExecutable() error {
+	srcBin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("could not retrieve the path to the currently running program (%s)", err)
+	}
+
+	binDir := c.binDir
+	if binDir == "" {
+		binDir = filepath.Dir(srcBin)
+	}
+	err = os.MkdirAll(binDir, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create installation directory %s (%s)", binDir, err)
+	}
+
+	destBin := filepath.Join(binDir, ExecutableName+getFileExt())
+	if isSameFile(srcBin, destBin) {
+		fmt.Fprintf(c.Output, "Plugin is already installed to %s.\n", destBin)
+		return nil
+	}
+
+	// Replace an earlier installation
+	err = os.Remove(destBin)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not replace %s (%s)", destBin, err)
+	}
+
+	if c.copy || !canSymlink() {
+		err = copyFile(srcBin, destBin)
+	} else {
+		err = os.Symlink(srcBin, destBin)
+	}
+	if err != nil {
+		return fmt.Errorf("could not install %s to %s (%s)", srcBin, destBin, err)
+	}
+
+	fmt.Fprintf(c.Output, "Plugin has been installed to %s. Run kubectl %s --help for help using the plugin.\n",
+		destBin, Name)
+	if !isOnPath(binDir) {
+		fmt.Fprintf(c.Output, "Add %s to your PATH so that kubectl finds the plugin.\n", binDir)
+	}
+
+	return nil
 }
 
 func (c *installCmd) install() error {
@@ -166,3 +247,27 @@ func saveManifest(installPath string, manifest []byte) error {
 
 	return nil
 }
+
+// isSameFile determines if both paths lead to the same file, following
+// symlinks.
+func isSameFile(path1, path2 string) bool {
+	info1, err := os.Stat(path1)
+	if err != nil {
+		return false
+	}
+	info2, err := os.Stat(path2)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(info1, info2)
+}
+
+// isOnPath determines if a directory is listed in the PATH.
+func isOnPath(dir string) bool {
+	for _, pathDir := range filepath.SplitList(os.Getenv("PATH")) {
+		if pathDir != "" && isSameFile(pathDir, dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -27,3 +27,7 @@ func getUserHomeDir() string {
 func getFileExt() string {
 	return ""
 }
+
+func canSymlink() bool {
+	return true
+}
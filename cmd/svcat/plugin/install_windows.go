@@ -27,3 +27,7 @@ func getUserHomeDir() string {
 func getFileExt() string {
 	return ".exe"
 }
+
+func canSymlink() bool {
+	return false
+}
@@ -135,7 +135,7 @@ func (m *Manifest) convertToPlugin(cmd *cobra.Command) Plugin {
 
 	p.Tree = []Plugin{}
 	for _, subCmd := range cmd.Commands() {
-		if _, skip := commandsToSkip[subCmd.CommandPath()]; !skip {
+		if _, skip := commandsToSkip[subCmd.CommandPath()]; !skip && !subCmd.Hidden {
 			p.Tree = append(p.Tree, m.convertToPlugin(subCmd))
 		}
 	}
@@ -20,6 +20,8 @@ package plugin
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -50,8 +52,28 @@ const (
 
 	// EnvPluginPath overrides where plugins should be installed.
 	EnvPluginPath = "KUBECTL_PLUGINS_PATH"
+
+	// ExecutableName is the name of the executable that kubectl 1.12 and later
+	// runs for "kubectl svcat", when it is found on the PATH.
+	ExecutableName = "kubectl-" + Name
 )
 
+// IsExecutablePlugin determines if the cli is running as a kubectl plugin
+// found on the PATH, which kubectl runs as kubectl-svcat with the arguments
+// of "kubectl svcat" passed through.
+func IsExecutablePlugin() bool {
+	name := filepath.Base(os.Args[0])
+	return strings.TrimSuffix(name, getFileExt()) == ExecutableName
+}
+
+// AddExecutablePluginFlags adds the kubectl flags that are passed through to
+// kubectl-svcat and may come before the svcat command, for example
+// kubectl svcat -n NAMESPACE get instances.
+func AddExecutablePluginFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("namespace", "n", "",
+		"If present, the namespace scope for this request")
+}
+
 // IsPlugin determines if the cli is running as a kubectl plugin
 func IsPlugin() bool {
 	_, ok := os.LookupEnv(EnvPluginCaller)
@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Prompter asks the user questions and reads their answers, one per line.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New creates a prompter that reads answers from in and writes questions to out.
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Input asks a question, returning the default when the answer is empty.
+func (p *Prompter) Input(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// Select asks the user to pick one of the options, either by its number or
+// its value, returning the default when the answer is empty.
+func (p *Prompter) Select(question string, options []string, def string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("no options available for %s", strings.ToLower(question))
+	}
+
+	fmt.Fprintf(p.out, "%s:\n", question)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, option)
+	}
+	for {
+		answer, err := p.Input("Choose an option", def)
+		if err != nil {
+			return "", err
+		}
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(options) {
+			return options[i-1], nil
+		}
+		for _, option := range options {
+			if answer == option {
+				return option, nil
+			}
+		}
+		fmt.Fprintf(p.out, "Invalid option (%s), enter a number between 1 and %d\n", answer, len(options))
+	}
+}
+
+// Confirm asks a yes/no question, returning the default when the answer is
+// empty.
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	for {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, choices)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Please answer yes or no")
+	}
+}
+
+// readLine reads the next answer. A last answer that isn't terminated by a
+// newline is still accepted.
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", fmt.Errorf("unexpected end of input")
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
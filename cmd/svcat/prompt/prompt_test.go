@@ -0,0 +1,110 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	_ "github.com/kubernetes-incubator/service-catalog/internal/test"
+)
+
+func TestInput(t *testing.T) {
+	testcases := []struct {
+		Name, Answer, Default, Want string
+	}{
+		{"answer", "foo\n", "", "foo"},
+		{"default", "\n", "bar", "bar"},
+		{"answer without newline", "foo", "bar", "foo"},
+		{"extra whitespace", "  foo \n", "", "foo"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.Answer), &bytes.Buffer{})
+
+			got, err := p.Input("Question", tc.Default)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.Want {
+				t.Fatalf("expected %q, got %q", tc.Want, got)
+			}
+		})
+	}
+}
+
+func TestInput_EndOfInput(t *testing.T) {
+	p := New(strings.NewReader(""), &bytes.Buffer{})
+
+	_, err := p.Input("Question", "default")
+	if err == nil {
+		t.Fatal("should have failed because there are no answers left")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	options := []string{"small", "large"}
+	testcases := []struct {
+		Name, Answers, Want string
+	}{
+		{"by number", "2\n", "large"},
+		{"by value", "small\n", "small"},
+		{"retries invalid options", "3\nmedium\n1\n", "small"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.Answers), &bytes.Buffer{})
+
+			got, err := p.Select("Size", options, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.Want {
+				t.Fatalf("expected %q, got %q", tc.Want, got)
+			}
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	testcases := []struct {
+		Name, Answers string
+		Default, Want bool
+	}{
+		{"yes", "y\n", false, true},
+		{"no", "NO\n", true, false},
+		{"default", "\n", true, true},
+		{"retries invalid answers", "maybe\nyes\n", false, true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := New(strings.NewReader(tc.Answers), &bytes.Buffer{})
+
+			got, err := p.Confirm("Continue?", tc.Default)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.Want {
+				t.Fatalf("expected %v, got %v", tc.Want, got)
+			}
+		})
+	}
+}
@@ -30,8 +30,10 @@ import (
 	"strings"
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientgotesting "k8s.io/client-go/testing"
 
 	"encoding/json"
@@ -43,6 +45,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
 	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	sdkfake "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog/fake"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -68,10 +71,88 @@ func TestCommandValidation(t *testing.T) {
 		{"bind requires arg", "bind", "an instance name is required"},
 		{"unbind requires arg", "unbind", "an instance or binding name is required"},
 		{"sync requires names", "sync broker", "a broker name is required"},
+		{"register requires name", "register --url http://broker.com", "a broker name is required"},
+		{"register requires url", "register mybroker", `required flag(s) "url" not set`},
+		{"register does not accept --basic-secret and --bearer-secret",
+			"register mybroker --url http://broker.com --basic-secret foo --bearer-secret bar",
+			"--basic-secret cannot be used with --bearer-secret"},
+		{"register rejects unknown relist behavior", "register mybroker --url http://broker.com --relist-behavior Sometimes",
+			`invalid --relist-behavior value "Sometimes"`},
+		{"register rejects manual relist with duration", "register mybroker --url http://broker.com --relist-behavior Manual --relist-duration 1h",
+			"--relist-duration cannot be used with --relist-behavior Manual"},
+		{"register rejects unknown scope", "register mybroker --url http://broker.com --scope all",
+			"invalid --scope (all), allowed values are: cluster and namespace"},
+		{"deregister requires name", "deregister", "a broker name is required"},
+		{"get brokers rejects unknown scope", "get brokers --scope world",
+			"invalid --scope (world), allowed values are: all, cluster and namespace"},
+		{"provision rejects unknown scope", "provision name --class class --plan plan --scope all",
+			"invalid --scope (all), allowed values are: cluster and namespace"},
+		{"provision requires name", "provision --class class --plan plan", "an instance name is required"},
+		{"provision requires class and plan", "provision name", `required flag(s) "class", "plan" not set`},
+		{"provision interactively does not require flags", "provision --interactive", ""},
+		{"provision interactively does not accept --param", "provision --interactive --param k=v",
+			"--interactive cannot be used with --param, --params-json or --secret"},
 		{"deprovision requires name", "deprovision", "an instance name is required"},
 		{"provision does not accept --param and --params-json",
 			`provision name --class class --plan plan --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
+		{"explain plan requires name", "explain plan", "a plan name or uuid is required"},
+		{"explain plan rejects unknown schema", "explain plan premium --schema foo",
+			"invalid --schema (foo), allowed values are: instance-create, instance-update and binding-create"},
+		{"explain plan rejects unknown example format", "explain plan premium --example xml",
+			"invalid --example (xml), allowed values are: json and yaml"},
+		{"wait instance requires name", "wait instance", "an instance name is required"},
+		{"wait binding requires name", "wait binding", "a binding name is required"},
+		{"wait broker requires name", "wait broker", "a broker name is required"},
+		{"wait rejects unknown condition", "wait instance name --for happy",
+			"invalid --for value (invalid condition (happy), allowed values are: ready, failed, deleted and condition=TYPE)"},
+		{"wait rejects invalid timeout", "wait instance name --timeout forever", "invalid --timeout value"},
+		{"tree instance requires name", "tree instance", "an instance name is required"},
+		{"tree broker requires name", "tree broker", "a broker name is required"},
+		{"get rejects unknown output", "get instances -o xml", "invalid --output format"},
+		{"get rejects empty jsonpath", "get instances -o jsonpath=", "missing template"},
+		{"get rejects invalid jsonpath", "get instances -o jsonpath={.items[", "invalid jsonpath template"},
+		{"get rejects invalid go-template", "get instances -o go-template={{.items", "invalid go-template"},
+		{"get rejects invalid custom-columns", "get instances -o custom-columns=NAME", "invalid custom column"},
+		{"tree rejects unknown output", "tree instance name -o json", "invalid --output format"},
+		{"export instance requires name", "export instance", "an instance name is required"},
+		{"credentials requires name", "credentials", "a binding name is required"},
+		{"credentials rejects unknown format", "credentials name --format xml", "invalid --format"},
+		{"run requires binding", "run -- printenv", "a binding name is required, use --binding"},
+		{"run requires a command", "run --binding name", "a command to run is required"},
+		{"diagnose instance requires name", "diagnose instance", "an instance name is required"},
+		{"diagnose binding requires name", "diagnose binding", "a binding name is required"},
+		{"diagnose rejects unknown output", "diagnose instance name -o wide", "invalid --output format"},
+		{"--contexts cannot be used with --context", "get brokers --context fakek8s --contexts fakek8s,fakek8s-east",
+			"--context and --contexts cannot be used together"},
+		{"compare requires contexts", "compare", "at least two kubeconfig contexts are required"},
+		{"compare requires two contexts", "compare --contexts fakek8s", "at least two kubeconfig contexts are required"},
+		{"names requires kind", "__names", "a kind is required"},
+		{"names rejects unknown kind", "__names secrets", "invalid kind (secrets), allowed values are: bindings, brokers, classes, instances and plans"},
+		{"config set requires key", "config set", "a key and an optional value are required"},
+		{"config use-profile requires name", "config use-profile", "a profile name is required"},
+		{"config use-profile rejects name with none", "config use-profile prod --none", "a profile name cannot be used with --none"},
+		{"config view rejects unknown output", "config view -o table", `invalid --output format "table", allowed values are yaml and json`},
+		{"apply requires a manifest", "apply", "a manifest is required"},
+		{"wait broker rejects unknown scope", "wait broker name --scope all",
+			"invalid --scope (all), allowed values are: cluster and namespace"},
+		{"update instance requires name", "update instance --plan premium", "an instance name is required"},
+		{"update instance rejects a name with --all", "update instance name --plan premium --all",
+			"an instance name cannot be used with --selector or --all"},
+		{"deprovision rejects a name with --selector", "deprovision name -l env=dev",
+			"an instance name cannot be used with --selector or --all"},
+		{"deprovision does not accept --selector and --all", "deprovision -l env=dev --all",
+			"--selector cannot be used with --all"},
+		{"unbind rejects a binding name with --all", "unbind --name binding --all",
+			"an instance or binding name cannot be used with --selector or --all"},
+		{"touch instance rejects invalid selector", "touch instance -l env==dev=",
+			"invalid --selector value"},
+		{"touch instance rejects invalid concurrency", "touch instance --all --concurrency 0",
+			"invalid --concurrency value (0), it must be at least 1"},
+		{"update instance requires a change", "update instance name", "nothing to update, specify --plan, --param, --params-json or --secret"},
+		{"update instance does not accept --param and --params-json",
+			`update instance name --params-json '{}' --param k=v`,
+			"--params-json cannot be used with --param"},
 		{"bind does not accept --param and --params-json",
 			`bind name --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
@@ -95,14 +176,28 @@ func TestCommandOutput(t *testing.T) {
 		cmd             string // Command to run
 		golden          string // Relative path to a golden file, compared to the command output
 		continueOnError bool   // Should the test stop immediately if the command fails or continue and capture the console output
+		input           string // Answers to interactive prompts, read by the command from stdin
 	}{
 		{name: "list all brokers", cmd: "get brokers", golden: "output/get-brokers.txt"},
+		{name: "watch all brokers", cmd: "get brokers --watch", golden: "output/get-brokers-watch.txt"},
 		{name: "list all brokers (json)", cmd: "get brokers -o json", golden: "output/get-brokers.json"},
 		{name: "list all brokers (yaml)", cmd: "get brokers -o yaml", golden: "output/get-brokers.yaml"},
+		{name: "list all brokers (wide)", cmd: "get brokers -o wide", golden: "output/get-brokers-wide.txt"},
+		{name: "list all brokers (name)", cmd: "get brokers -o name", golden: "output/get-brokers-name.txt"},
+		{name: "list all classes (wide)", cmd: "get classes -o wide", golden: "output/get-classes-wide.txt"},
+		{name: "list all plans (wide)", cmd: "get plans -o wide", golden: "output/get-plans-wide.txt"},
+		{name: "list all bindings (wide)", cmd: "get bindings -n test-ns -o wide", golden: "output/get-bindings-wide.txt"},
+		{name: "describe broker (yaml)", cmd: "describe broker ups-broker -o yaml", golden: "output/describe-broker.yaml"},
 		{name: "get broker", cmd: "get broker ups-broker", golden: "output/get-broker.txt"},
 		{name: "get broker (json)", cmd: "get broker ups-broker -o json", golden: "output/get-broker.json"},
 		{name: "get broker (yaml)", cmd: "get broker ups-broker -o yaml", golden: "output/get-broker.yaml"},
 		{name: "describe broker", cmd: "describe broker ups-broker", golden: "output/describe-broker.txt"},
+		{name: "list all brokers in a namespace", cmd: "get brokers -n test-ns", golden: "output/get-brokers-in-namespace.txt"},
+		{name: "list namespaced brokers", cmd: "get brokers --scope namespace -n test-ns", golden: "output/get-namespaced-brokers.txt"},
+		{name: "describe namespaced broker", cmd: "describe broker team-broker --scope namespace -n test-ns", golden: "output/describe-namespaced-broker.txt"},
+		{name: "register broker", cmd: "register ups-broker --url http://upsbroker.com", golden: "output/register-broker.txt"},
+		{name: "register namespaced broker", cmd: "register ups-broker --url http://upsbroker.com --scope namespace -n test-ns", golden: "output/register-namespaced-broker.txt"},
+		{name: "deregister broker", cmd: "deregister ups-broker", golden: "output/deregister-broker.txt"},
 
 		{name: "list all classes", cmd: "get classes", golden: "output/get-classes.txt"},
 		{name: "list all classes (json)", cmd: "get classes -o json", golden: "output/get-classes.json"},
@@ -113,6 +208,9 @@ func TestCommandOutput(t *testing.T) {
 		{name: "get class by uuid", cmd: "get class --uuid 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468", golden: "output/get-class.txt"},
 		{name: "describe class by name", cmd: "describe class user-provided-service", golden: "output/describe-class.txt"},
 		{name: "describe class uuid", cmd: "describe class --uuid 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468", golden: "output/describe-class.txt"},
+		{name: "list all classes in a namespace", cmd: "get classes -n test-ns", golden: "output/get-classes-in-namespace.txt"},
+		{name: "list all classes in a namespace (json)", cmd: "get classes -n test-ns -o json", golden: "output/get-classes-in-namespace.json"},
+		{name: "describe namespaced class", cmd: "describe class team-service -n test-ns", golden: "output/describe-namespaced-class.txt"},
 
 		{name: "list all plans", cmd: "get plans", golden: "output/get-plans.txt"},
 		{name: "list all plans (json)", cmd: "get plans -o json", golden: "output/get-plans.json"},
@@ -131,27 +229,50 @@ func TestCommandOutput(t *testing.T) {
 		{name: "describe plan by class/plan name combo", cmd: "describe plan user-provided-service/default", golden: "output/describe-plan.txt"},
 		{name: "describe plan with schemas", cmd: "describe plan premium", golden: "output/describe-plan-with-schemas.txt"},
 		{name: "describe plan without schemas", cmd: "describe plan premium --show-schemas=false", golden: "output/describe-plan-without-schemas.txt"},
+		{name: "explain plan", cmd: "explain plan user-provided-service/premium", golden: "output/explain-plan.txt"},
+		{name: "explain plan binding schema", cmd: "explain plan premium --schema binding-create", golden: "output/explain-plan-binding.txt"},
+		{name: "explain plan example (json)", cmd: "explain plan premium --example json", golden: "output/explain-plan-example.json"},
+		{name: "explain plan example (yaml)", cmd: "explain plan premium --example yaml", golden: "output/explain-plan-example.yaml"},
+		{name: "list all plans in a namespace", cmd: "get plans -n test-ns", golden: "output/get-plans-in-namespace.txt"},
+		{name: "describe namespaced plan", cmd: "describe plan team-plan --scope namespace -n test-ns", golden: "output/describe-namespaced-plan.txt"},
 
 		{name: "list all instances in a namespace", cmd: "get instances -n test-ns", golden: "output/get-instances.txt"},
 		{name: "list all instances in a namespace (json)", cmd: "get instances -n test-ns -o json", golden: "output/get-instances.json"},
 		{name: "list all instances in a namespace (yaml)", cmd: "get instances -n test-ns -o yaml", golden: "output/get-instances.yaml"},
+		{name: "list all instances in a namespace (wide)", cmd: "get instances -n test-ns -o wide", golden: "output/get-instances-wide.txt"},
+		{name: "list all instances in a namespace (name)", cmd: "get instances -n test-ns -o name", golden: "output/get-instances-name.txt"},
+		{name: "list all instances in a namespace (jsonpath)", cmd: "get instances -n test-ns -o jsonpath={.items[*].metadata.name}", golden: "output/get-instances-jsonpath.txt"},
+		{name: "list all instances in a namespace (go-template)", cmd: `get instances -n test-ns -o go-template={{range.items}}{{.metadata.name}},{{end}}`, golden: "output/get-instances-go-template.txt"},
+		{name: "list all instances in a namespace (custom-columns)", cmd: "get instances -n test-ns -o custom-columns=NAME:.metadata.name,PLAN:.spec.clusterServicePlanExternalName,DASHBOARD:.status.dashboardURL", golden: "output/get-instances-custom-columns.txt"},
+		{name: "describe instance (json)", cmd: "describe instance ups-instance -n test-ns -o json", golden: "output/describe-instance.json"},
+		{name: "describe instance (name)", cmd: "describe instance ups-instance -n test-ns -o name", golden: "output/describe-instance-name.txt"},
 		{name: "list all instances", cmd: "get instances --all-namespaces", golden: "output/get-instances-all-namespaces.txt"},
+		{name: "watch instances in a namespace", cmd: "get instances -n test-ns --watch", golden: "output/get-instances-watch.txt"},
+		{name: "watch instances in a namespace (yaml)", cmd: "get instances -n test-ns --watch -o yaml", golden: "output/get-instances-watch.yaml"},
+		{name: "watch instance (json)", cmd: "get instance ups-instance -n test-ns --watch -o json", golden: "output/get-instance-watch.json"},
 		{name: "get instance", cmd: "get instance ups-instance -n test-ns", golden: "output/get-instance.txt"},
 		{name: "get instance (json)", cmd: "get instance ups-instance -n test-ns -o json", golden: "output/get-instance.json"},
 		{name: "get instance (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml", golden: "output/get-instance.yaml"},
 		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance.txt"},
-		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt"},
-		{name: "bind instance and wait", cmd: "bind ups-instance --name ups-binding -n test-ns --wait", golden: "output/bind-instance-and-wait.txt"},
 		{name: "unbind instance", cmd: "unbind ups-instance -n test-ns", golden: "output/unbind-instance.txt"},
 		{name: "unbind instance and wait", cmd: "unbind ups-instance -n test-ns --wait", golden: "output/unbind-instance-and-wait.txt"},
-		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
-		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
-		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
-		{name: "deprovision instance and wait", cmd: "deprovision ups-instance -n test-ns --wait", golden: "output/deprovision-instance-and-wait.txt"},
+		{name: "provision instance interactively", cmd: "provision -i -n test-ns", golden: "output/provision-instance-interactive.txt",
+			input: "ups-instance\nuser-provided-service\n2\n\nsome value\ny\nmysecret[params]\n\n\n"},
+		{name: "provision instance interactively and cancel", cmd: "provision ups-instance -i -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance-interactive-cancelled.txt",
+			input: "\nn\nn\n"},
+		{name: "update instance", cmd: "update instance ups-instance -n test-ns --plan premium", golden: "output/update-instance.txt"},
+		{name: "update instance and wait", cmd: "update instance ups-instance -n test-ns --plan premium -p foo=bar --wait", golden: "output/update-instance-and-wait.txt"},
+		{name: "update selected instances", cmd: "update instance -l env=dev -n test-ns --plan premium --yes", golden: "output/update-instance-selector.txt"},
+		{name: "deprovision selected instances", cmd: "deprovision -l env=dev -n test-ns", golden: "output/deprovision-selector.txt", input: "y\n"},
+		{name: "deprovision selected instances and wait", cmd: "deprovision -l env=dev -n test-ns --yes --wait --concurrency 1", golden: "output/deprovision-selector-and-wait.txt"},
+		{name: "unbind all bindings", cmd: "unbind --all -n test-ns --yes", golden: "output/unbind-all.txt"},
+		{name: "touch selected instances canceled", cmd: "touch instance -l env=dev -n test-ns", golden: "output/touch-instance-selector-canceled.txt", input: "n\n", continueOnError: true},
+		{name: "touch all instances", cmd: "touch instance --all -n test-ns --yes", golden: "output/touch-instance-all.txt"},
 
 		{name: "list all bindings in a namespace", cmd: "get bindings -n test-ns", golden: "output/get-bindings.txt"},
 		{name: "list all bindings in a namespace (json)", cmd: "get bindings -n test-ns -o json", golden: "output/get-bindings.json"},
 		{name: "list all bindings in a namespace (yaml)", cmd: "get bindings -n test-ns -o yaml", golden: "output/get-bindings.yaml"},
+		{name: "watch binding", cmd: "get binding ups-binding -n test-ns --watch", golden: "output/get-binding-watch.txt"},
 		{name: "list all bindings", cmd: "get bindings --all-namespaces", golden: "output/get-bindings-all-namespaces.txt"},
 		{name: "get binding", cmd: "get binding ups-binding -n test-ns", golden: "output/get-binding.txt"},
 		{name: "get binding (json)", cmd: "get binding ups-binding -n test-ns -o json", golden: "output/get-binding.json"},
@@ -161,18 +282,202 @@ func TestCommandOutput(t *testing.T) {
 		{name: "delete binding", cmd: "unbind --name ups-binding -n test-ns", golden: "output/delete-binding.txt"},
 		{name: "delete binding and wait", cmd: "unbind --name ups-binding -n test-ns --wait", golden: "output/delete-binding-and-wait.txt"},
 
+		{name: "marketplace", cmd: "marketplace", golden: "output/marketplace.txt"},
+		{name: "marketplace search", cmd: "marketplace --search premium", golden: "output/marketplace-search.txt"},
+		{name: "marketplace by tag", cmd: "marketplace --tag Sample", golden: "output/marketplace-tag.txt"},
+		{name: "marketplace free plans", cmd: "marketplace --free-only", golden: "output/marketplace-free.txt"},
+		{name: "marketplace by broker", cmd: "marketplace --broker other-broker", golden: "output/marketplace-broker.txt"},
+		{name: "marketplace (json)", cmd: "marketplace --search another --free-only -o json", golden: "output/marketplace.json"},
+		{name: "tree instance", cmd: "tree instance ups-instance -n test-ns", golden: "output/tree-instance.txt"},
+		{name: "tree instance as dot", cmd: "tree instance ups-instance -n test-ns -o dot", golden: "output/tree-instance-dot.txt"},
+		{name: "tree broker", cmd: "tree broker ups-broker", golden: "output/tree-broker.txt"},
+		{name: "credentials as env", cmd: "credentials ups-binding -n test-ns", golden: "output/credentials-env.txt"},
+		{name: "credentials as dotenv", cmd: "credentials ups-binding -n test-ns --format dotenv", golden: "output/credentials-dotenv.txt"},
+		{name: "credentials as json", cmd: "credentials ups-binding -n test-ns --format json", golden: "output/credentials.json"},
+		{name: "credentials key", cmd: "credentials ups-binding -n test-ns --key special-key-1", golden: "output/credentials-key.txt"},
+		{name: "credentials missing key", cmd: "credentials ups-binding -n test-ns --key password", golden: "output/credentials-missing-key.txt", continueOnError: true},
+		{name: "run with credentials", cmd: "run --binding ups-binding -n test-ns -- printenv SPECIAL_KEY_2", golden: "output/run.txt"},
+		{name: "diagnose stuck instance", cmd: "diagnose instance stuck-instance -n test-ns", golden: "output/diagnose-instance.txt"},
+		{name: "diagnose stuck instance (json)", cmd: "diagnose instance stuck-instance -n test-ns -o json", golden: "output/diagnose-instance.json"},
+		{name: "diagnose ready binding", cmd: "diagnose binding ups-binding -n test-ns", golden: "output/diagnose-binding.txt"},
+		{name: "list instances across contexts", cmd: "get instances -n test-ns --contexts fakek8s,fakek8s-east", golden: "output/get-instances-contexts.txt"},
+		{name: "list brokers across contexts (json)", cmd: "get brokers --contexts fakek8s,fakek8s-east -o json", golden: "output/get-brokers-contexts.json"},
+		{name: "list plans across contexts (name)", cmd: "get plans --contexts fakek8s,fakek8s-east -o name", golden: "output/get-plans-contexts-name.txt"},
+		{name: "describe instance across contexts", cmd: "describe instance ups-instance -n test-ns --contexts fakek8s,fakek8s-east", golden: "output/describe-instance-contexts.txt"},
+		{name: "compare contexts", cmd: "compare --contexts fakek8s,fakek8s-east --scope cluster", golden: "output/compare.txt"},
+		{name: "contexts with watch", cmd: "get brokers --watch --contexts fakek8s,fakek8s-east", golden: "output/contexts-watch.txt", continueOnError: true},
+		{name: "contexts with unsupported command", cmd: "deprovision ups-instance -n test-ns --contexts fakek8s,fakek8s-east", golden: "output/contexts-unsupported.txt", continueOnError: true},
+		{name: "export instance", cmd: "export instance ups-instance -n test-ns", golden: "output/export-instance.txt"},
+		{name: "export instance with bindings", cmd: "export instance ups-instance -n test-ns --with-bindings", golden: "output/export-instance-with-bindings.txt"},
+		{name: "apply manifest", cmd: "apply -n test-ns -f testdata/manifest.yaml", golden: "output/apply-manifest.txt"},
+		{name: "apply manifest from stdin", cmd: "apply -n test-ns -f -", golden: "output/apply-manifest-stdin.txt",
+			input: "apiVersion: servicecatalog.k8s.io/v1beta1\nkind: ServiceInstance\nmetadata:\n  name: new-instance\nspec:\n  clusterServiceClassExternalName: user-provided-service\n  clusterServicePlanExternalName: default\n"},
+		{name: "apply changed binding", cmd: "apply -n test-ns -f -", golden: "output/apply-changed-binding.txt", continueOnError: true,
+			input: "apiVersion: servicecatalog.k8s.io/v1beta1\nkind: ServiceBinding\nmetadata:\n  name: ups-binding\nspec:\n  instanceRef:\n    name: other-instance\n"},
+
+		{name: "view empty config (json)", cmd: "config view -o json", golden: "output/config-view-empty.json"},
+		{name: "complete instance names", cmd: "__names instances -n test-ns", golden: "output/names-instances.txt"},
+		{name: "complete binding names", cmd: "__names bindings -n test-ns", golden: "output/names-bindings.txt"},
+		{name: "complete broker names", cmd: "__names brokers", golden: "output/names-brokers.txt"},
+		{name: "complete class names", cmd: "__names classes", golden: "output/names-classes.txt"},
+		{name: "complete plan names", cmd: "__names plans", golden: "output/names-plans.txt"},
+		{name: "complete plan names of a class", cmd: "__names plans --class user-provided-service", golden: "output/names-plans-class.txt"},
+		{name: "complete plan names of an instance", cmd: "__names plans --instance ups-instance -n test-ns", golden: "output/names-plans-instance.txt"},
 		{name: "completion bash", cmd: "completion bash", golden: "output/completion-bash.txt"},
 		{name: "completion zsh", cmd: "completion zsh", golden: "output/completion-zsh.txt"},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			output := executeCommand(t, tc.cmd, tc.continueOnError)
+			output := executeCommand(t, tc.cmd, tc.input, tc.continueOnError)
+			test.AssertEqualsGoldenFile(t, tc.golden, output)
+		})
+	}
+}
+
+// TestFakeSDKCommandOutput runs the commands that provision, bind,
+// deprovision and wait against the in-memory SDK, and compares their output
+// to the golden files.
+func TestFakeSDKCommandOutput(t *testing.T) {
+	testcases := []struct {
+		name            string // Test Name
+		cmd             string // Command to run
+		golden          string // Relative path to a golden file, compared to the command output
+		continueOnError bool   // Should the test stop immediately if the command fails or continue and compare the output
+		provisioned     bool   // Provision ups-instance before running the command
+		bound           bool   // Bind ups-instance as ups-binding before running the command
+	}{
+		// The fake holds what the commands send, so the commands that wait
+		// pass the parameters and secret shown by the golden files.
+		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt", provisioned: true},
+		{name: "bind instance and wait", cmd: `bind ups-instance --name ups-binding -n test-ns --secret-name ups-binding --params-json {"param1":"value1","paramset":{"ps1":1,"ps2":"two"}} --secret binding-parameters[params] --wait`,
+			golden: "output/bind-instance-and-wait.txt", provisioned: true},
+		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
+		{name: "provision namespaced instance", cmd: "provision ups-instance -n test-ns --class team-service --plan team-plan --scope namespace", golden: "output/provision-namespaced-instance.txt"},
+		{name: "provision instance and wait", cmd: `provision ups-instance -n test-ns --class user-provided-service --plan default --params-json {"param1":"value1","paramset":{"ps1":1,"ps2":"two"}} --secret instance-parameters[params] --wait`,
+			golden: "output/provision-instance-and-wait.txt"},
+		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt", provisioned: true},
+		{name: "deprovision instance and wait", cmd: "deprovision ups-instance -n test-ns --wait", golden: "output/deprovision-instance-and-wait.txt", provisioned: true},
+
+		{name: "wait for instance", cmd: "wait instance ups-instance -n test-ns", golden: "output/wait-instance.txt", provisioned: true},
+		{name: "wait for instance condition", cmd: "wait instance ups-instance -n test-ns --for condition=ready", golden: "output/wait-instance-condition.txt", provisioned: true},
+		{name: "wait for instance timeout", cmd: "wait instance ups-instance -n test-ns --for deleted --timeout 50ms --interval 10ms", golden: "output/wait-instance-timeout.txt", continueOnError: true, provisioned: true},
+		{name: "wait for binding", cmd: "wait binding ups-binding -n test-ns", golden: "output/wait-binding.txt", provisioned: true, bound: true},
+		{name: "wait for broker", cmd: "wait broker ups-broker", golden: "output/wait-broker.txt"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := newContext()
+			cxt.App = &svcat.App{SvcatClient: newFakeSDK(t, tc.provisioned, tc.bound)}
+
+			output := executeFakeCommand(t, tc.cmd, cxt, tc.continueOnError)
 			test.AssertEqualsGoldenFile(t, tc.golden, output)
 		})
 	}
 }
 
+func TestWaitExitCodes(t *testing.T) {
+	testcases := []struct {
+		name     string // Test Name
+		cmd      string // Command to run
+		wantCode int    // Expected exit code
+	}{
+		{"condition met", "wait instance ups-instance -n test-ns", 0},
+		{"timed out", "wait instance ups-instance -n test-ns --for failed --timeout 50ms --interval 10ms", command.ExitCodeTimeout},
+		{"not found", "wait instance missing-instance -n test-ns", command.ExitCodeError},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := newContext()
+			cxt.App = &svcat.App{SvcatClient: newFakeSDK(t, true, false)}
+
+			svcat, _, err := buildCommand(tc.cmd, cxt, "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			svcat.SetOutput(&bytes.Buffer{})
+
+			err = svcat.Execute()
+			if gotCode := command.ExitCode(err); gotCode != tc.wantCode {
+				t.Fatalf("unexpected exit code, WANT: %d GOT: %d (%v)", tc.wantCode, gotCode, err)
+			}
+		})
+	}
+}
+
+// TestFakeSDK runs commands against the in-memory SDK, whose resources move
+// through scripted transitions instead of recorded responses.
+func TestFakeSDK(t *testing.T) {
+	const ns = "default"
+	testcases := []struct {
+		name        string
+		cmd         string
+		transitions []sdkfake.Transition
+		wantCode    int
+		wantOutput  string
+	}{
+		{
+			name:       "provision and wait",
+			cmd:        "provision mydb --class mysql --plan free --wait --interval 1ms",
+			wantOutput: "Ready",
+		},
+		{
+			name:        "provision fails",
+			cmd:         "provision mydb --class mysql --plan free --wait --interval 1ms",
+			transitions: []sdkfake.Transition{sdkfake.InProgress(), sdkfake.Failed("ProvisionCallFailed", "out of capacity")},
+			wantOutput:  "out of capacity",
+		},
+		{
+			name:        "wait for a failed instance",
+			cmd:         "wait instance mydb --interval 1ms",
+			transitions: []sdkfake.Transition{sdkfake.Failed("ProvisionCallFailed", "out of capacity")},
+			wantCode:    command.ExitCodeFailed,
+		},
+		{
+			name:        "wait times out",
+			cmd:         "wait instance mydb --interval 1ms --timeout 20ms",
+			transitions: []sdkfake.Transition{},
+			wantCode:    command.ExitCodeTimeout,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			sdk := sdkfake.NewSDK()
+			if strings.HasPrefix(tc.cmd, "wait") {
+				sdk.Provision(ns, "mydb", "", "mysql", "free", nil, nil, servicecatalog.AllScope)
+			}
+			if tc.transitions != nil {
+				sdk.ScriptInstance(ns, "mydb", tc.transitions...)
+			}
+
+			output := &bytes.Buffer{}
+			cxt := newContext()
+			cxt.App = &svcat.App{SvcatClient: sdk, CurrentNamespace: ns}
+			cxt.Output = output
+
+			svcat, _, err := buildCommand(tc.cmd, cxt, "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			svcat.SetOutput(output)
+
+			err = svcat.Execute()
+			if gotCode := command.ExitCode(err); gotCode != tc.wantCode {
+				t.Fatalf("unexpected exit code, WANT: %d GOT: %d (%v)", tc.wantCode, gotCode, err)
+			}
+			if err != nil {
+				fmt.Fprintln(output, err)
+			}
+			if !strings.Contains(output.String(), tc.wantOutput) {
+				t.Fatalf("unexpected output\nWANT to contain: %q\nGOT: %s", tc.wantOutput, output)
+			}
+		})
+	}
+}
+
 // If you add a new command to svcat, this test will fail, because the plugin.yaml
 // golden file will be out of date. To fix this, run:
 //
@@ -221,6 +526,9 @@ func TestNamespacedCommands(t *testing.T) {
 		{name: "provision with flag namespace", cmd: "provision --class CLASS --plan PLAN NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "provision with context namespace", cmd: "provision --class CLASS --plan PLAN NAME", wantNS: contextNS},
 
+		{name: "update instance with flag namespace", cmd: "update instance NAME --plan PLAN --namespace " + flagNS, wantNS: flagNS},
+		{name: "update instance with context namespace", cmd: "update instance NAME --plan PLAN", wantNS: contextNS},
+
 		{name: "deprovision with flag namespace", cmd: "deprovision NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "deprovision with context namespace", cmd: "deprovision NAME", wantNS: contextNS},
 
@@ -236,6 +544,16 @@ func TestNamespacedCommands(t *testing.T) {
 
 		{name: "describe binding with flag namespace", cmd: "describe binding NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "describe binding with context namespace", cmd: "describe binding NAME", wantNS: contextNS},
+
+		{name: "register namespaced broker with flag namespace", cmd: "register NAME --url URL --scope namespace --namespace " + flagNS, wantNS: flagNS},
+		{name: "register namespaced broker with context namespace", cmd: "register NAME --url URL --scope namespace", wantNS: contextNS},
+
+		{name: "deregister namespaced broker with flag namespace", cmd: "deregister NAME --scope namespace --namespace " + flagNS, wantNS: flagNS},
+		{name: "deregister namespaced broker with context namespace", cmd: "deregister NAME --scope namespace", wantNS: contextNS},
+
+		{name: "get namespaced brokers with flag namespace", cmd: "get brokers --scope namespace --namespace " + flagNS, wantNS: flagNS},
+		{name: "get namespaced classes with context namespace", cmd: "get classes --scope namespace", wantNS: contextNS},
+		{name: "get namespaced plans from all namespaces", cmd: "get plans --scope namespace --all-namespaces", wantNS: allNS},
 	}
 
 	for _, tc := range testcases {
@@ -245,7 +563,7 @@ func TestNamespacedCommands(t *testing.T) {
 			cxt := newContext()
 			cxt.App = &svcat.App{
 				CurrentNamespace: contextNS,
-				SDK:              &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
+				SvcatClient:      &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
 			}
 			cxt.Output = ioutil.Discard
 
@@ -259,6 +577,175 @@ func TestNamespacedCommands(t *testing.T) {
 	}
 }
 
+// TestConfigDefaults confirms that the defaults of the configuration file
+// apply to the flags that are not specified.
+func TestConfigDefaults(t *testing.T) {
+	const contextNS = "from-context"
+	const configNS = "from-config"
+	const profileNS = "from-profile"
+	const flagNS = "from-flag"
+
+	config := &command.Config{
+		Defaults: command.Defaults{Namespace: configNS, Scope: "namespace"},
+	}
+	profileConfig := &command.Config{
+		CurrentProfile: "dev",
+		Defaults:       command.Defaults{Namespace: configNS, Scope: "all"},
+		Profiles: map[string]command.Profile{
+			"dev": {Defaults: command.Defaults{Namespace: profileNS}},
+		},
+	}
+
+	testcases := []struct {
+		name         string
+		config       *command.Config
+		cmd          string
+		wantNS       string
+		wantResource string
+	}{
+		{name: "no configuration", config: &command.Config{}, cmd: "get instances", wantNS: contextNS, wantResource: "serviceinstances"},
+		{name: "default namespace", config: config, cmd: "get instances", wantNS: configNS, wantResource: "serviceinstances"},
+		{name: "flag overrides default namespace", config: config, cmd: "get instances -n " + flagNS, wantNS: flagNS, wantResource: "serviceinstances"},
+		{name: "default scope", config: config, cmd: "get brokers", wantNS: configNS, wantResource: "servicebrokers"},
+		{name: "flag overrides default scope", config: config, cmd: "get brokers --scope cluster", wantNS: "", wantResource: "clusterservicebrokers"},
+		{name: "profile overrides default namespace", config: profileConfig, cmd: "get bindings", wantNS: profileNS, wantResource: "servicebindings"},
+		{name: "unsupported default scope", config: profileConfig, cmd: "deregister NAME", wantNS: "", wantResource: "clusterservicebrokers"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+
+			cxt := newContext()
+			cxt.Config = tc.config
+			cxt.App = &svcat.App{
+				CurrentNamespace: contextNS,
+				SvcatClient:      &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
+			}
+			cxt.Output = ioutil.Discard
+
+			executeFakeCommand(t, tc.cmd, cxt, true)
+
+			action := fakeClient.Actions()[0]
+			if tc.wantNS != action.GetNamespace() {
+				t.Fatalf("the wrong namespace was used. WANT: %q, GOT: %q", tc.wantNS, action.GetNamespace())
+			}
+			if tc.wantResource != action.GetResource().Resource {
+				t.Fatalf("the wrong resource was used. WANT: %q, GOT: %q", tc.wantResource, action.GetResource().Resource)
+			}
+		})
+	}
+}
+
+// TestConfigProfileContext confirms that the current profile selects the
+// kubeconfig context, unless one is specified.
+func TestConfigProfileContext(t *testing.T) {
+	kubeconfig, err := writeTestKubeconfig("http://localhost")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.Remove(kubeconfig)
+
+	testcases := []struct {
+		name      string
+		cmd       string
+		wantError string
+	}{
+		{name: "profile context", cmd: "get instances", wantError: `could not get Kubernetes config for context "missing"`},
+		{name: "flag overrides profile context", cmd: "get instances --context fakek8s-east"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cxt := newContext()
+			cxt.Config = &command.Config{
+				CurrentProfile: "prod",
+				Profiles:       map[string]command.Profile{"prod": {Context: "missing"}},
+			}
+			svcat, targetCmd, err := buildCommand(tc.cmd, cxt, kubeconfig)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			targetCmd.RunE = func(*cobra.Command, []string) error { return nil }
+			svcat.SetOutput(ioutil.Discard)
+
+			err = svcat.Execute()
+			if tc.wantError == "" {
+				if err != nil {
+					t.Fatalf("%+v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+				t.Fatalf("unexpected error, WANT: %q GOT: %v", tc.wantError, err)
+			}
+		})
+	}
+}
+
+// TestConfigCommands edits a configuration file with svcat config set and
+// use-profile, then shows it with svcat config view.
+func TestConfigCommands(t *testing.T) {
+	dir, err := ioutil.TempDir("", "svcat-config")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".svcat", "config.yaml")
+
+	run := func(cmd string) (string, error) {
+		cxt := &command.Context{Viper: viper.New()}
+		svcat, _, err := buildCommand(cmd+" --config "+path, cxt, "")
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		output := &bytes.Buffer{}
+		svcat.SetOutput(output)
+		err = svcat.Execute()
+		return output.String(), err
+	}
+
+	for _, cmd := range []string{
+		"config set namespace dev",
+		"config set output wide",
+		"config set timeout 10m",
+		"config set profiles.prod.context prod-cluster",
+		"config set profiles.prod.namespace payments",
+		"config set profiles.prod.scope cluster",
+		"config set profiles.staging.context staging-cluster",
+		"config set profiles.staging.context",
+		"config set output",
+		"config use-profile prod",
+	} {
+		if _, err := run(cmd); err != nil {
+			t.Fatalf("%s: %+v", cmd, err)
+		}
+	}
+
+	errorcases := []struct {
+		cmd       string
+		wantError string
+	}{
+		{"config set color blue", `unknown configuration key "color"`},
+		{"config set profiles.prod.color blue", `unknown configuration key "profiles.prod.color"`},
+		{"config set scope everywhere", "invalid scope (everywhere), allowed values are: all, cluster and namespace"},
+		{"config set timeout soon", "invalid timeout value"},
+		{"config set output xml", "invalid output value"},
+		{"config use-profile staging", `profile "staging" is not defined, allowed values are: prod`},
+	}
+	for _, tc := range errorcases {
+		if _, err := run(tc.cmd); err == nil || !strings.Contains(err.Error(), tc.wantError) {
+			t.Fatalf("%s: unexpected error, WANT: %q GOT: %v", tc.cmd, tc.wantError, err)
+		}
+	}
+
+	output, err := run("config view")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	test.AssertEqualsGoldenFile(t, "output/config-view.txt", output)
+}
+
 // TestParametersForBinding confirms that parameters given as --param or --param-json work the same way
 func TestParametersForBinding(t *testing.T) {
 	testcases := []struct {
@@ -300,7 +787,7 @@ func TestParametersForBinding(t *testing.T) {
 
 			cxt := newContext()
 			cxt.App = &svcat.App{
-				SDK: &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
+				SvcatClient: &servicecatalog.SDK{ServiceCatalogClient: fakeClient},
 			}
 			cxt.Output = ioutil.Discard
 
@@ -339,6 +826,91 @@ func TestParametersForBinding(t *testing.T) {
 	}
 }
 
+// TestInstallPlugin ensures that svcat is installed as kubectl expects in
+// every mode.
+func TestInstallPlugin(t *testing.T) {
+	testcases := []struct {
+		name      string // Test Name
+		flags     string // Flags of svcat install plugin, DIR is the installation directory
+		installed string // Installed file, relative to the installation directory
+		symlink   bool   // Whether the installed file is a symlink
+	}{
+		{"path", "--bin-dir DIR", plugin.ExecutableName, true},
+		{"path with copy", "--mode path --bin-dir DIR --copy", plugin.ExecutableName, false},
+		{"legacy", "--mode legacy --plugins-path DIR", filepath.Join(plugin.Name, "plugin.yaml"), false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "svcat-plugin")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			cmd := "install plugin " + strings.Replace(tc.flags, "DIR", dir, 1)
+			executeCommand(t, cmd, "", false)
+
+			info, err := os.Lstat(filepath.Join(dir, tc.installed))
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if symlink := info.Mode()&os.ModeSymlink != 0; symlink != tc.symlink {
+				t.Fatalf("unexpected symlink, WANT: %t GOT: %t", tc.symlink, symlink)
+			}
+		})
+	}
+
+	t.Run("invalid mode", func(t *testing.T) {
+		output := executeCommand(t, "install plugin --mode krew", "", true)
+		if !strings.Contains(output, "invalid --mode (krew), allowed values are: path and legacy") {
+			t.Fatalf("unexpected output: %s", output)
+		}
+	})
+}
+
+// TestExecutablePluginFlags ensures that the kubectl flags passed through to
+// kubectl-svcat are parsed the same wherever they are.
+func TestExecutablePluginFlags(t *testing.T) {
+	testcases := []struct {
+		name      string // Test Name
+		cmd       string // Command run by kubectl
+		namespace string // Expected namespace
+	}{
+		{"namespace before the command", "-n foo get instances", "foo"},
+		{"namespace after the command", "get instances --namespace foo", "foo"},
+		{"namespace before a command without namespace", "--namespace foo version --client", "foo"},
+	}
+
+	norun := func(cmd *cobra.Command, args []string) error {
+		return nil
+	}
+
+	args0 := os.Args[0]
+	defer func() { os.Args[0] = args0 }()
+	os.Args[0] = filepath.Join("bin", plugin.ExecutableName)
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rootCmd, targetCmd, err := buildCommand(tc.cmd, newContext(), "")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			// Only parse the flags
+			rootCmd.PersistentPreRunE = norun
+			targetCmd.PreRunE = norun
+			targetCmd.RunE = norun
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, _ := targetCmd.Flags().GetString("namespace"); got != tc.namespace {
+				t.Fatalf("WANT: %q\n\nGOT: %q", tc.namespace, got)
+			}
+		})
+	}
+}
+
 // TestPluginFlags ensures that flags are parsed the same in both standalone and plugin mode.
 func TestPluginFlags(t *testing.T) {
 	testcases := []struct {
@@ -404,7 +976,7 @@ func TestPluginFlags(t *testing.T) {
 
 // executeCommand runs a svcat command against a fake k8s api,
 // returning the cli output.
-func executeCommand(t *testing.T, cmd string, continueOnErr bool) string {
+func executeCommand(t *testing.T, cmd string, input string, continueOnErr bool) string {
 	// Fake the k8s api server
 	apisvr := newAPIServer()
 	defer apisvr.Close()
@@ -417,7 +989,9 @@ func executeCommand(t *testing.T, cmd string, continueOnErr bool) string {
 	defer os.Remove(kubeconfig)
 
 	// Setup the svcat command
-	svcat, _, err := buildCommand(cmd, newContext(), kubeconfig)
+	cxt := newContext()
+	cxt.Input = strings.NewReader(input)
+	svcat, _, err := buildCommand(cmd, cxt, kubeconfig)
 	if err != nil {
 		t.Fatalf("%+v", err)
 	}
@@ -504,7 +1078,15 @@ func buildCommand(cmd string, cxt *command.Context, kubeconfig string) (rootCmd
 	rootCmd = buildRootCommand(cxt)
 	args := strings.Split(cmd, " ")
 	if kubeconfig != "" {
-		args = append(args, "--kubeconfig", kubeconfig)
+		// Keep the flag before --, the arguments after it belong to the command run by svcat run
+		dash := len(args)
+		for i, arg := range args {
+			if arg == "--" {
+				dash = i
+				break
+			}
+		}
+		args = append(args[:dash], append([]string{"--kubeconfig", kubeconfig}, args[dash:]...)...)
 	}
 	rootCmd.SetArgs(args)
 
@@ -516,7 +1098,51 @@ func buildCommand(cmd string, cxt *command.Context, kubeconfig string) (rootCmd
 func newContext() *command.Context {
 	return &command.Context{
 		Viper: viper.New(),
+		// Never read the configuration file of the user running the tests
+		Config: &command.Config{},
+	}
+}
+
+// newFakeSDK returns an in-memory SDK holding the ready ups-broker, where the
+// instances and bindings are ready as soon as they are retrieved, like the
+// ones of the recorded responses. When provisioned is set, it also holds ups-instance in test-ns,
+// and when bound is set, ups-binding for it.
+func newFakeSDK(t *testing.T, provisioned, bound bool) *sdkfake.SDK {
+	sdk := sdkfake.NewSDK(&v1beta1.ClusterServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "ups-broker"},
+		Status: v1beta1.ClusterServiceBrokerStatus{CommonServiceBrokerStatus: v1beta1.CommonServiceBrokerStatus{
+			Conditions: []v1beta1.ServiceBrokerCondition{{
+				Type:   v1beta1.ServiceBrokerConditionReady,
+				Status: v1beta1.ConditionTrue,
+			}},
+		}},
+	})
+	sdk.InstanceTransitions = []sdkfake.Transition{{
+		Ready:   true,
+		Reason:  "ProvisionedSuccessfully",
+		Message: "The instance was provisioned successfully",
+		Time:    metav1.Date(2018, 1, 11, 20, 59, 47, 0, time.UTC),
+	}}
+	sdk.BindingTransitions = []sdkfake.Transition{{
+		Ready:   true,
+		Reason:  "InjectedBindResult",
+		Message: "Injected bind result",
+		Time:    metav1.Date(2018, 1, 11, 21, 0, 47, 0, time.UTC),
+	}}
+
+	if provisioned {
+		_, err := sdk.Provision("test-ns", "ups-instance", "", "user-provided-service", "default", nil, nil, servicecatalog.ClusterScope)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+	if bound {
+		_, err := sdk.Bind("test-ns", "ups-binding", "", "ups-instance", "ups-binding", nil, nil)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
 	}
+	return sdk
 }
 
 func newAPIServer() *httptest.Server {
@@ -568,7 +1194,9 @@ func apihandler(w http.ResponseWriter, r *http.Request) {
 	responseFile := filepath.Join("responses", relpath+".json")
 	_, response, err := test.GetTestdata(responseFile)
 	if err != nil {
-		w.WriteHeader(500)
+		// Resources without testdata, e.g. the namespaced brokers, classes and
+		// plans searched by default, are reported as not found.
+		w.WriteHeader(http.StatusNotFound)
 		w.Write([]byte(fmt.Sprintf("request %s has no matching testdata at %s (%s)", r.RequestURI, responseFile, err)))
 		return
 	}
@@ -103,6 +103,11 @@ type ControllerManagerConfiguration struct {
 	// backoff for polling OSB API operations will use.
 	OperationPollingMaximumBackoffDuration time.Duration
 
+	// BindingRefreshInterval is the interval on which the credentials of
+	// ready bindings are fetched again from brokers that support retrieving
+	// bindings. A zero value disables the refresh.
+	BindingRefreshInterval time.Duration
+
 	SecureServingOptions *genericoptions.SecureServingOptions
 
 	// ClusterIDConfigMapName is the k8s name that the clusterid configmap will have
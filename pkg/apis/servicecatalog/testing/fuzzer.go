@@ -122,6 +122,10 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			for bs.SecretName == "" {
 				bs.SecretName = c.RandString()
 			}
+			// Same reasoning as above for the SecretAdoptionPolicy.
+			if bs.SecretAdoptionPolicy == "" {
+				bs.SecretAdoptionPolicy = servicecatalog.SecretAdoptionPolicyNever
+			}
 			parameters, err := createParameter(c)
 			if err != nil {
 				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
@@ -1068,6 +1068,39 @@ type ServiceBindingSpec struct {
 	// by the broker before they are inserted into the Secret
 	SecretTransforms []SecretTransform
 
+	// SecretTemplate holds metadata that should be applied to the Secret
+	// holding the credentials associated with the ServiceBinding.
+	// +optional
+	SecretTemplate *SecretTemplate
+
+	// SecretAdoptionPolicy determines what the controller does when a
+	// Secret named SecretName already exists but is not controlled by
+	// the ServiceBinding. Defaults to "Never". A Secret adopted under
+	// "IfNotControlled" is released, not deleted, when the ServiceBinding
+	// is unbound.
+	// +optional
+	SecretAdoptionPolicy SecretAdoptionPolicy
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// PodPresetTemplate, if set, makes the controller maintain a PodPreset
+	// that injects the credentials Secret into the pods selected by the
+	// template. The PodPreset is deleted when the ServiceBinding is unbound.
+	// Requires the PodPreset feature gate.
+	// +optional
+	PodPresetTemplate *PodPresetTemplate
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// WorkloadRestart, if set, makes the controller trigger a rolling restart
+	// of the Deployments, StatefulSets and DaemonSets in the ServiceBinding's
+	// namespace that match its selector whenever the contents of the
+	// credentials Secret change.
+	// +optional
+	WorkloadRestart *WorkloadRestart
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1258,6 +1291,69 @@ type ClusterObjectReference struct {
 	Name string
 }
 
+// SecretTemplate specifies metadata that Service Catalog applies to the
+// Secret associated with a ServiceBinding, so that other controllers can
+// recognize and react to Service Catalog secrets.
+type SecretTemplate struct {
+	// Labels that are added to the Secret.
+	Labels map[string]string
+	// Annotations that are added to the Secret.
+	Annotations map[string]string
+}
+
+// SecretAdoptionPolicy specifies whether a ServiceBinding may take ownership
+// of a pre-existing Secret.
+type SecretAdoptionPolicy string
+
+const (
+	// SecretAdoptionPolicyNever means that the binding fails if a Secret with
+	// the same name exists and is not controlled by the ServiceBinding.
+	SecretAdoptionPolicyNever SecretAdoptionPolicy = "Never"
+
+	// SecretAdoptionPolicyIfNotControlled means that the ServiceBinding
+	// takes ownership of a pre-existing Secret as long as no other
+	// controller owns it. The Secret's existing data is replaced by the
+	// binding's credentials. On unbind, the ServiceBinding removes its owner
+	// reference from the Secret instead of deleting it, leaving the last
+	// credentials in place.
+	SecretAdoptionPolicyIfNotControlled SecretAdoptionPolicy = "IfNotControlled"
+)
+
+// PodPresetTemplate describes the PodPreset that Service Catalog generates
+// for a ServiceBinding. The generated PodPreset has the same name as the
+// ServiceBinding and always exposes the keys of the credentials Secret as
+// environment variables of the selected pods' containers.
+type PodPresetTemplate struct {
+	// Selector is a label query over the pods that the credentials are
+	// injected into. It must not be empty.
+	Selector metav1.LabelSelector
+
+	// EnvPrefix is prepended to the name of every environment variable
+	// created from the credentials Secret.
+	// +optional
+	EnvPrefix string
+
+	// MountPath, if set, is the path at which the credentials Secret is
+	// additionally mounted as a read-only volume in the selected pods'
+	// containers.
+	// +optional
+	MountPath string
+}
+
+// WorkloadRestart selects the workloads that consume the credentials of a
+// ServiceBinding. The controller records a checksum of the credentials,
+// keyed with the UID of the ServiceBinding, in an annotation of every selected
+// workload. When a workload does not record the current checksum yet, it also
+// sets the checksum in the pod template, which makes the workload controller
+// roll out new pods. The UID is not secret, so anyone who can read the
+// workload and the ServiceBinding can check a guess of the credentials
+// against the checksum.
+type WorkloadRestart struct {
+	// Selector is a label query over the Deployments, StatefulSets and
+	// DaemonSets to restart. It must not be empty.
+	Selector metav1.LabelSelector
+}
+
 // SecretTransform is a single transformation of the credentials returned
 // from the broker
 type SecretTransform struct {
@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// GetExternalName returns the class's external name.
+func (c *ClusterServiceClass) GetExternalName() string {
+	return c.Spec.ExternalName
+}
+
+// GetDescription returns the class's description.
+func (c *ClusterServiceClass) GetDescription() string {
+	return c.Spec.Description
+}
+
+// GetSpec returns the spec shared by all classes.
+func (c *ClusterServiceClass) GetSpec() CommonServiceClassSpec {
+	return c.Spec.CommonServiceClassSpec
+}
+
+// GetStatus returns the status shared by all classes.
+func (c *ClusterServiceClass) GetStatus() CommonServiceClassStatus {
+	return c.Status.CommonServiceClassStatus
+}
+
+// GetServiceBrokerName returns the name of the class's broker.
+func (c *ClusterServiceClass) GetServiceBrokerName() string {
+	return c.Spec.ClusterServiceBrokerName
+}
+
+// GetExternalName returns the class's external name.
+func (c *ServiceClass) GetExternalName() string {
+	return c.Spec.ExternalName
+}
+
+// GetDescription returns the class's description.
+func (c *ServiceClass) GetDescription() string {
+	return c.Spec.Description
+}
+
+// GetSpec returns the spec shared by all classes.
+func (c *ServiceClass) GetSpec() CommonServiceClassSpec {
+	return c.Spec.CommonServiceClassSpec
+}
+
+// GetStatus returns the status shared by all classes.
+func (c *ServiceClass) GetStatus() CommonServiceClassStatus {
+	return c.Status.CommonServiceClassStatus
+}
+
+// GetServiceBrokerName returns the name of the class's broker.
+func (c *ServiceClass) GetServiceBrokerName() string {
+	return c.Spec.ServiceBrokerName
+}
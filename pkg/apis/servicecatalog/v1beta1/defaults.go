@@ -50,4 +50,7 @@ func SetDefaults_ServiceBinding(binding *ServiceBinding) {
 	if binding.Spec.SecretName == "" {
 		binding.Spec.SecretName = binding.Name
 	}
+	if binding.Spec.SecretAdoptionPolicy == "" {
+		binding.Spec.SecretAdoptionPolicy = SecretAdoptionPolicyNever
+	}
 }
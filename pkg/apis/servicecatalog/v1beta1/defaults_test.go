@@ -127,3 +127,52 @@ func TestSetDefaultClusterServiceBroker(t *testing.T) {
 		}
 	}
 }
+
+func TestSetDefaultServiceBinding(t *testing.T) {
+	cases := []struct {
+		name           string
+		binding        *versioned.ServiceBinding
+		secretName     string
+		adoptionPolicy versioned.SecretAdoptionPolicy
+	}{
+		{
+			name: "nothing set",
+			binding: &versioned.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
+			},
+			secretName:     "test-binding",
+			adoptionPolicy: versioned.SecretAdoptionPolicyNever,
+		},
+		{
+			name: "adoption policy set",
+			binding: func() *versioned.ServiceBinding {
+				b := &versioned.ServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-binding"},
+				}
+				b.Spec.SecretName = "test-secret"
+				b.Spec.SecretAdoptionPolicy = versioned.SecretAdoptionPolicyIfNotControlled
+				return b
+			}(),
+			secretName:     "test-secret",
+			adoptionPolicy: versioned.SecretAdoptionPolicyIfNotControlled,
+		},
+	}
+
+	for _, tc := range cases {
+		o := roundTrip(t, runtime.Object(tc.binding))
+		actualSpec := o.(*versioned.ServiceBinding).Spec
+
+		if tc.secretName != actualSpec.SecretName {
+			t.Errorf(
+				"%v: unexpected default SecretName: expected %v, got %v",
+				tc.name, tc.secretName, actualSpec.SecretName,
+			)
+		}
+		if tc.adoptionPolicy != actualSpec.SecretAdoptionPolicy {
+			t.Errorf(
+				"%v: unexpected default SecretAdoptionPolicy: expected %v, got %v",
+				tc.name, tc.adoptionPolicy, actualSpec.SecretAdoptionPolicy,
+			)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// GetExternalName returns the plan's external name.
+func (p *ClusterServicePlan) GetExternalName() string {
+	return p.Spec.ExternalName
+}
+
+// GetDescription returns the plan's description.
+func (p *ClusterServicePlan) GetDescription() string {
+	return p.Spec.Description
+}
+
+// GetSpec returns the spec shared by all plans.
+func (p *ClusterServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
+
+// GetStatus returns the status shared by all planes.
+func (p *ClusterServicePlan) GetStatus() CommonServicePlanStatus {
+	return p.Status.CommonServicePlanStatus
+}
+
+// GetServiceBrokerName returns the name of the plan's broker.
+func (p *ClusterServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ClusterServiceBrokerName
+}
+
+// GetClassID returns the name (UUID) of the plan's class.
+func (p *ClusterServicePlan) GetClassID() string {
+	return p.Spec.ClusterServiceClassRef.Name
+}
+
+// GetExternalName returns the plan's external name.
+func (p *ServicePlan) GetExternalName() string {
+	return p.Spec.ExternalName
+}
+
+// GetDescription returns the plan's description.
+func (p *ServicePlan) GetDescription() string {
+	return p.Spec.Description
+}
+
+// GetSpec returns the spec shared by all plans.
+func (p *ServicePlan) GetSpec() CommonServicePlanSpec {
+	return p.Spec.CommonServicePlanSpec
+}
+
+// GetStatus returns the status shared by all planes.
+func (p *ServicePlan) GetStatus() CommonServicePlanStatus {
+	return p.Status.CommonServicePlanStatus
+}
+
+// GetServiceBrokerName returns the name of the plan's broker.
+func (p *ServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ServiceBrokerName
+}
+
+// GetClassID returns the name (UUID) of the plan's class.
+func (p *ServicePlan) GetClassID() string {
+	return p.Spec.ServiceClassRef.Name
+}
@@ -99,12 +99,16 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource,
 		Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference,
 		Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference,
+		Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate,
+		Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate,
 		Convert_v1beta1_RemoveKeyTransform_To_servicecatalog_RemoveKeyTransform,
 		Convert_servicecatalog_RemoveKeyTransform_To_v1beta1_RemoveKeyTransform,
 		Convert_v1beta1_RenameKeyTransform_To_servicecatalog_RenameKeyTransform,
 		Convert_servicecatalog_RenameKeyTransform_To_v1beta1_RenameKeyTransform,
 		Convert_v1beta1_SecretKeyReference_To_servicecatalog_SecretKeyReference,
 		Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference,
+		Convert_v1beta1_SecretTemplate_To_servicecatalog_SecretTemplate,
+		Convert_servicecatalog_SecretTemplate_To_v1beta1_SecretTemplate,
 		Convert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform,
 		Convert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform,
 		Convert_v1beta1_ServiceBinding_To_servicecatalog_ServiceBinding,
@@ -161,6 +165,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus,
 		Convert_v1beta1_UserInfo_To_servicecatalog_UserInfo,
 		Convert_servicecatalog_UserInfo_To_v1beta1_UserInfo,
+		Convert_v1beta1_WorkloadRestart_To_servicecatalog_WorkloadRestart,
+		Convert_servicecatalog_WorkloadRestart_To_v1beta1_WorkloadRestart,
 	)
 }
 
@@ -948,6 +954,30 @@ func Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in *serviceca
 	return autoConvert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in, out, s)
 }
 
+func autoConvert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in *PodPresetTemplate, out *servicecatalog.PodPresetTemplate, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.EnvPrefix = in.EnvPrefix
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate is an autogenerated conversion function.
+func Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in *PodPresetTemplate, out *servicecatalog.PodPresetTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in, out, s)
+}
+
+func autoConvert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in *servicecatalog.PodPresetTemplate, out *PodPresetTemplate, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.EnvPrefix = in.EnvPrefix
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate is an autogenerated conversion function.
+func Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in *servicecatalog.PodPresetTemplate, out *PodPresetTemplate, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in, out, s)
+}
+
 func autoConvert_v1beta1_RemoveKeyTransform_To_servicecatalog_RemoveKeyTransform(in *RemoveKeyTransform, out *servicecatalog.RemoveKeyTransform, s conversion.Scope) error {
 	out.Key = in.Key
 	return nil
@@ -1012,6 +1042,28 @@ func Convert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference(in
 	return autoConvert_servicecatalog_SecretKeyReference_To_v1beta1_SecretKeyReference(in, out, s)
 }
 
+func autoConvert_v1beta1_SecretTemplate_To_servicecatalog_SecretTemplate(in *SecretTemplate, out *servicecatalog.SecretTemplate, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_v1beta1_SecretTemplate_To_servicecatalog_SecretTemplate is an autogenerated conversion function.
+func Convert_v1beta1_SecretTemplate_To_servicecatalog_SecretTemplate(in *SecretTemplate, out *servicecatalog.SecretTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_SecretTemplate_To_servicecatalog_SecretTemplate(in, out, s)
+}
+
+func autoConvert_servicecatalog_SecretTemplate_To_v1beta1_SecretTemplate(in *servicecatalog.SecretTemplate, out *SecretTemplate, s conversion.Scope) error {
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
+	out.Annotations = *(*map[string]string)(unsafe.Pointer(&in.Annotations))
+	return nil
+}
+
+// Convert_servicecatalog_SecretTemplate_To_v1beta1_SecretTemplate is an autogenerated conversion function.
+func Convert_servicecatalog_SecretTemplate_To_v1beta1_SecretTemplate(in *servicecatalog.SecretTemplate, out *SecretTemplate, s conversion.Scope) error {
+	return autoConvert_servicecatalog_SecretTemplate_To_v1beta1_SecretTemplate(in, out, s)
+}
+
 func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *SecretTransform, out *servicecatalog.SecretTransform, s conversion.Scope) error {
 	out.RenameKey = (*servicecatalog.RenameKeyTransform)(unsafe.Pointer(in.RenameKey))
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
@@ -1152,6 +1204,10 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.SecretTemplate = (*servicecatalog.SecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretAdoptionPolicy = servicecatalog.SecretAdoptionPolicy(in.SecretAdoptionPolicy)
+	out.PodPresetTemplate = (*servicecatalog.PodPresetTemplate)(unsafe.Pointer(in.PodPresetTemplate))
+	out.WorkloadRestart = (*servicecatalog.WorkloadRestart)(unsafe.Pointer(in.WorkloadRestart))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1170,6 +1226,10 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.SecretTemplate = (*SecretTemplate)(unsafe.Pointer(in.SecretTemplate))
+	out.SecretAdoptionPolicy = SecretAdoptionPolicy(in.SecretAdoptionPolicy)
+	out.PodPresetTemplate = (*PodPresetTemplate)(unsafe.Pointer(in.PodPresetTemplate))
+	out.WorkloadRestart = (*WorkloadRestart)(unsafe.Pointer(in.WorkloadRestart))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1811,3 +1871,23 @@ func autoConvert_servicecatalog_UserInfo_To_v1beta1_UserInfo(in *servicecatalog.
 func Convert_servicecatalog_UserInfo_To_v1beta1_UserInfo(in *servicecatalog.UserInfo, out *UserInfo, s conversion.Scope) error {
 	return autoConvert_servicecatalog_UserInfo_To_v1beta1_UserInfo(in, out, s)
 }
+
+func autoConvert_v1beta1_WorkloadRestart_To_servicecatalog_WorkloadRestart(in *WorkloadRestart, out *servicecatalog.WorkloadRestart, s conversion.Scope) error {
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_v1beta1_WorkloadRestart_To_servicecatalog_WorkloadRestart is an autogenerated conversion function.
+func Convert_v1beta1_WorkloadRestart_To_servicecatalog_WorkloadRestart(in *WorkloadRestart, out *servicecatalog.WorkloadRestart, s conversion.Scope) error {
+	return autoConvert_v1beta1_WorkloadRestart_To_servicecatalog_WorkloadRestart(in, out, s)
+}
+
+func autoConvert_servicecatalog_WorkloadRestart_To_v1beta1_WorkloadRestart(in *servicecatalog.WorkloadRestart, out *WorkloadRestart, s conversion.Scope) error {
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_servicecatalog_WorkloadRestart_To_v1beta1_WorkloadRestart is an autogenerated conversion function.
+func Convert_servicecatalog_WorkloadRestart_To_v1beta1_WorkloadRestart(in *servicecatalog.WorkloadRestart, out *WorkloadRestart, s conversion.Scope) error {
+	return autoConvert_servicecatalog_WorkloadRestart_To_v1beta1_WorkloadRestart(in, out, s)
+}
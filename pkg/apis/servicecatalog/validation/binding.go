@@ -17,10 +17,15 @@ limitations under the License.
 package validation
 
 import (
+	"path"
+
 	"github.com/ghodss/yaml"
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 )
@@ -61,6 +66,22 @@ var validServiceBindingUnbindStatusValues = func() []string {
 	return validValues
 }()
 
+var validSecretAdoptionPolicies = map[sc.SecretAdoptionPolicy]bool{
+	sc.SecretAdoptionPolicy(""):            true,
+	sc.SecretAdoptionPolicyNever:           true,
+	sc.SecretAdoptionPolicyIfNotControlled: true,
+}
+
+var validSecretAdoptionPolicyValues = func() []string {
+	validValues := make([]string, len(validSecretAdoptionPolicies))
+	i := 0
+	for policy := range validSecretAdoptionPolicies {
+		validValues[i] = string(policy)
+		i++
+	}
+	return validValues
+}()
+
 // ValidateServiceBinding validates a ServiceBinding and returns a list of errors.
 func ValidateServiceBinding(binding *sc.ServiceBinding) field.ErrorList {
 	return internalValidateServiceBinding(binding, true)
@@ -95,6 +116,52 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
 	}
 
+	if spec.SecretTemplate != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabels(spec.SecretTemplate.Labels, fldPath.Child("secretTemplate", "labels"))...)
+		allErrs = append(allErrs, apivalidation.ValidateAnnotations(spec.SecretTemplate.Annotations, fldPath.Child("secretTemplate", "annotations"))...)
+	}
+
+	if !validSecretAdoptionPolicies[spec.SecretAdoptionPolicy] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("secretAdoptionPolicy"), spec.SecretAdoptionPolicy, validSecretAdoptionPolicyValues))
+	}
+
+	if spec.PodPresetTemplate != nil {
+		allErrs = append(allErrs, validatePodPresetTemplate(spec.PodPresetTemplate, fldPath.Child("podPresetTemplate"))...)
+	}
+
+	if spec.WorkloadRestart != nil {
+		allErrs = append(allErrs, validateNonEmptyLabelSelector(&spec.WorkloadRestart.Selector, fldPath.Child("workloadRestart", "selector"))...)
+	}
+
+	return allErrs
+}
+
+func validatePodPresetTemplate(template *sc.PodPresetTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateNonEmptyLabelSelector(&template.Selector, fldPath.Child("selector"))...)
+
+	if template.EnvPrefix != "" {
+		for _, msg := range utilvalidation.IsEnvVarName(template.EnvPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("envPrefix"), template.EnvPrefix, msg))
+		}
+	}
+
+	if template.MountPath != "" && !path.IsAbs(template.MountPath) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), template.MountPath, "mountPath must be an absolute path"))
+	}
+
+	return allErrs
+}
+
+// validateNonEmptyLabelSelector validates a label selector that must select
+// something, unlike an empty selector which selects everything.
+func validateNonEmptyLabelSelector(selector *metav1.LabelSelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(selector.MatchLabels)+len(selector.MatchExpressions) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "selector must not be empty"))
+	}
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(selector, fldPath)...)
 	return allErrs
 }
 
@@ -162,6 +162,124 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid secretTemplate",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTemplate = &servicecatalog.SecretTemplate{
+					Labels:      map[string]string{"app": "foo"},
+					Annotations: map[string]string{"reloader.stakater.com/match": "true"},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid label in secretTemplate",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTemplate = &servicecatalog.SecretTemplate{
+					Labels: map[string]string{"app": "T_T!"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid annotation in secretTemplate",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretTemplate = &servicecatalog.SecretTemplate{
+					Annotations: map[string]string{"T_T!": "foo"},
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid secretAdoptionPolicy",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretAdoptionPolicy = servicecatalog.SecretAdoptionPolicyIfNotControlled
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid podPresetTemplate",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.PodPresetTemplate = &servicecatalog.PodPresetTemplate{
+					Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					EnvPrefix: "DB_",
+					MountPath: "/etc/db",
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "podPresetTemplate with empty selector",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.PodPresetTemplate = &servicecatalog.PodPresetTemplate{}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "podPresetTemplate with invalid envPrefix",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.PodPresetTemplate = &servicecatalog.PodPresetTemplate{
+					Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					EnvPrefix: "DB=",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "podPresetTemplate with relative mountPath",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.PodPresetTemplate = &servicecatalog.PodPresetTemplate{
+					Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+					MountPath: "etc/db",
+				}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid workloadRestart",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadRestart = &servicecatalog.WorkloadRestart{
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+				}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "workloadRestart with empty selector",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.WorkloadRestart = &servicecatalog.WorkloadRestart{}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "invalid secretAdoptionPolicy",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretAdoptionPolicy = "Always"
+				return b
+			}(),
+			valid: false,
+		},
 
 		{
 			name:    "valid with in-progress bind",
@@ -868,6 +868,23 @@ func (in *PlanReference) DeepCopy() *PlanReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPresetTemplate) DeepCopyInto(out *PodPresetTemplate) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPresetTemplate.
+func (in *PodPresetTemplate) DeepCopy() *PodPresetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPresetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveKeyTransform) DeepCopyInto(out *RemoveKeyTransform) {
 	*out = *in
@@ -916,6 +933,36 @@ func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretTemplate) DeepCopyInto(out *SecretTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTemplate.
+func (in *SecretTemplate) DeepCopy() *SecretTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
 	*out = *in
@@ -1107,6 +1154,33 @@ func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SecretTemplate != nil {
+		in, out := &in.SecretTemplate, &out.SecretTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SecretTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PodPresetTemplate != nil {
+		in, out := &in.PodPresetTemplate, &out.PodPresetTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodPresetTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.WorkloadRestart != nil {
+		in, out := &in.WorkloadRestart, &out.WorkloadRestart
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(WorkloadRestart)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.UserInfo != nil {
 		in, out := &in.UserInfo, &out.UserInfo
 		if *in == nil {
@@ -1822,3 +1896,20 @@ func (in *UserInfo) DeepCopy() *UserInfo {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadRestart) DeepCopyInto(out *WorkloadRestart) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadRestart.
+func (in *WorkloadRestart) DeepCopy() *WorkloadRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadRestart)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,6 +43,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalogclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	settingsclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/settings/v1alpha1"
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
@@ -72,6 +74,7 @@ const (
 func NewController(
 	kubeClient kubernetes.Interface,
 	serviceCatalogClient servicecatalogclientset.ServicecatalogV1beta1Interface,
+	settingsClient settingsclientset.SettingsV1alpha1Interface,
 	clusterServiceBrokerInformer informers.ClusterServiceBrokerInformer,
 	serviceBrokerInformer informers.ServiceBrokerInformer,
 	clusterServiceClassInformer informers.ClusterServiceClassInformer,
@@ -86,17 +89,21 @@ func NewController(
 	recorder record.EventRecorder,
 	reconciliationRetryDuration time.Duration,
 	operationPollingMaximumBackoffDuration time.Duration,
+	bindingRefreshInterval time.Duration,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
 ) (Controller, error) {
 	controller := &controller{
 		kubeClient:                  kubeClient,
 		serviceCatalogClient:        serviceCatalogClient,
+		settingsClient:              settingsClient,
 		brokerClientCreateFunc:      brokerClientCreateFunc,
 		brokerRelistInterval:        brokerRelistInterval,
 		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
 		recorder:                    recorder,
 		reconciliationRetryDuration: reconciliationRetryDuration,
+		bindingRefreshInterval:      bindingRefreshInterval,
+		bindingRefreshTimes:         make(map[types.UID]time.Time),
 		clusterServiceBrokerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-broker"),
 		serviceBrokerQueue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-broker"),
 		clusterServiceClassQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
@@ -183,6 +190,7 @@ type Controller interface {
 type controller struct {
 	kubeClient                  kubernetes.Interface
 	serviceCatalogClient        servicecatalogclientset.ServicecatalogV1beta1Interface
+	settingsClient              settingsclientset.SettingsV1alpha1Interface
 	brokerClientCreateFunc      osb.CreateFunc
 	clusterServiceBrokerLister  listers.ClusterServiceBrokerLister
 	serviceBrokerLister         listers.ServiceBrokerLister
@@ -206,6 +214,15 @@ type controller struct {
 	bindingQueue                workqueue.RateLimitingInterface
 	instancePollingQueue        workqueue.RateLimitingInterface
 	bindingPollingQueue         workqueue.RateLimitingInterface
+	// bindingRefreshInterval is the interval on which the credentials of
+	// ready bindings to retrievable classes are fetched again from the
+	// broker. Zero disables the refresh.
+	bindingRefreshInterval time.Duration
+	// bindingRefreshTimes holds the time of the last credentials refresh
+	// of each binding.
+	bindingRefreshTimes map[types.UID]time.Time
+	// bindingRefreshLock protects access to bindingRefreshTimes.
+	bindingRefreshLock sync.Mutex
 	// clusterIDConfigMapName is the k8s name that the clusterid
 	// configmap will have.
 	clusterIDConfigMapName string
@@ -17,8 +17,14 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
@@ -26,12 +32,14 @@ import (
 
 	"bytes"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
@@ -49,9 +57,12 @@ const (
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	errorRefreshingBindingFailedReason        string = "RefreshingBindingFailed"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
+	successRestartedWorkloadsReason  string = "RestartedWorkloads"
+	successRefreshedBindingReason    string = "RefreshedBinding"
 	successUnboundReason             string = "UnboundSuccessfully"
 	asyncBindingReason               string = "Binding"
 	asyncBindingMessage              string = "The binding is being created asynchronously"
@@ -66,6 +77,11 @@ const (
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
 var bindingControllerKind = v1beta1.SchemeGroupVersion.WithKind("ServiceBinding")
 
+// adoptedSecretAnnotation is the annotation that records the UID of the
+// ServiceBinding that adopted a pre-existing Secret, so that the Secret is
+// released instead of deleted on unbind.
+const adoptedSecretAnnotation = "servicecatalog.k8s.io/adopted-by"
+
 // ServiceBinding handlers and control-loop
 
 func (c *controller) bindingAdd(obj interface{}) {
@@ -109,6 +125,7 @@ func (c *controller) bindingDelete(obj interface{}) {
 
 	pcb := pretty.NewBindingContextBuilder(binding)
 	glog.V(4).Info(pcb.Messagef("Received DELETE event; no further processing will occur; resourceVersion %v", binding.ResourceVersion))
+	c.forgetBindingRefresh(binding)
 }
 
 func (c *controller) reconcileServiceBindingKey(key string) error {
@@ -139,6 +156,15 @@ func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	return false
 }
 
+func isServiceBindingReady(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady {
+			return condition.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // getReconciliationActionForServiceBinding gets the action the reconciler
 // should be taking on the given binding.
 func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) ReconciliationAction {
@@ -183,6 +209,9 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	if binding.Status.ReconciledGeneration == binding.Generation {
+		if c.bindingRefreshInterval > 0 && isServiceBindingReady(binding) {
+			return c.refreshServiceBinding(binding)
+		}
 		glog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
 		return nil
 	}
@@ -430,14 +459,19 @@ func isPlanBindable(serviceClass *v1beta1.ClusterServiceClass, plan *v1beta1.Clu
 }
 
 func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
-	pcb := pretty.NewBindingContextBuilder(binding)
-	glog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
-		binding.Namespace, binding.Spec.SecretName, len(credentials),
-	))
+	secretData, err := c.secretDataForServiceBinding(binding, credentials)
+	if err != nil {
+		return err
+	}
+	return c.injectServiceBindingSecretData(binding, secretData)
+}
 
+// secretDataForServiceBinding applies the binding's secret transforms to the
+// credentials returned by the broker and serializes them into Secret data.
+func (c *controller) secretDataForServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) (map[string][]byte, error) {
 	err := c.transformCredentials(binding.Spec.SecretTransforms, credentials)
 	if err != nil {
-		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+		return nil, fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
 	}
 
 	secretData := make(map[string][]byte)
@@ -445,9 +479,19 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		var err error
 		secretData[k], err = serialize(v)
 		if err != nil {
-			return fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+			return nil, fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
 		}
 	}
+	return secretData, nil
+}
+
+// injectServiceBindingSecretData writes the given data to the binding's
+// Secret and updates everything that consumes it.
+func (c *controller) injectServiceBindingSecretData(binding *v1beta1.ServiceBinding, secretData map[string][]byte) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	glog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
+		binding.Namespace, binding.Spec.SecretName, len(secretData),
+	))
 
 	// Creating/updating the Secret
 	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
@@ -456,8 +500,17 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		// Update existing secret
 		if !metav1.IsControlledBy(existingSecret, binding) {
 			controllerRef := metav1.GetControllerOf(existingSecret)
-			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
+			if controllerRef != nil || binding.Spec.SecretAdoptionPolicy != v1beta1.SecretAdoptionPolicyIfNotControlled {
+				return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
+			}
+			glog.V(4).Info(pcb.Messagef(`Adopting Secret "%s/%s"`, binding.Namespace, existingSecret.Name))
+			existingSecret.OwnerReferences = append(existingSecret.OwnerReferences, *metav1.NewControllerRef(binding, bindingControllerKind))
+			if existingSecret.Annotations == nil {
+				existingSecret.Annotations = make(map[string]string)
+			}
+			existingSecret.Annotations[adoptedSecretAnnotation] = string(binding.UID)
 		}
+		applySecretTemplate(binding.Spec.SecretTemplate, existingSecret)
 		existingSecret.Data = secretData
 		_, err = secretClient.Update(existingSecret)
 		if err != nil {
@@ -485,6 +538,7 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 			},
 			Data: secretData,
 		}
+		applySecretTemplate(binding.Spec.SecretTemplate, secret)
 		_, err = secretClient.Create(secret)
 		if err != nil {
 			if apierrors.IsAlreadyExists(err) {
@@ -497,7 +551,383 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		}
 	}
 
-	return err
+	if err := c.reconcileBindingPodPreset(binding); err != nil {
+		return err
+	}
+
+	if err := c.restartBindingWorkloads(binding, secretData); err != nil {
+		return err
+	}
+
+	c.recordBindingRefresh(binding)
+	return nil
+}
+
+// refreshServiceBinding fetches the credentials of a ready binding to a
+// retrievable service class from the broker again once the binding refresh
+// interval has elapsed. The Secret is only written, and consumers are only
+// notified, if the broker returned credentials that differ from the ones
+// in the Secret.
+func (c *controller) refreshServiceBinding(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	key, err := cache.MetaNamespaceKeyFunc(binding)
+	if err != nil {
+		return err
+	}
+
+	if delay := c.bindingRefreshDelay(binding); delay > 0 {
+		glog.V(6).Info(pcb.Messagef("Not refreshing credentials; next refresh in %v", delay))
+		c.bindingQueue.AddAfter(key, delay)
+		return nil
+	}
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return fmt.Errorf(`Unable to refresh credentials of ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	serviceClass, _, _, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+	if !serviceClass.Spec.BindingRetrievable {
+		glog.V(4).Info(pcb.Messagef("Not refreshing credentials; %s does not support retrieving bindings", pretty.ClusterServiceClassName(serviceClass)))
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message("Refreshing credentials"))
+	// Whatever the outcome, the next refresh happens one interval from now.
+	c.recordBindingRefresh(binding)
+	c.bindingQueue.AddAfter(key, c.bindingRefreshInterval)
+
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRefreshingBindingFailedReason, msg)
+		return nil
+	}
+
+	secretData, err := c.secretDataForServiceBinding(binding, response.Credentials)
+	if err != nil {
+		glog.Warning(pcb.Message(err.Error()))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRefreshingBindingFailedReason, err.Error())
+		return nil
+	}
+
+	changed, err := c.bindingSecretDataChanged(binding, secretData)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		glog.V(4).Info(pcb.Message("Credentials are unchanged"))
+		return nil
+	}
+
+	if err := c.injectServiceBindingSecretData(binding, secretData); err != nil {
+		msg := fmt.Sprintf("Error injecting refreshed bind results: %v", err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorRefreshingBindingFailedReason, msg)
+		return err
+	}
+
+	msg := "Injected refreshed bind result"
+	glog.V(4).Info(pcb.Message(msg))
+	c.recorder.Event(binding, corev1.EventTypeNormal, successRefreshedBindingReason, msg)
+	return nil
+}
+
+// bindingSecretDataChanged returns whether the given data differs from the
+// data held by the binding's Secret, by comparing their checksums.
+func (c *controller) bindingSecretDataChanged(binding *v1beta1.ServiceBinding, secretData map[string][]byte) (bool, error) {
+	secret, err := c.kubeClient.CoreV1().Secrets(binding.Namespace).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, binding.Spec.SecretName, err)
+	}
+
+	existing, err := generateChecksumOfSecretData(secret.Data)
+	if err != nil {
+		return false, err
+	}
+	refreshed, err := generateChecksumOfSecretData(secretData)
+	if err != nil {
+		return false, err
+	}
+	return existing != refreshed, nil
+}
+
+// bindingRefreshDelay returns how long to wait before the credentials of the
+// binding are due to be refreshed.
+// The refresh times are only kept in memory, so the first refresh of a
+// binding the controller has not refreshed yet is scheduled at a random point
+// within one interval. This spreads the refreshes of all the bindings over
+// the interval after a restart of the controller, instead of sending them to
+// the brokers at once.
+func (c *controller) bindingRefreshDelay(binding *v1beta1.ServiceBinding) time.Duration {
+	c.bindingRefreshLock.Lock()
+	defer c.bindingRefreshLock.Unlock()
+
+	last, ok := c.bindingRefreshTimes[binding.UID]
+	if !ok {
+		delay := time.Duration(rand.Int63n(int64(c.bindingRefreshInterval))) + 1
+		c.bindingRefreshTimes[binding.UID] = time.Now().Add(delay - c.bindingRefreshInterval)
+		return delay
+	}
+	return c.bindingRefreshInterval - time.Since(last)
+}
+
+// recordBindingRefresh records that the credentials of the binding have just
+// been fetched from the broker.
+func (c *controller) recordBindingRefresh(binding *v1beta1.ServiceBinding) {
+	if c.bindingRefreshInterval <= 0 {
+		return
+	}
+	c.bindingRefreshLock.Lock()
+	defer c.bindingRefreshLock.Unlock()
+
+	c.bindingRefreshTimes[binding.UID] = time.Now()
+}
+
+// forgetBindingRefresh drops the refresh bookkeeping of the binding.
+func (c *controller) forgetBindingRefresh(binding *v1beta1.ServiceBinding) {
+	c.bindingRefreshLock.Lock()
+	defer c.bindingRefreshLock.Unlock()
+
+	delete(c.bindingRefreshTimes, binding.UID)
+}
+
+// restartBindingWorkloads records a keyed checksum of the given credentials
+// on the workloads selected by the binding's WorkloadRestart, and triggers a
+// rolling restart of the workloads that do not record that checksum yet.
+func (c *controller) restartBindingWorkloads(binding *v1beta1.ServiceBinding, secretData map[string][]byte) error {
+	if binding.Spec.WorkloadRestart == nil {
+		return nil
+	}
+	pcb := pretty.NewBindingContextBuilder(binding)
+
+	selector, err := metav1.LabelSelectorAsSelector(&binding.Spec.WorkloadRestart.Selector)
+	if err != nil {
+		return fmt.Errorf(`Invalid workload restart selector for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	checksum, err := generateKeyedChecksumOfSecretData([]byte(binding.UID), secretData)
+	if err != nil {
+		return fmt.Errorf(`Unable to compute credentials checksum for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	annotation := credentialsChecksumAnnotation(binding.Name)
+
+	listOptions := metav1.ListOptions{LabelSelector: selector.String()}
+	appsClient := c.kubeClient.AppsV1()
+	var restarted []string
+
+	deployments, err := appsClient.Deployments(binding.Namespace).List(listOptions)
+	if err != nil {
+		return fmt.Errorf(`Unexpected error listing Deployments in namespace %q: %v`, binding.Namespace, err)
+	}
+	for _, d := range deployments.Items {
+		patch, err := workloadChecksumPatch(annotation, checksum, d.Annotations)
+		if err != nil {
+			return err
+		}
+		if patch == nil {
+			continue
+		}
+		if _, err := appsClient.Deployments(binding.Namespace).Patch(d.Name, types.StrategicMergePatchType, patch); err != nil {
+			return fmt.Errorf(`Unexpected error restarting Deployment "%s/%s": %v`, binding.Namespace, d.Name, err)
+		}
+		restarted = append(restarted, "Deployment/"+d.Name)
+	}
+
+	statefulSets, err := appsClient.StatefulSets(binding.Namespace).List(listOptions)
+	if err != nil {
+		return fmt.Errorf(`Unexpected error listing StatefulSets in namespace %q: %v`, binding.Namespace, err)
+	}
+	for _, ss := range statefulSets.Items {
+		patch, err := workloadChecksumPatch(annotation, checksum, ss.Annotations)
+		if err != nil {
+			return err
+		}
+		if patch == nil {
+			continue
+		}
+		if _, err := appsClient.StatefulSets(binding.Namespace).Patch(ss.Name, types.StrategicMergePatchType, patch); err != nil {
+			return fmt.Errorf(`Unexpected error restarting StatefulSet "%s/%s": %v`, binding.Namespace, ss.Name, err)
+		}
+		restarted = append(restarted, "StatefulSet/"+ss.Name)
+	}
+
+	daemonSets, err := appsClient.DaemonSets(binding.Namespace).List(listOptions)
+	if err != nil {
+		return fmt.Errorf(`Unexpected error listing DaemonSets in namespace %q: %v`, binding.Namespace, err)
+	}
+	for _, ds := range daemonSets.Items {
+		patch, err := workloadChecksumPatch(annotation, checksum, ds.Annotations)
+		if err != nil {
+			return err
+		}
+		if patch == nil {
+			continue
+		}
+		if _, err := appsClient.DaemonSets(binding.Namespace).Patch(ds.Name, types.StrategicMergePatchType, patch); err != nil {
+			return fmt.Errorf(`Unexpected error restarting DaemonSet "%s/%s": %v`, binding.Namespace, ds.Name, err)
+		}
+		restarted = append(restarted, "DaemonSet/"+ds.Name)
+	}
+
+	if len(restarted) > 0 {
+		message := fmt.Sprintf("Restarted workloads consuming the credentials: %s", strings.Join(restarted, ", "))
+		glog.V(4).Info(pcb.Message(message))
+		c.recorder.Event(binding, corev1.EventTypeNormal, successRestartedWorkloadsReason, message)
+	}
+	return nil
+}
+
+// workloadChecksumPatch returns the patch recording the checksum in the
+// annotations of a workload and of its pod template, which restarts the
+// workload, or nil if the workload already records the checksum.
+func workloadChecksumPatch(annotation, checksum string, annotations map[string]string) ([]byte, error) {
+	if annotations[annotation] == checksum {
+		return nil, nil
+	}
+
+	checksumAnnotations := map[string]interface{}{
+		"annotations": map[string]string{annotation: checksum},
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": checksumAnnotations,
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": checksumAnnotations,
+			},
+		},
+	})
+}
+
+// credentialsChecksumAnnotation returns the workload and pod template
+// annotation that holds the checksum of the credentials of the binding with
+// the given name.
+// The name part of an annotation key is limited to 63 characters, so long
+// binding names are shortened and suffixed with a hash to keep them unique.
+func credentialsChecksumAnnotation(bindingName string) string {
+	const (
+		prefix        = "credentials.servicecatalog.k8s.io/"
+		nameMaxLength = 63
+	)
+	if len(bindingName) <= nameMaxLength {
+		return prefix + bindingName
+	}
+	hash := sha256.Sum256([]byte(bindingName))
+	return fmt.Sprintf("%s%s-%x", prefix, bindingName[:nameMaxLength-9], hash[:4])
+}
+
+// reconcileBindingPodPreset creates or updates the PodPreset generated from
+// the binding's PodPresetTemplate, if any.
+func (c *controller) reconcileBindingPodPreset(binding *v1beta1.ServiceBinding) error {
+	if binding.Spec.PodPresetTemplate == nil {
+		return nil
+	}
+	pcb := pretty.NewBindingContextBuilder(binding)
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+		glog.Warning(pcb.Message("Ignoring PodPresetTemplate because the PodPreset feature gate is disabled"))
+		return nil
+	}
+
+	podPreset := podPresetForBinding(binding)
+	podPresetClient := c.settingsClient.PodPresets(binding.Namespace)
+	existingPodPreset, err := podPresetClient.Get(podPreset.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf(`Unexpected error getting PodPreset "%s/%s": %v`, binding.Namespace, podPreset.Name, err)
+		}
+		glog.V(5).Info(pcb.Messagef(`Creating PodPreset "%s/%s"`, binding.Namespace, podPreset.Name))
+		if _, err := podPresetClient.Create(podPreset); err != nil {
+			return fmt.Errorf(`Unexpected error creating PodPreset "%s/%s": %v`, binding.Namespace, podPreset.Name, err)
+		}
+		return nil
+	}
+
+	if !metav1.IsControlledBy(existingPodPreset, binding) {
+		controllerRef := metav1.GetControllerOf(existingPodPreset)
+		return fmt.Errorf(`PodPreset "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingPodPreset.Name, controllerRef)
+	}
+	if reflect.DeepEqual(existingPodPreset.Spec, podPreset.Spec) {
+		return nil
+	}
+	glog.V(5).Info(pcb.Messagef(`Updating PodPreset "%s/%s"`, binding.Namespace, podPreset.Name))
+	existingPodPreset.Spec = podPreset.Spec
+	if _, err := podPresetClient.Update(existingPodPreset); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf(`Conflicting PodPreset "%s/%s" update detected`, binding.Namespace, existingPodPreset.Name)
+		}
+		return fmt.Errorf(`Unexpected error updating PodPreset "%s/%s": %v`, binding.Namespace, existingPodPreset.Name, err)
+	}
+	return nil
+}
+
+// podPresetForBinding returns the PodPreset described by the binding's
+// PodPresetTemplate. It injects the binding's Secret as environment
+// variables and, if a mount path is given, as a read-only volume.
+func podPresetForBinding(binding *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+	template := binding.Spec.PodPresetTemplate
+	podPreset := &settingsv1alpha1.PodPreset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      binding.Name,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Spec: settingsv1alpha1.PodPresetSpec{
+			Selector: *template.Selector.DeepCopy(),
+			EnvFrom: []corev1.EnvFromSource{
+				{
+					Prefix: template.EnvPrefix,
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: binding.Spec.SecretName},
+					},
+				},
+			},
+		},
+	}
+	if template.MountPath != "" {
+		volumeName := fmt.Sprintf("%s-credentials", binding.Name)
+		podPreset.Spec.Volumes = []corev1.Volume{
+			{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: binding.Spec.SecretName},
+				},
+			},
+		}
+		podPreset.Spec.VolumeMounts = []corev1.VolumeMount{
+			{Name: volumeName, MountPath: template.MountPath, ReadOnly: true},
+		}
+	}
+	return podPreset
+}
+
+// applySecretTemplate copies the labels and annotations from the given
+// template onto the Secret, overwriting any existing values for the same keys.
+func applySecretTemplate(template *v1beta1.SecretTemplate, secret *corev1.Secret) {
+	if template == nil {
+		return
+	}
+	if len(template.Labels) > 0 && secret.Labels == nil {
+		secret.Labels = make(map[string]string, len(template.Labels))
+	}
+	for k, v := range template.Labels {
+		secret.Labels[k] = v
+	}
+	if len(template.Annotations) > 0 && secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, len(template.Annotations))
+	}
+	for k, v := range template.Annotations {
+		secret.Annotations[k] = v
+	}
 }
 
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
@@ -555,14 +985,90 @@ func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (stri
 func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error {
 	var err error
 	pcb := pretty.NewBindingContextBuilder(binding)
-	glog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`,
-		binding.Namespace, binding.Spec.SecretName,
-	))
-	err = c.kubeClient.CoreV1().Secrets(binding.Namespace).Delete(binding.Spec.SecretName, &metav1.DeleteOptions{})
-	if err != nil && !apierrors.IsNotFound(err) {
+	released := false
+	if binding.Spec.SecretAdoptionPolicy == v1beta1.SecretAdoptionPolicyIfNotControlled {
+		released, err = c.releaseAdoptedSecret(binding)
+		if err != nil {
+			return err
+		}
+	}
+	if !released {
+		glog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`,
+			binding.Namespace, binding.Spec.SecretName,
+		))
+		err = c.kubeClient.CoreV1().Secrets(binding.Namespace).Delete(binding.Spec.SecretName, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if binding.Spec.PodPresetTemplate != nil && utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+		if err := c.deleteBindingPodPreset(binding); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseAdoptedSecret removes the binding's owner reference from the
+// binding's Secret if the binding adopted it, so that the Secret outlives the
+// binding. It returns whether the Secret was released.
+func (c *controller) releaseAdoptedSecret(binding *v1beta1.ServiceBinding) (bool, error) {
+	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
+	secret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, binding.Spec.SecretName, err)
+	}
+	if secret.Annotations[adoptedSecretAnnotation] != string(binding.UID) {
+		return false, nil
+	}
+
+	pcb := pretty.NewBindingContextBuilder(binding)
+	glog.V(4).Info(pcb.Messagef(`Releasing adopted Secret "%s/%s"`, binding.Namespace, secret.Name))
+	delete(secret.Annotations, adoptedSecretAnnotation)
+	var ownerReferences []metav1.OwnerReference
+	for _, ref := range secret.OwnerReferences {
+		if ref.UID != binding.UID {
+			ownerReferences = append(ownerReferences, ref)
+		}
+	}
+	secret.OwnerReferences = ownerReferences
+	if _, err := secretClient.Update(secret); err != nil {
+		return false, fmt.Errorf(`Unexpected error releasing Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+	}
+	return true, nil
+}
+
+// deleteBindingPodPreset deletes the PodPreset generated from the binding's
+// PodPresetTemplate. A PodPreset with the same name that is not controlled
+// by the binding is left alone.
+func (c *controller) deleteBindingPodPreset(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewBindingContextBuilder(binding)
+	podPresetClient := c.settingsClient.PodPresets(binding.Namespace)
+	podPreset, err := podPresetClient.Get(binding.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
 		return err
 	}
+	if !metav1.IsControlledBy(podPreset, binding) {
+		controllerRef := metav1.GetControllerOf(podPreset)
+		glog.Warning(pcb.Messagef(`Not deleting PodPreset "%s/%s" because it is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, podPreset.Name, controllerRef))
+		return nil
+	}
 
+	glog.V(5).Info(pcb.Messagef(`Deleting PodPreset "%s/%s"`, binding.Namespace, podPreset.Name))
+	err = podPresetClient.Delete(podPreset.Name, &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &podPreset.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
 
@@ -28,15 +28,18 @@ import (
 
 	scmeta "github.com/kubernetes-incubator/service-catalog/pkg/api/meta"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
 	v1beta1informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
@@ -4069,6 +4072,647 @@ func TestTransformSecretData(t *testing.T) {
 	}
 }
 
+// TestInjectServiceBindingSecretOwnership tests that injectServiceBinding
+// honors the binding's SecretTemplate and SecretAdoptionPolicy when creating
+// or updating the credentials Secret.
+func TestInjectServiceBindingSecretOwnership(t *testing.T) {
+	otherOwner := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: testNamespace, UID: "other-uid"},
+	}
+	cases := []struct {
+		name                string
+		existingSecret      *corev1.Secret
+		adoptionPolicy      v1beta1.SecretAdoptionPolicy
+		expectedError       bool
+		expectedAction      string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:                "new secret gets template metadata",
+			expectedAction:      "create",
+			expectedLabels:      map[string]string{"app": "foo"},
+			expectedAnnotations: map[string]string{"reloader.stakater.com/match": "true"},
+		},
+		{
+			name: "unowned secret is not adopted by default",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: testServiceBindingSecretName, Namespace: testNamespace},
+			},
+			adoptionPolicy: v1beta1.SecretAdoptionPolicyNever,
+			expectedError:  true,
+		},
+		{
+			name: "unowned secret is adopted",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+					Labels:    map[string]string{"team": "bar"},
+				},
+			},
+			adoptionPolicy: v1beta1.SecretAdoptionPolicyIfNotControlled,
+			expectedAction: "update",
+			expectedLabels: map[string]string{"app": "foo", "team": "bar"},
+			expectedAnnotations: map[string]string{
+				"reloader.stakater.com/match": "true",
+				adoptedSecretAnnotation:       "binding-uid",
+			},
+		},
+		{
+			name: "secret controlled by another object is never adopted",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(otherOwner, bindingControllerKind),
+					},
+				},
+			},
+			adoptionPolicy: v1beta1.SecretAdoptionPolicyIfNotControlled,
+			expectedError:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+		if tc.existingSecret != nil {
+			addGetSecretReaction(fakeKubeClient, tc.existingSecret)
+		} else {
+			addGetSecretNotFoundReaction(fakeKubeClient)
+		}
+
+		binding := getTestServiceBinding()
+		binding.UID = "binding-uid"
+		binding.Spec.SecretName = testServiceBindingSecretName
+		binding.Spec.SecretAdoptionPolicy = tc.adoptionPolicy
+		binding.Spec.SecretTemplate = &v1beta1.SecretTemplate{
+			Labels:      map[string]string{"app": "foo"},
+			Annotations: map[string]string{"reloader.stakater.com/match": "true"},
+		}
+
+		err := testController.injectServiceBinding(binding, map[string]interface{}{"a": "b"})
+		if tc.expectedError {
+			if err == nil {
+				t.Errorf("%v: expected error", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		kubeActions := fakeKubeClient.Actions()
+		assertNumberOfActions(t, kubeActions, 2)
+		assertActionEquals(t, kubeActions[1], tc.expectedAction, "secrets")
+
+		var secret *corev1.Secret
+		switch action := kubeActions[1].(type) {
+		case clientgotesting.CreateAction:
+			secret = action.GetObject().(*corev1.Secret)
+		case clientgotesting.UpdateAction:
+			secret = action.GetObject().(*corev1.Secret)
+		}
+		if !metav1.IsControlledBy(secret, binding) {
+			t.Errorf("%v: expected Secret to be controlled by the binding", tc.name)
+		}
+		if !reflect.DeepEqual(secret.Labels, tc.expectedLabels) {
+			t.Errorf("%v: unexpected labels; expected: %v; actual: %v", tc.name, tc.expectedLabels, secret.Labels)
+		}
+		if !reflect.DeepEqual(secret.Annotations, tc.expectedAnnotations) {
+			t.Errorf("%v: unexpected annotations; expected: %v; actual: %v", tc.name, tc.expectedAnnotations, secret.Annotations)
+		}
+		if string(secret.Data["a"]) != "b" {
+			t.Errorf("%v: unexpected secret data: %v", tc.name, secret.Data)
+		}
+	}
+}
+
+// TestEjectServiceBindingReleasesAdoptedSecret tests that unbinding releases
+// a Secret adopted under the IfNotControlled policy instead of deleting it,
+// and deletes the Secrets the binding created.
+func TestEjectServiceBindingReleasesAdoptedSecret(t *testing.T) {
+	otherOwner := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-binding", UID: "other-uid"},
+	}
+	newBinding := func() *v1beta1.ServiceBinding {
+		b := getTestServiceBinding()
+		b.UID = "binding-uid"
+		b.Spec.SecretName = testServiceBindingSecretName
+		b.Spec.SecretAdoptionPolicy = v1beta1.SecretAdoptionPolicyIfNotControlled
+		return b
+	}
+
+	cases := []struct {
+		name            string
+		existingSecret  *corev1.Secret
+		expectedActions []string
+	}{
+		{
+			name: "adopted secret",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+					Annotations: map[string]string{
+						"team":                  "bar",
+						adoptedSecretAnnotation: "binding-uid",
+					},
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(newBinding(), bindingControllerKind),
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "other-owner", UID: "other-uid"},
+					},
+				},
+			},
+			expectedActions: []string{"get", "update"},
+		},
+		{
+			name: "created secret",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testServiceBindingSecretName,
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(newBinding(), bindingControllerKind),
+					},
+				},
+			},
+			expectedActions: []string{"get", "delete"},
+		},
+		{
+			name: "secret adopted by another binding",
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        testServiceBindingSecretName,
+					Namespace:   testNamespace,
+					Annotations: map[string]string{adoptedSecretAnnotation: "other-uid"},
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(otherOwner, bindingControllerKind),
+					},
+				},
+			},
+			expectedActions: []string{"get", "delete"},
+		},
+		{
+			name:            "missing secret",
+			expectedActions: []string{"get", "delete"},
+		},
+	}
+
+	for _, tc := range cases {
+		fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+		if tc.existingSecret != nil {
+			addGetSecretReaction(fakeKubeClient, tc.existingSecret)
+		} else {
+			addGetSecretNotFoundReaction(fakeKubeClient)
+		}
+
+		if err := testController.ejectServiceBinding(newBinding()); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		actions := fakeKubeClient.Actions()
+		assertNumberOfActions(t, actions, len(tc.expectedActions))
+		for i, verb := range tc.expectedActions {
+			assertActionEquals(t, actions[i], verb, "secrets")
+		}
+		if tc.expectedActions[1] != "update" {
+			continue
+		}
+
+		secret := actions[1].(clientgotesting.UpdateAction).GetObject().(*corev1.Secret)
+		if e, a := map[string]string{"team": "bar"}, secret.Annotations; !reflect.DeepEqual(e, a) {
+			t.Errorf("%v: unexpected annotations; expected: %v; actual: %v", tc.name, e, a)
+		}
+		if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "other-uid" {
+			t.Errorf("%v: expected only the binding's owner reference to be removed, got %v", tc.name, secret.OwnerReferences)
+		}
+	}
+}
+
+// TestReconcileBindingPodPreset tests that the PodPreset described by a
+// binding's PodPresetTemplate is created, updated or left alone as needed.
+func TestReconcileBindingPodPreset(t *testing.T) {
+	newBinding := func() *v1beta1.ServiceBinding {
+		b := getTestServiceBinding()
+		b.UID = "binding-uid"
+		b.Spec.SecretName = testServiceBindingSecretName
+		b.Spec.PodPresetTemplate = &v1beta1.PodPresetTemplate{
+			Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			EnvPrefix: "DB_",
+			MountPath: "/etc/db",
+		}
+		return b
+	}
+	otherOwner := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: testNamespace, UID: "other-uid"},
+	}
+
+	cases := []struct {
+		name              string
+		enablePodPreset   bool
+		existingPodPreset func(*v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset
+		expectedError     bool
+		expectedActions   []string
+	}{
+		{
+			name:            "feature gate disabled",
+			enablePodPreset: false,
+		},
+		{
+			name:            "create PodPreset",
+			enablePodPreset: true,
+			expectedActions: []string{"get", "create"},
+		},
+		{
+			name:            "up-to-date PodPreset",
+			enablePodPreset: true,
+			existingPodPreset: func(b *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+				return podPresetForBinding(b)
+			},
+			expectedActions: []string{"get"},
+		},
+		{
+			name:            "outdated PodPreset",
+			enablePodPreset: true,
+			existingPodPreset: func(b *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+				pp := podPresetForBinding(b)
+				pp.Spec.Volumes = nil
+				pp.Spec.VolumeMounts = nil
+				return pp
+			},
+			expectedActions: []string{"get", "update"},
+		},
+		{
+			name:            "PodPreset owned by another object",
+			enablePodPreset: true,
+			existingPodPreset: func(b *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+				pp := podPresetForBinding(b)
+				pp.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(otherOwner, bindingControllerKind)}
+				return pp
+			},
+			expectedError:   true,
+			expectedActions: []string{"get"},
+		},
+	}
+
+	for _, tc := range cases {
+		func() {
+			if tc.enablePodPreset {
+				utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PodPreset))
+				defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+			}
+
+			_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+			binding := newBinding()
+			if tc.existingPodPreset != nil {
+				existing := tc.existingPodPreset(binding)
+				fakeCatalogClient.AddReactor("get", "podpresets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+					return true, existing, nil
+				})
+			} else {
+				fakeCatalogClient.AddReactor("get", "podpresets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+				})
+			}
+
+			err := testController.reconcileBindingPodPreset(binding)
+			if tc.expectedError && err == nil {
+				t.Errorf("%v: expected error", tc.name)
+			} else if !tc.expectedError && err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, len(tc.expectedActions))
+			for i, verb := range tc.expectedActions {
+				assertActionEquals(t, actions[i], verb, "podpresets")
+			}
+			if len(actions) == 2 && actions[1].GetVerb() == "create" {
+				podPreset := actions[1].(clientgotesting.CreateAction).GetObject().(*settingsv1alpha1.PodPreset)
+				if !metav1.IsControlledBy(podPreset, binding) {
+					t.Errorf("%v: expected PodPreset to be controlled by the binding", tc.name)
+				}
+				if e, a := "DB_", podPreset.Spec.EnvFrom[0].Prefix; e != a {
+					t.Errorf("%v: unexpected env prefix; expected: %v; actual: %v", tc.name, e, a)
+				}
+				if e, a := testServiceBindingSecretName, podPreset.Spec.EnvFrom[0].SecretRef.Name; e != a {
+					t.Errorf("%v: unexpected secret reference; expected: %v; actual: %v", tc.name, e, a)
+				}
+				if e, a := "/etc/db", podPreset.Spec.VolumeMounts[0].MountPath; e != a {
+					t.Errorf("%v: unexpected mount path; expected: %v; actual: %v", tc.name, e, a)
+				}
+			}
+		}()
+	}
+}
+
+// TestEjectServiceBindingDeletesPodPreset tests that unbinding deletes the
+// PodPreset generated for the binding, but not a PodPreset with the same name
+// that the binding doesn't control.
+func TestEjectServiceBindingDeletesPodPreset(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PodPreset))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+
+	otherOwner := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-binding", Namespace: testNamespace, UID: "other-uid"},
+	}
+
+	cases := []struct {
+		name              string
+		existingPodPreset func(*v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset
+		expectedActions   []string
+	}{
+		{
+			name:              "PodPreset owned by the binding",
+			existingPodPreset: podPresetForBinding,
+			expectedActions:   []string{"get", "delete"},
+		},
+		{
+			name:            "missing PodPreset",
+			expectedActions: []string{"get"},
+		},
+		{
+			name: "PodPreset created by a user",
+			existingPodPreset: func(b *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+				pp := podPresetForBinding(b)
+				pp.OwnerReferences = nil
+				return pp
+			},
+			expectedActions: []string{"get"},
+		},
+		{
+			name: "PodPreset owned by another object",
+			existingPodPreset: func(b *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+				pp := podPresetForBinding(b)
+				pp.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(otherOwner, bindingControllerKind)}
+				return pp
+			},
+			expectedActions: []string{"get"},
+		},
+	}
+
+	for _, tc := range cases {
+		fakeKubeClient, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+		binding := getTestServiceBinding()
+		binding.Spec.SecretName = testServiceBindingSecretName
+		binding.Spec.PodPresetTemplate = &v1beta1.PodPresetTemplate{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+		}
+		fakeCatalogClient.AddReactor("get", "podpresets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+			if tc.existingPodPreset == nil {
+				return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+			}
+			return true, tc.existingPodPreset(binding), nil
+		})
+
+		if err := testController.ejectServiceBinding(binding); err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.name, err)
+		}
+
+		assertDeleteSecretAction(t, fakeKubeClient.Actions(), testServiceBindingSecretName)
+		actions := fakeCatalogClient.Actions()
+		assertNumberOfActions(t, actions, len(tc.expectedActions))
+		for i, verb := range tc.expectedActions {
+			assertActionEquals(t, actions[i], verb, "podpresets")
+		}
+	}
+}
+
+// TestRestartBindingWorkloads tests that the workloads selected by a
+// binding's WorkloadRestart record the keyed credentials checksum, and that
+// the workloads that did not record it yet, including the workloads that have
+// no checksum at all, are restarted.
+func TestRestartBindingWorkloads(t *testing.T) {
+	secretData := map[string][]byte{"password": []byte("secret")}
+	binding := getTestServiceBinding()
+	checksum, err := generateKeyedChecksumOfSecretData([]byte(binding.UID), secretData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unkeyedChecksum, err := generateChecksumOfSecretData(secretData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checksum == unkeyedChecksum {
+		t.Fatalf("expected the checksum to be keyed with the binding UID")
+	}
+	annotation := credentialsChecksumAnnotation(testServiceBindingName)
+
+	labels := map[string]string{"app": "foo"}
+	outdated := map[string]string{annotation: "outdated"}
+
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	fakeKubeClient.AddReactor("list", "deployments", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &appsv1.DeploymentList{Items: []appsv1.Deployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "outdated", Namespace: testNamespace, Labels: labels, Annotations: outdated}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "up-to-date", Namespace: testNamespace, Labels: labels, Annotations: map[string]string{annotation: checksum}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "unrecorded", Namespace: testNamespace, Labels: labels}},
+		}}, nil
+	})
+	fakeKubeClient.AddReactor("list", "statefulsets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &appsv1.StatefulSetList{Items: []appsv1.StatefulSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "db-consumer", Namespace: testNamespace, Labels: labels, Annotations: outdated}},
+		}}, nil
+	})
+	fakeKubeClient.AddReactor("list", "daemonsets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &appsv1.DaemonSetList{}, nil
+	})
+
+	binding.Spec.WorkloadRestart = &v1beta1.WorkloadRestart{
+		Selector: metav1.LabelSelector{MatchLabels: labels},
+	}
+
+	if err := testController.restartBindingWorkloads(binding, secretData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, actions, 6)
+	assertActionEquals(t, actions[0], "list", "deployments")
+	if e, a := "app=foo", actions[0].(clientgotesting.ListAction).GetListRestrictions().Labels.String(); e != a {
+		t.Errorf("unexpected label selector; expected: %v; actual: %v", e, a)
+	}
+	assertActionEquals(t, actions[1], "patch", "deployments")
+	assertActionEquals(t, actions[2], "patch", "deployments")
+	assertActionEquals(t, actions[3], "list", "statefulsets")
+	assertActionEquals(t, actions[4], "patch", "statefulsets")
+	assertActionEquals(t, actions[5], "list", "daemonsets")
+
+	for i, name := range map[int]string{1: "outdated", 2: "unrecorded", 4: "db-consumer"} {
+		patch := actions[i].(clientgotesting.PatchAction)
+		if e, a := name, patch.GetName(); e != a {
+			t.Errorf("unexpected patched workload; expected: %v; actual: %v", e, a)
+		}
+		if !strings.Contains(string(patch.GetPatch()), checksum) {
+			t.Errorf("expected patch of %s to contain the credentials checksum, got %s", name, patch.GetPatch())
+		}
+		if !strings.Contains(string(patch.GetPatch()), `"template"`) {
+			t.Errorf("expected patch of %s to update the pod template, got %s", name, patch.GetPatch())
+		}
+	}
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 1)
+	expectedEvent := normalEventBuilder(successRestartedWorkloadsReason).msg(
+		"Restarted workloads consuming the credentials: Deployment/outdated, Deployment/unrecorded, StatefulSet/db-consumer",
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCredentialsChecksumAnnotation(t *testing.T) {
+	if e, a := "credentials.servicecatalog.k8s.io/db", credentialsChecksumAnnotation("db"); e != a {
+		t.Errorf("unexpected annotation; expected: %v; actual: %v", e, a)
+	}
+	long := strings.Repeat("a", 100)
+	annotation := credentialsChecksumAnnotation(long)
+	if errs := validation.IsQualifiedName(annotation); len(errs) != 0 {
+		t.Errorf("expected a valid annotation key, got %q: %v", annotation, errs)
+	}
+	if annotation == credentialsChecksumAnnotation(long+"b") {
+		t.Errorf("expected long binding names to map to distinct annotations")
+	}
+}
+
+// TestRefreshServiceBinding tests that the credentials of a ready binding to
+// a retrievable class are fetched again from the broker, and that the Secret
+// is only written when they changed.
+func TestRefreshServiceBinding(t *testing.T) {
+	cases := []struct {
+		name                string
+		retrievable         bool
+		refreshed           bool
+		firstSeen           bool
+		getBindingReaction  *fakeosb.GetBindingReaction
+		secretData          map[string][]byte
+		expectedGetBindings int
+		expectedKubeActions []string
+		expectedEvents      []string
+	}{
+		{
+			name:        "credentials unchanged",
+			retrievable: true,
+			getBindingReaction: &fakeosb.GetBindingReaction{
+				Response: &osb.GetBindingResponse{Credentials: map[string]interface{}{"password": "foo"}},
+			},
+			secretData:          map[string][]byte{"password": []byte("foo")},
+			expectedGetBindings: 1,
+			expectedKubeActions: []string{"get"},
+		},
+		{
+			name:        "credentials rotated",
+			retrievable: true,
+			getBindingReaction: &fakeosb.GetBindingReaction{
+				Response: &osb.GetBindingResponse{Credentials: map[string]interface{}{"password": "bar"}},
+			},
+			secretData:          map[string][]byte{"password": []byte("foo")},
+			expectedGetBindings: 1,
+			expectedKubeActions: []string{"get", "get", "update"},
+			expectedEvents: []string{
+				normalEventBuilder(successRefreshedBindingReason).msg("Injected refreshed bind result").String(),
+			},
+		},
+		{
+			name:        "GET failed",
+			retrievable: true,
+			getBindingReaction: &fakeosb.GetBindingReaction{
+				Error: fmt.Errorf("some error"),
+			},
+			secretData:          map[string][]byte{"password": []byte("foo")},
+			expectedGetBindings: 1,
+			expectedEvents: []string{
+				warningEventBuilder(errorRefreshingBindingFailedReason).msg("Could not do a GET on binding resource: some error").String(),
+			},
+		},
+		{
+			name:        "refresh not due yet",
+			retrievable: true,
+			refreshed:   true,
+			secretData:  map[string][]byte{"password": []byte("foo")},
+		},
+		{
+			name:        "first refresh spread over the interval",
+			retrievable: true,
+			firstSeen:   true,
+			secretData:  map[string][]byte{"password": []byte("foo")},
+		},
+		{
+			name:       "class not retrievable",
+			secretData: map[string][]byte{"password": []byte("foo")},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				GetBindingReaction: tc.getBindingReaction,
+			})
+			testController.bindingRefreshInterval = time.Hour
+
+			serviceClass := getTestClusterServiceClass()
+			if tc.retrievable {
+				serviceClass = getTestBindingRetrievableClusterServiceClass()
+			}
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+			binding := getTestServiceBinding()
+			binding.UID = "binding-uid"
+			binding.Spec.SecretName = testServiceBindingSecretName
+			binding.Status.ReconciledGeneration = binding.Generation
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+				Type:   v1beta1.ServiceBindingConditionReady,
+				Status: v1beta1.ConditionTrue,
+			}}
+			if tc.refreshed {
+				testController.recordBindingRefresh(binding)
+			} else if !tc.firstSeen {
+				testController.bindingRefreshTimes[binding.UID] = time.Now().Add(-testController.bindingRefreshInterval)
+			}
+
+			addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            testServiceBindingSecretName,
+					Namespace:       testNamespace,
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+				},
+				Data: tc.secretData,
+			})
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), tc.expectedGetBindings)
+			assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+			kubeActions := fakeKubeClient.Actions()
+			assertNumberOfActions(t, kubeActions, len(tc.expectedKubeActions))
+			for i, verb := range tc.expectedKubeActions {
+				assertActionEquals(t, kubeActions[i], verb, "secrets")
+			}
+
+			events := getRecordedEvents(testController)
+			if err := checkEvents(events, tc.expectedEvents); err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.retrievable {
+				if delay := testController.bindingRefreshDelay(binding); delay <= 0 || delay > testController.bindingRefreshInterval {
+					t.Fatalf("expected the next refresh to be scheduled within one interval, got delay %v", delay)
+				}
+			}
+		})
+	}
+}
+
 func assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t *testing.T, fakeCatalogClient *fake.Clientset, binding *v1beta1.ServiceBinding) *v1beta1.ServiceBinding {
 	return assertServiceBindingOperationInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding, v1beta1.ServiceBindingOperationBind)
 }
@@ -1935,6 +1935,7 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	testController, err := NewController(
 		fakeKubeClient,
 		fakeCatalogClient.ServicecatalogV1beta1(),
+		fakeCatalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
@@ -1949,6 +1950,7 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		0,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
 	)
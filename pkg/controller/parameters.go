@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
@@ -147,6 +148,30 @@ func generateChecksumOfParameters(params map[string]interface{}) (string, error)
 	return fmt.Sprintf("%x", hash), nil
 }
 
+// generateChecksumOfSecretData returns a checksum of the given Secret data
+// that is independent of the order of its keys.
+func generateChecksumOfSecretData(data map[string][]byte) (string, error) {
+	dataAsJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(dataAsJSON)
+	return fmt.Sprintf("%x", hash), nil
+}
+
+// generateKeyedChecksumOfSecretData returns an HMAC of the given Secret data,
+// keyed with key, that is independent of the order of its keys. It only hides
+// the data as well as the key is kept secret.
+func generateKeyedChecksumOfSecretData(key []byte, data map[string][]byte) (string, error) {
+	dataAsJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(dataAsJSON)
+	return fmt.Sprintf("%x", mac.Sum(nil)), nil
+}
+
 // prepareInProgressPropertyParameters generates the required parameters for setting
 // the in-progress status of a Type.
 // Returns (parameters, parametersChecksum, rawParametersWithRedaction, err) where
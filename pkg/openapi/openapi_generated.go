@@ -1290,6 +1290,38 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "PodPresetTemplate describes the PodPreset that Service Catalog generates for a ServiceBinding. The generated PodPreset has the same name as the ServiceBinding and always exposes the keys of the credentials Secret as environment variables of the selected pods' containers.",
+					Properties: map[string]spec.Schema{
+						"selector": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Selector is a label query over the pods that the credentials are injected into. It must not be empty.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							},
+						},
+						"envPrefix": {
+							SchemaProps: spec.SchemaProps{
+								Description: "EnvPrefix is prepended to the name of every environment variable created from the credentials Secret.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"mountPath": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MountPath, if set, is the path at which the credentials Secret is additionally mounted as a read-only volume in the selected pods' containers.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"selector"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1358,6 +1390,44 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTemplate": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "SecretTemplate specifies metadata that Service Catalog applies to the Secret associated with a ServiceBinding, so that other controllers can recognize and react to Service Catalog secrets.",
+					Properties: map[string]spec.Schema{
+						"labels": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Labels that are added to the Secret.",
+								Type:        []string{"object"},
+								AdditionalProperties: &spec.SchemaOrBool{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+						"annotations": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Annotations that are added to the Secret.",
+								Type:        []string{"object"},
+								AdditionalProperties: &spec.SchemaOrBool{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1610,6 +1680,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
+						"secretTemplate": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretTemplate holds metadata that should be applied to the Secret holding the credentials associated with the ServiceBinding.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTemplate"),
+							},
+						},
+						"secretAdoptionPolicy": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretAdoptionPolicy determines what the controller does when a Secret named SecretName already exists but is not controlled by the ServiceBinding. Defaults to \"Never\". A Secret adopted under \"IfNotControlled\" is released, not deleted, when the ServiceBinding is unbound.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"podPresetTemplate": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nPodPresetTemplate, if set, makes the controller maintain a PodPreset that injects the credentials Secret into the pods selected by the template. The PodPreset is deleted when the ServiceBinding is unbound. Requires the PodPreset feature gate.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate"),
+							},
+						},
+						"workloadRestart": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nWorkloadRestart, if set, makes the controller trigger a rolling restart of the Deployments, StatefulSets and DaemonSets in the ServiceBinding's namespace that match its selector whenever the contents of the credentials Secret change.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.WorkloadRestart"),
+							},
+						},
 						"externalID": {
 							SchemaProps: spec.SchemaProps{
 								Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
@@ -1628,7 +1723,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTemplate", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.WorkloadRestart", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus": {
 			Schema: spec.Schema{
@@ -2896,6 +2991,24 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.WorkloadRestart": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "WorkloadRestart selects the workloads that consume the credentials of a ServiceBinding. The controller records a checksum of the credentials, keyed with the UID of the ServiceBinding, in an annotation of every selected workload. When a workload does not record the current checksum yet, it also sets the checksum in the pod template, which makes the workload controller roll out new pods. The UID is not secret, so anyone who can read the workload and the ServiceBinding can check a guess of the credentials against the checksum.",
+					Properties: map[string]spec.Schema{
+						"selector": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Selector is a label query over the Deployments, StatefulSets and DaemonSets to restart. It must not be empty.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							},
+						},
+					},
+					Required: []string{"selector"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1.PodPreset": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
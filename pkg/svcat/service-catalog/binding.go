@@ -17,18 +17,19 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
-	"sync"
 	"time"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // RetrieveBindings lists all bindings in a namespace.
@@ -41,11 +42,21 @@ func (sdk *SDK) RetrieveBindings(ns string) (*v1beta1.ServiceBindingList, error)
 	return bindings, nil
 }
 
-// RetrieveBinding gets a binding by its name.
+// RetrieveBinding gets a binding by its name. A NotFoundError is returned
+// when the binding does not exist.
 func (sdk *SDK) RetrieveBinding(ns, name string) (*v1beta1.ServiceBinding, error) {
+	return sdk.RetrieveBindingContext(context.Background(), ns, name)
+}
+
+// RetrieveBindingContext is RetrieveBinding, failing with the error of the
+// context if it is done before the request is sent.
+func (sdk *SDK) RetrieveBindingContext(ctx context.Context, ns, name string) (*v1beta1.ServiceBinding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	binding, err := sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to get binding '%s.%s'", ns, name)
+		return nil, getError("binding", ns+"."+name, err)
 	}
 	return binding, nil
 }
@@ -72,6 +83,16 @@ func (sdk *SDK) RetrieveBindingsByInstance(instance *v1beta1.ServiceInstance,
 // Bind an instance to a secret.
 func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretName string,
 	params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error) {
+	return sdk.BindContext(context.Background(), namespace, bindingName, externalID, instanceName, secretName, params, secrets)
+}
+
+// BindContext is Bind, failing with the error of the context if it is done
+// before the request is sent.
+func (sdk *SDK) BindContext(ctx context.Context, namespace, bindingName, externalID, instanceName, secretName string,
+	params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Manually defaulting the name of the binding
 	// I'm not doing the same for the secret since the API handles defaulting that value.
@@ -105,10 +126,20 @@ func (sdk *SDK) Bind(namespace, bindingName, externalID, instanceName, secretNam
 
 // Unbind deletes all bindings associated to an instance.
 func (sdk *SDK) Unbind(ns, instanceName string) ([]types.NamespacedName, error) {
-	instance, err := sdk.RetrieveInstance(ns, instanceName)
+	return sdk.UnbindContext(context.Background(), ns, instanceName)
+}
+
+// UnbindContext is Unbind, failing with the error of the context if it is
+// done before one of its requests is sent. The bindings deleted until then
+// are returned.
+func (sdk *SDK) UnbindContext(ctx context.Context, ns, instanceName string) ([]types.NamespacedName, error) {
+	instance, err := sdk.RetrieveInstanceContext(ctx, ns, instanceName)
 	if err != nil {
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	bindings, err := sdk.RetrieveBindingsByInstance(instance)
 	if err != nil {
 		return nil, err
@@ -118,50 +149,35 @@ func (sdk *SDK) Unbind(ns, instanceName string) ([]types.NamespacedName, error)
 	for _, b := range bindings {
 		namespacedNames = append(namespacedNames, types.NamespacedName{b.Namespace, b.Name})
 	}
-	return sdk.DeleteBindings(namespacedNames)
+	return ForEach(namespacedNames, 0, func(binding types.NamespacedName) error {
+		return sdk.DeleteBindingContext(ctx, binding.Namespace, binding.Name)
+	})
 }
 
 // DeleteBindings deletes bindings by name.
 func (sdk *SDK) DeleteBindings(bindings []types.NamespacedName) ([]types.NamespacedName, error) {
-	var g sync.WaitGroup
-	errs := make(chan error, len(bindings))
-	deletedBindings := make(chan types.NamespacedName, len(bindings))
-	for _, binding := range bindings {
-		g.Add(1)
-		go func(binding types.NamespacedName) {
-			defer g.Done()
-			err := sdk.DeleteBinding(binding.Namespace, binding.Name)
-			if err == nil {
-				deletedBindings <- binding
-			}
-			errs <- err
-		}(binding)
-	}
-
-	g.Wait()
-	close(errs)
-	close(deletedBindings)
-
-	// Collect any errors that occurred into a single formatted error
-	bindErr := &multierror.Error{
-		ErrorFormat: func(errors []error) string {
-			return joinErrors("error:", errors, "\n  ")
-		},
-	}
-	for err := range errs {
-		bindErr = multierror.Append(bindErr, err)
-	}
+	return sdk.DeleteBindingsConcurrently(bindings, 0)
+}
 
-	//Range over the deleted bindings to build a slice to return
-	deleted := []types.NamespacedName(nil)
-	for b := range deletedBindings {
-		deleted = append(deleted, b)
-	}
-	return deleted, bindErr.ErrorOrNil()
+// DeleteBindingsConcurrently deletes bindings by name, deleting at most
+// concurrency bindings at the same time, or all of them when concurrency is 0.
+func (sdk *SDK) DeleteBindingsConcurrently(bindings []types.NamespacedName, concurrency int) ([]types.NamespacedName, error) {
+	return ForEach(bindings, concurrency, func(binding types.NamespacedName) error {
+		return sdk.DeleteBinding(binding.Namespace, binding.Name)
+	})
 }
 
 // DeleteBinding by name.
 func (sdk *SDK) DeleteBinding(ns, bindingName string) error {
+	return sdk.DeleteBindingContext(context.Background(), ns, bindingName)
+}
+
+// DeleteBindingContext is DeleteBinding, failing with the error of the
+// context if it is done before the request is sent.
+func (sdk *SDK) DeleteBindingContext(ctx context.Context, ns, bindingName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	err := sdk.ServiceCatalog().ServiceBindings(ns).Delete(bindingName, &v1.DeleteOptions{})
 	if err != nil {
 		return errors.Wrapf(err, "remove binding %s/%s failed", ns, bindingName)
@@ -185,7 +201,7 @@ func joinErrors(groupMsg string, errors []error, sep string, a ...interface{}) s
 
 // BindingParentHierarchy retrieves all ancestor resources of a binding.
 func (sdk *SDK) BindingParentHierarchy(binding *v1beta1.ServiceBinding,
-) (*v1beta1.ServiceInstance, *v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, *v1beta1.ClusterServiceBroker, error) {
+) (*v1beta1.ServiceInstance, Class, Plan, Broker, error) {
 	instance, err := sdk.RetrieveInstanceByBinding(binding)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -235,10 +251,46 @@ func (sdk *SDK) WaitForBinding(ns, name string, interval time.Duration, timeout
 			return isDone, nil
 		},
 	)
+	if err == wait.ErrWaitTimeout {
+		err = &TimeoutError{Kind: "binding", Name: ns + "/" + name}
+	}
 
 	return binding, err
 }
 
+// WaitForBindingContext waits for the binding to complete the current
+// operation, watching it for changes until the context is done. A
+// FailedError is returned when the operation failed, and a TimeoutError when
+// the deadline of the context is exceeded. A nil binding is returned when
+// the binding is deleted.
+func (sdk *SDK) WaitForBindingContext(ctx context.Context, ns, name string) (binding *v1beta1.ServiceBinding, err error) {
+	err = sdk.watchBindingUntil(ctx, ns, name, func(b *v1beta1.ServiceBinding) (bool, error) {
+		binding = b
+		if b == nil {
+			return true, nil
+		}
+		return operationDone("binding", ns+"/"+name, bindingConditions(b))
+	})
+	return binding, err
+}
+
+// watchBindingUntil watches a binding for changes until done returns true or
+// an error, or the context is done. done is called with nil once the binding
+// does not exist.
+func (sdk *SDK) watchBindingUntil(ctx context.Context, ns, name string, done func(*v1beta1.ServiceBinding) (bool, error)) error {
+	return watchUntil(ctx, "binding", ns+"/"+name, name,
+		func() (runtime.Object, error) {
+			return sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
+		},
+		func(opts v1.ListOptions) (watch.Interface, error) {
+			return sdk.ServiceCatalog().ServiceBindings(ns).Watch(opts)
+		},
+		func(obj runtime.Object) (bool, error) {
+			binding, _ := obj.(*v1beta1.ServiceBinding)
+			return done(binding)
+		})
+}
+
 // IsBindingReady returns if the instance is in the Ready status.
 func (sdk *SDK) IsBindingReady(binding *v1beta1.ServiceBinding) bool {
 	return sdk.BindingHasStatus(binding, v1beta1.ServiceBindingConditionReady)
@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -24,31 +25,148 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// RetrieveBrokers lists all brokers defined in the cluster.
-func (sdk *SDK) RetrieveBrokers() ([]v1beta1.ClusterServiceBroker, error) {
-	brokers, err := sdk.ServiceCatalog().ClusterServiceBrokers().List(v1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list brokers (%s)", err)
+// Broker provides a unified view over ClusterServiceBrokers and
+// ServiceBrokers.
+type Broker interface {
+	// GetName returns the broker's name.
+	GetName() string
+
+	// GetNamespace returns the broker's namespace, or "" if it is
+	// cluster-scoped.
+	GetNamespace() string
+
+	// GetURL returns the broker's URL.
+	GetURL() string
+
+	// GetSpec returns the spec shared by all brokers.
+	GetSpec() v1beta1.CommonServiceBrokerSpec
+
+	// GetStatus returns the status shared by all brokers.
+	GetStatus() v1beta1.CommonServiceBrokerStatus
+}
+
+// RegisterOptions holds the optional settings of a broker being registered.
+type RegisterOptions struct {
+	// BasicSecret is the name of the secret holding the basic auth
+	// credentials of the broker.
+	BasicSecret string
+
+	// BearerSecret is the name of the secret holding the bearer token of
+	// the broker.
+	BearerSecret string
+
+	// CABundle is the PEM encoded CA bundle used to validate the broker's
+	// serving certificate.
+	CABundle []byte
+
+	// RelistBehavior is the behavior used to relist the broker's catalog.
+	RelistBehavior v1beta1.ServiceBrokerRelistBehavior
+
+	// RelistDuration is the interval between catalog relists when
+	// RelistBehavior is Duration.
+	RelistDuration *v1.Duration
+
+	// ClassRestrictions are the selectors restricting the classes loaded
+	// from the broker's catalog.
+	ClassRestrictions []string
+
+	// PlanRestrictions are the selectors restricting the plans loaded
+	// from the broker's catalog.
+	PlanRestrictions []string
+}
+
+// RetrieveBrokers lists all brokers defined in the selected scope.
+func (sdk *SDK) RetrieveBrokers(opts ScopeOptions) ([]Broker, error) {
+	return sdk.RetrieveBrokersContext(context.Background(), opts)
+}
+
+// RetrieveBrokersContext is RetrieveBrokers, failing with the error of the
+// context if it is done before the requests are sent.
+func (sdk *SDK) RetrieveBrokersContext(ctx context.Context, opts ScopeOptions) ([]Broker, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return brokers.Items, nil
+	var brokers []Broker
+
+	if opts.Scope.Matches(ClusterScope) {
+		csb, err := sdk.ServiceCatalog().ClusterServiceBrokers().List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list brokers (%s)", err)
+		}
+		for i := range csb.Items {
+			brokers = append(brokers, &csb.Items[i])
+		}
+	}
+
+	if opts.Scope.Matches(NamespaceScope) {
+		sb, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).List(v1.ListOptions{})
+		if err == nil {
+			for i := range sb.Items {
+				brokers = append(brokers, &sb.Items[i])
+			}
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, fmt.Errorf("unable to list brokers in %q (%s)", opts.Namespace, err)
+		}
+	}
+
+	return brokers, nil
 }
 
-// RetrieveBroker gets a broker by its name.
-func (sdk *SDK) RetrieveBroker(name string) (*v1beta1.ClusterServiceBroker, error) {
-	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to get broker '%s' (%s)", name, err)
+// RetrieveBroker gets a broker by its name. A NotFoundError is returned when
+// no broker matches in the selected scope, and an AmbiguousError when both a
+// cluster and a namespaced broker match.
+func (sdk *SDK) RetrieveBroker(name string, opts ScopeOptions) (Broker, error) {
+	return sdk.RetrieveBrokerContext(context.Background(), name, opts)
+}
+
+// RetrieveBrokerContext is RetrieveBroker, failing with the error of the
+// context if it is done before the request is sent.
+func (sdk *SDK) RetrieveBrokerContext(ctx context.Context, name string, opts ScopeOptions) (Broker, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return broker, nil
+	var found []Broker
+
+	if opts.Scope.Matches(ClusterScope) {
+		broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+		if err == nil {
+			found = append(found, broker)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("broker", name, err)
+		}
+	}
+	if opts.Scope.Matches(NamespaceScope) {
+		broker, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		if err == nil {
+			found = append(found, broker)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("broker", name, err)
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, &NotFoundError{Kind: "broker", Name: name}
+	}
+	if len(found) > 1 {
+		return nil, &AmbiguousError{Kind: "broker", Name: name}
+	}
+	return found[0], nil
 }
 
 // RetrieveBrokerByClass gets the parent broker of a class.
-func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
-) (*v1beta1.ClusterServiceBroker, error) {
-	brokerName := class.Spec.ClusterServiceBrokerName
-	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(brokerName, v1.GetOptions{})
+func (sdk *SDK) RetrieveBrokerByClass(class Class) (Broker, error) {
+	brokerName := class.GetServiceBrokerName()
+	if class.GetNamespace() == "" {
+		broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(brokerName, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return broker, nil
+	}
+
+	broker, err := sdk.ServiceCatalog().ServiceBrokers(class.GetNamespace()).Get(brokerName, v1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -57,11 +175,20 @@ func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
 
 // Sync or relist a broker to refresh its catalog metadata.
 func (sdk *SDK) Sync(name string, retries int) error {
+	return sdk.SyncContext(context.Background(), name, retries)
+}
+
+// SyncContext is Sync, failing with the error of the context if it is done
+// before one of the retries.
+func (sdk *SDK) SyncContext(ctx context.Context, name string, retries int) error {
 	for j := 0; j < retries; j++ {
-		catalog, err := sdk.RetrieveBroker(name)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
+		catalog, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+		if err != nil {
+			return getError("broker", name, err)
+		}
 
 		catalog.Spec.RelistRequests = catalog.Spec.RelistRequests + 1
 
@@ -76,3 +203,103 @@ func (sdk *SDK) Sync(name string, retries int) error {
 
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
+
+// Register creates a broker. A ClusterServiceBroker is created for the
+// cluster scope, in which case the auth secrets are looked up in the given
+// namespace, and a ServiceBroker is created in the namespace otherwise.
+func (sdk *SDK) Register(name, url string, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error) {
+	spec := v1beta1.CommonServiceBrokerSpec{
+		URL:            url,
+		CABundle:       opts.CABundle,
+		RelistBehavior: opts.RelistBehavior,
+		RelistDuration: opts.RelistDuration,
+	}
+	if len(opts.ClassRestrictions) > 0 || len(opts.PlanRestrictions) > 0 {
+		spec.CatalogRestrictions = &v1beta1.CatalogRestrictions{
+			ServiceClass: opts.ClassRestrictions,
+			ServicePlan:  opts.PlanRestrictions,
+		}
+	}
+
+	if scopeOpts.Scope == NamespaceScope {
+		request := &v1beta1.ServiceBroker{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      name,
+				Namespace: scopeOpts.Namespace,
+			},
+			Spec: v1beta1.ServiceBrokerSpec{
+				CommonServiceBrokerSpec: spec,
+			},
+		}
+		if opts.BasicSecret != "" {
+			request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+				Basic: &v1beta1.BasicAuthConfig{
+					SecretRef: &v1beta1.LocalObjectReference{Name: opts.BasicSecret},
+				},
+			}
+		} else if opts.BearerSecret != "" {
+			request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
+				Bearer: &v1beta1.BearerTokenAuthConfig{
+					SecretRef: &v1beta1.LocalObjectReference{Name: opts.BearerSecret},
+				},
+			}
+		}
+
+		broker, err := sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Create(request)
+		if err != nil {
+			return nil, fmt.Errorf("register request failed (%s)", err)
+		}
+		return broker, nil
+	}
+
+	request := &v1beta1.ClusterServiceBroker{
+		ObjectMeta: v1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1beta1.ClusterServiceBrokerSpec{
+			CommonServiceBrokerSpec: spec,
+		},
+	}
+	if opts.BasicSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
+			Basic: &v1beta1.ClusterBasicAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{Name: opts.BasicSecret, Namespace: scopeOpts.Namespace},
+			},
+		}
+	} else if opts.BearerSecret != "" {
+		request.Spec.AuthInfo = &v1beta1.ClusterServiceBrokerAuthInfo{
+			Bearer: &v1beta1.ClusterBearerTokenAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{Name: opts.BearerSecret, Namespace: scopeOpts.Namespace},
+			},
+		}
+	}
+
+	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("register request failed (%s)", err)
+	}
+	return broker, nil
+}
+
+// Deregister deletes a broker.
+func (sdk *SDK) Deregister(name string, scopeOpts *ScopeOptions) error {
+	return sdk.DeregisterContext(context.Background(), name, scopeOpts)
+}
+
+// DeregisterContext is Deregister, failing with the error of the context if
+// it is done before the request is sent.
+func (sdk *SDK) DeregisterContext(ctx context.Context, name string, scopeOpts *ScopeOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var err error
+	if scopeOpts.Scope == NamespaceScope {
+		err = sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Delete(name, &v1.DeleteOptions{})
+	} else {
+		err = sdk.ServiceCatalog().ClusterServiceBrokers().Delete(name, &v1.DeleteOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("deregister request failed (%s)", err)
+	}
+	return nil
+}
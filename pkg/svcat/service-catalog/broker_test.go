@@ -37,12 +37,14 @@ var _ = Describe("Broker", func() {
 		svcCatClient *fake.Clientset
 		sb           *v1beta1.ClusterServiceBroker
 		sb2          *v1beta1.ClusterServiceBroker
+		nsb          *v1beta1.ServiceBroker
 	)
 
 	BeforeEach(func() {
 		sb = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
 		sb2 = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "barbaz"}}
-		svcCatClient = fake.NewSimpleClientset(sb, sb2)
+		nsb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+		svcCatClient = fake.NewSimpleClientset(sb, sb2, nsb)
 		sdk = &SDK{
 			ServiceCatalogClient: svcCatClient,
 		}
@@ -50,12 +52,31 @@ var _ = Describe("Broker", func() {
 
 	Describe("RetrieveBrokers", func() {
 		It("Calls the generated v1beta1 List method", func() {
-			brokers, err := sdk.RetrieveBrokers()
+			brokers, err := sdk.RetrieveBrokers(ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(brokers).Should(ConsistOf(*sb, *sb2))
+			Expect(brokers).Should(ConsistOf(sb, sb2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterservicebrokers")).To(BeTrue())
 		})
+		It("Lists the namespaced brokers", func() {
+			brokers, err := sdk.RetrieveBrokers(ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).Should(ConsistOf(nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("list", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("default"))
+		})
+		It("Lists the brokers of all scopes", func() {
+			brokers, err := sdk.RetrieveBrokers(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).Should(ConsistOf(sb, sb2, nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "clusterservicebrokers")).To(BeTrue())
+			Expect(actions[1].Matches("list", "servicebrokers")).To(BeTrue())
+		})
 		It("Bubbles up errors", func() {
 			badClient := &fake.Clientset{}
 			errorMessage := "error retrieving list"
@@ -63,7 +84,7 @@ var _ = Describe("Broker", func() {
 				return true, nil, fmt.Errorf(errorMessage)
 			})
 			sdk.ServiceCatalogClient = badClient
-			_, err := sdk.RetrieveBrokers()
+			_, err := sdk.RetrieveBrokers(ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -72,7 +93,7 @@ var _ = Describe("Broker", func() {
 	})
 	Describe("RetrieveBroker", func() {
 		It("Calls the generated v1beta1 List method with the passed in broker", func() {
-			broker, err := sdk.RetrieveBroker(sb.Name)
+			broker, err := sdk.RetrieveBroker(sb.Name, ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(broker).To(Equal(sb))
@@ -80,10 +101,32 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].Matches("get", "clusterservicebrokers")).To(BeTrue())
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(sb.Name))
 		})
+		It("Gets a namespaced broker", func() {
+			broker, err := sdk.RetrieveBroker(nsb.Name, ScopeOptions{Scope: NamespaceScope, Namespace: nsb.Namespace})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(nsb.Namespace))
+		})
+		It("Finds a broker in either scope", func() {
+			broker, err := sdk.RetrieveBroker(sb2.Name, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(sb2))
+		})
+		It("Rejects a broker name found in both scopes", func() {
+			broker, err := sdk.RetrieveBroker(sb.Name, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("more than one matching broker"))
+		})
 		It("Bubbles up errors", func() {
 			brokerName := "banana"
 
-			broker, err := sdk.RetrieveBroker(brokerName)
+			broker, err := sdk.RetrieveBroker(brokerName, ScopeOptions{Scope: ClusterScope})
 
 			Expect(broker).To(BeNil())
 			Expect(err).To(HaveOccurred())
@@ -104,6 +147,19 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].Matches("get", "clusterservicebrokers")).To(BeTrue())
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(sb.Name))
 		})
+		It("Gets the namespaced broker of a namespaced class", func() {
+			sc := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Namespace: nsb.Namespace},
+				Spec:       v1beta1.ServiceClassSpec{ServiceBrokerName: nsb.Name},
+			}
+			broker, err := sdk.RetrieveBrokerByClass(sc)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(nsb))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(nsb.Namespace))
+		})
 
 		It("Bubbles up errors", func() {
 			brokerName := "banana"
@@ -132,4 +188,75 @@ var _ = Describe("Broker", func() {
 			Expect(actions[1].(testing.UpdateActionImpl).Object.(*v1beta1.ClusterServiceBroker).Spec.RelistRequests).Should(BeNumerically(">", 0))
 		})
 	})
+	Describe("Register", func() {
+		It("Creates a ClusterServiceBroker with auth secrets in the given namespace", func() {
+			opts := &RegisterOptions{
+				BasicSecret:       "auth",
+				RelistBehavior:    v1beta1.ServiceBrokerRelistBehaviorManual,
+				ClassRestrictions: []string{"name==mysqldb"},
+			}
+			scopeOpts := &ScopeOptions{Scope: ClusterScope, Namespace: "brokers"}
+
+			broker, err := sdk.Register("newbroker", "http://broker.com", opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.GetName()).To(Equal("newbroker"))
+			Expect(broker.GetURL()).To(Equal("http://broker.com"))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "clusterservicebrokers")).To(BeTrue())
+			created := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(created.Spec.RelistBehavior).To(Equal(v1beta1.ServiceBrokerRelistBehaviorManual))
+			Expect(created.Spec.AuthInfo.Basic.SecretRef).To(Equal(&v1beta1.ObjectReference{Name: "auth", Namespace: "brokers"}))
+			Expect(created.Spec.CatalogRestrictions.ServiceClass).To(ConsistOf("name==mysqldb"))
+		})
+		It("Creates a ServiceBroker for the namespace scope", func() {
+			opts := &RegisterOptions{BearerSecret: "token"}
+			scopeOpts := &ScopeOptions{Scope: NamespaceScope, Namespace: "brokers"}
+
+			broker, err := sdk.Register("newbroker", "http://broker.com", opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.GetNamespace()).To(Equal("brokers"))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("brokers"))
+			created := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(created.Spec.AuthInfo.Bearer.SecretRef).To(Equal(&v1beta1.LocalObjectReference{Name: "token"}))
+			Expect(created.Spec.CatalogRestrictions).To(BeNil())
+		})
+		It("Bubbles up errors", func() {
+			_, err := sdk.Register(sb.Name, "http://broker.com", &RegisterOptions{}, &ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("already exists"))
+		})
+	})
+	Describe("Deregister", func() {
+		It("Deletes the ClusterServiceBroker", func() {
+			err := sdk.Deregister(sb.Name, &ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "clusterservicebrokers")).To(BeTrue())
+			Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(sb.Name))
+		})
+		It("Deletes the ServiceBroker for the namespace scope", func() {
+			nsb := &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "brokers"}}
+			svcCatClient = fake.NewSimpleClientset(nsb)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			err := sdk.Deregister(nsb.Name, &ScopeOptions{Scope: NamespaceScope, Namespace: nsb.Namespace})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("delete", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(nsb.Namespace))
+		})
+		It("Bubbles up errors", func() {
+			err := sdk.Deregister("banana", &ScopeOptions{Scope: ClusterScope})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not found"))
+		})
+	})
 })
@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultConcurrency is the number of resources that bulk operations change
+// at the same time.
+const DefaultConcurrency = 5
+
+// SelectInstances returns the names of the instances in a namespace matching
+// a label selector, or of all of them when the selector is empty.
+func (sdk *SDK) SelectInstances(ns, selector string) ([]types.NamespacedName, error) {
+	instances, err := sdk.ServiceCatalog().ServiceInstances(ns).List(v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list instances in %s (%s)", ns, err)
+	}
+
+	names := make([]types.NamespacedName, 0, len(instances.Items))
+	for _, instance := range instances.Items {
+		names = append(names, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+	}
+	return names, nil
+}
+
+// SelectBindings returns the names of the bindings in a namespace matching a
+// label selector, or of all of them when the selector is empty.
+func (sdk *SDK) SelectBindings(ns, selector string) ([]types.NamespacedName, error) {
+	bindings, err := sdk.ServiceCatalog().ServiceBindings(ns).List(v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list bindings in %s", ns)
+	}
+
+	names := make([]types.NamespacedName, 0, len(bindings.Items))
+	for _, binding := range bindings.Items {
+		names = append(names, types.NamespacedName{Namespace: binding.Namespace, Name: binding.Name})
+	}
+	return names, nil
+}
+
+// ForEach calls op for every resource, running at most concurrency calls at
+// the same time, or all of them when concurrency is 0. It returns the
+// resources for which op succeeded, in their original order, along with the
+// errors of the others collected into a single error.
+func ForEach(resources []types.NamespacedName, concurrency int, op func(types.NamespacedName) error) ([]types.NamespacedName, error) {
+	if concurrency <= 0 || concurrency > len(resources) {
+		concurrency = len(resources)
+	}
+
+	errs := make([]error, len(resources))
+	slots := make(chan struct{}, concurrency)
+	var g sync.WaitGroup
+	for i, resource := range resources {
+		g.Add(1)
+		slots <- struct{}{}
+		go func(i int, resource types.NamespacedName) {
+			defer g.Done()
+			errs[i] = op(resource)
+			<-slots
+		}(i, resource)
+	}
+	g.Wait()
+
+	// Collect any errors that occurred into a single formatted error
+	bulkErr := &multierror.Error{
+		ErrorFormat: func(errors []error) string {
+			return joinErrors("error:", errors, "\n  ")
+		},
+	}
+	var succeeded []types.NamespacedName
+	for i, err := range errs {
+		if err != nil {
+			bulkErr = multierror.Append(bulkErr, err)
+			continue
+		}
+		succeeded = append(succeeded, resources[i])
+	}
+	return succeeded, bulkErr.ErrorOrNil()
+}
+
+// DeprovisionInstances deletes instances by name, deleting at most
+// concurrency instances at the same time.
+func (sdk *SDK) DeprovisionInstances(instances []types.NamespacedName, concurrency int) ([]types.NamespacedName, error) {
+	return ForEach(instances, concurrency, func(instance types.NamespacedName) error {
+		return errors.Wrapf(sdk.Deprovision(instance.Namespace, instance.Name), "%s", instance)
+	})
+}
+
+// TouchInstances touches instances by name, touching at most concurrency
+// instances at the same time.
+func (sdk *SDK) TouchInstances(instances []types.NamespacedName, retries, concurrency int) ([]types.NamespacedName, error) {
+	return ForEach(instances, concurrency, func(instance types.NamespacedName) error {
+		return errors.Wrapf(sdk.TouchInstance(instance.Namespace, instance.Name, retries), "%s", instance)
+	})
+}
+
+// UpdateInstances applies the same changes to instances by name, updating at
+// most concurrency instances at the same time. It returns the updated
+// instances in their original order.
+func (sdk *SDK) UpdateInstances(instances []types.NamespacedName, opts *UpdateInstanceOptions, retries, concurrency int) ([]*v1beta1.ServiceInstance, error) {
+	var mutex sync.Mutex
+	updated := map[types.NamespacedName]*v1beta1.ServiceInstance{}
+	succeeded, err := ForEach(instances, concurrency, func(instance types.NamespacedName) error {
+		result, err := sdk.UpdateInstance(instance.Namespace, instance.Name, opts, retries)
+		if err != nil {
+			return errors.Wrapf(err, "%s", instance)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		updated[instance] = result
+		return nil
+	})
+
+	results := make([]*v1beta1.ServiceInstance, 0, len(succeeded))
+	for _, instance := range succeeded {
+		results = append(results, updated[instance])
+	}
+	return results, err
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bulk operations", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		si           *v1beta1.ServiceInstance
+		si2          *v1beta1.ServiceInstance
+		sb           *v1beta1.ServiceBinding
+	)
+
+	BeforeEach(func() {
+		si = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace", Labels: map[string]string{"env": "dev"}}}
+		si2 = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "barbaz", Namespace: "foobar_namespace", Labels: map[string]string{"env": "prod"}}}
+		sb = &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace", Labels: map[string]string{"env": "dev"}}}
+		svcCatClient = fake.NewSimpleClientset(si, si2, sb)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("SelectInstances", func() {
+		It("Lists the instances matching the label selector", func() {
+			instances, err := sdk.SelectInstances(si.Namespace, "env=dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(ConsistOf(types.NamespacedName{Namespace: si.Namespace, Name: si.Name}))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("env=dev"))
+		})
+		It("Lists all instances without a selector", func() {
+			instances, err := sdk.SelectInstances(si.Namespace, "")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(HaveLen(2))
+		})
+	})
+
+	Describe("SelectBindings", func() {
+		It("Lists the bindings matching the label selector", func() {
+			bindings, err := sdk.SelectBindings(sb.Namespace, "env=dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings).To(ConsistOf(types.NamespacedName{Namespace: sb.Namespace, Name: sb.Name}))
+		})
+	})
+
+	Describe("ForEach", func() {
+		resources := []types.NamespacedName{
+			{Namespace: "ns", Name: "a"},
+			{Namespace: "ns", Name: "b"},
+			{Namespace: "ns", Name: "c"},
+			{Namespace: "ns", Name: "d"},
+		}
+
+		It("Runs at most concurrency operations at the same time", func() {
+			var mutex sync.Mutex
+			var running, maxRunning int
+			succeeded, err := ForEach(resources, 2, func(types.NamespacedName) error {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mutex.Unlock()
+
+				// Keep the operation running long enough for the others to start
+				time.Sleep(10 * time.Millisecond)
+
+				mutex.Lock()
+				running--
+				mutex.Unlock()
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(succeeded).To(Equal(resources))
+			Expect(maxRunning).To(Equal(2))
+		})
+		It("Returns the resources that succeeded in order and joins the errors", func() {
+			succeeded, err := ForEach(resources, 0, func(resource types.NamespacedName) error {
+				if resource.Name == "b" || resource.Name == "d" {
+					return fmt.Errorf("%s failed", resource.Name)
+				}
+				return nil
+			})
+
+			Expect(succeeded).To(Equal([]types.NamespacedName{resources[0], resources[2]}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("error:\n  b failed\n  d failed"))
+		})
+	})
+
+	Describe("DeprovisionInstances", func() {
+		It("Deletes every instance and reports the failures with their name", func() {
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("delete", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				if action.(testing.DeleteAction).GetName() == si2.Name {
+					return true, nil, fmt.Errorf("error deleting instance")
+				}
+				return true, nil, nil
+			})
+			sdk = &SDK{
+				ServiceCatalogClient: badClient,
+			}
+			instances := []types.NamespacedName{
+				{Namespace: si.Namespace, Name: si.Name},
+				{Namespace: si2.Namespace, Name: si2.Name},
+			}
+
+			deleted, err := sdk.DeprovisionInstances(instances, 1)
+
+			Expect(deleted).To(Equal(instances[:1]))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("foobar_namespace/barbaz: deprovision request failed (error deleting instance)"))
+			Expect(badClient.Actions()).To(HaveLen(2))
+		})
+	})
+
+	Describe("TouchInstances", func() {
+		It("Increments the update requests of every instance", func() {
+			instances := []types.NamespacedName{
+				{Namespace: si.Namespace, Name: si.Name},
+				{Namespace: si2.Namespace, Name: si2.Name},
+			}
+
+			touched, err := sdk.TouchInstances(instances, 3, 2)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(touched).To(Equal(instances))
+			instance, err := sdk.RetrieveInstance(si2.Namespace, si2.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.UpdateRequests).To(Equal(int64(1)))
+		})
+	})
+})
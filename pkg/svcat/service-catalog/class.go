@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -29,51 +30,148 @@ const (
 	FieldExternalClassName = "spec.externalName"
 )
 
-// RetrieveClasses lists all classes defined in the cluster.
-func (sdk *SDK) RetrieveClasses() ([]v1beta1.ClusterServiceClass, error) {
-	classes, err := sdk.ServiceCatalog().ClusterServiceClasses().List(v1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list classes (%s)", err)
+// Class provides a unified view over ClusterServiceClasses and
+// ServiceClasses.
+type Class interface {
+	// GetName returns the class's name (UUID).
+	GetName() string
+
+	// GetNamespace returns the class's namespace, or "" if it is
+	// cluster-scoped.
+	GetNamespace() string
+
+	// GetExternalName returns the class's external name.
+	GetExternalName() string
+
+	// GetDescription returns the class's description.
+	GetDescription() string
+
+	// GetSpec returns the spec shared by all classes.
+	GetSpec() v1beta1.CommonServiceClassSpec
+
+	// GetStatus returns the status shared by all classes.
+	GetStatus() v1beta1.CommonServiceClassStatus
+
+	// GetServiceBrokerName returns the name of the class's broker.
+	GetServiceBrokerName() string
+}
+
+// RetrieveClasses lists all classes defined in the selected scope.
+func (sdk *SDK) RetrieveClasses(opts ScopeOptions) ([]Class, error) {
+	return sdk.RetrieveClassesContext(context.Background(), opts)
+}
+
+// RetrieveClassesContext is RetrieveClasses, failing with the error of the
+// context if it is done before the requests are sent.
+func (sdk *SDK) RetrieveClassesContext(ctx context.Context, opts ScopeOptions) ([]Class, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	return classes.Items, nil
+	var classes []Class
+
+	if opts.Scope.Matches(ClusterScope) {
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list classes (%s)", err)
+		}
+		for i := range csc.Items {
+			classes = append(classes, &csc.Items[i])
+		}
+	}
+
+	if opts.Scope.Matches(NamespaceScope) {
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(v1.ListOptions{})
+		if err == nil {
+			for i := range sc.Items {
+				classes = append(classes, &sc.Items[i])
+			}
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, fmt.Errorf("unable to list classes in %q (%s)", opts.Namespace, err)
+		}
+	}
+
+	return classes, nil
 }
 
 // RetrieveClassByName gets a class by its external name.
-func (sdk *SDK) RetrieveClassByName(name string) (*v1beta1.ClusterServiceClass, error) {
-	opts := v1.ListOptions{
+func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, error) {
+	listOpts := v1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector(FieldExternalClassName, name).String(),
 	}
-	searchResults, err := sdk.ServiceCatalog().ClusterServiceClasses().List(opts)
-	if err != nil {
-		return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+
+	var searchResults []Class
+	if opts.Scope.Matches(ClusterScope) {
+		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+		}
+		for i := range csc.Items {
+			searchResults = append(searchResults, &csc.Items[i])
+		}
 	}
-	if len(searchResults.Items) == 0 {
-		return nil, fmt.Errorf("class '%s' not found", name)
+	if opts.Scope.Matches(NamespaceScope) {
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(listOpts)
+		if err == nil {
+			for i := range sc.Items {
+				searchResults = append(searchResults, &sc.Items[i])
+			}
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+		}
 	}
-	if len(searchResults.Items) > 1 {
-		return nil, fmt.Errorf("more than one matching class found for '%s'", name)
+
+	if len(searchResults) == 0 {
+		return nil, &NotFoundError{Kind: "class", Name: name}
 	}
-	return &searchResults.Items[0], nil
+	if len(searchResults) > 1 {
+		return nil, &AmbiguousError{Kind: "class", Name: name}
+	}
+	return searchResults[0], nil
 }
 
 // RetrieveClassByID gets a class by its UUID.
-func (sdk *SDK) RetrieveClassByID(uuid string) (*v1beta1.ClusterServiceClass, error) {
-	class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(uuid, v1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to get class (%s)", err)
+func (sdk *SDK) RetrieveClassByID(uuid string, opts ScopeOptions) (Class, error) {
+	var found []Class
+
+	if opts.Scope.Matches(ClusterScope) {
+		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(uuid, v1.GetOptions{})
+		if err == nil {
+			found = append(found, class)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("class", uuid, err)
+		}
 	}
-	return class, nil
+	if opts.Scope.Matches(NamespaceScope) {
+		class, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).Get(uuid, v1.GetOptions{})
+		if err == nil {
+			found = append(found, class)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("class", uuid, err)
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, &NotFoundError{Kind: "class", Name: uuid}
+	}
+	if len(found) > 1 {
+		return nil, &AmbiguousError{Kind: "class", Name: uuid}
+	}
+	return found[0], nil
 }
 
 // RetrieveClassByPlan gets the class associated to a plan.
-func (sdk *SDK) RetrieveClassByPlan(plan *v1beta1.ClusterServicePlan,
-) (*v1beta1.ClusterServiceClass, error) {
+func (sdk *SDK) RetrieveClassByPlan(plan Plan) (Class, error) {
 	// Retrieve the class as well because plans don't have the external class name
-	class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(plan.Spec.ClusterServiceClassRef.Name, v1.GetOptions{})
+	var class Class
+	var err error
+	if plan.GetNamespace() == "" {
+		class, err = sdk.ServiceCatalog().ClusterServiceClasses().Get(plan.GetClassID(), v1.GetOptions{})
+	} else {
+		class, err = sdk.ServiceCatalog().ServiceClasses(plan.GetNamespace()).Get(plan.GetClassID(), v1.GetOptions{})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("unable to get class (%s)", err)
+		return nil, getError("class", plan.GetClassID(), err)
 	}
-
 	return class, nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/testing"
@@ -38,12 +39,14 @@ var _ = Describe("Class", func() {
 		svcCatClient *fake.Clientset
 		sc           *v1beta1.ClusterServiceClass
 		sc2          *v1beta1.ClusterServiceClass
+		nsc          *v1beta1.ServiceClass
 	)
 
 	BeforeEach(func() {
 		sc = &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
 		sc2 = &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "barbaz"}}
-		svcCatClient = fake.NewSimpleClientset(sc, sc2)
+		nsc = &v1beta1.ServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+		svcCatClient = fake.NewSimpleClientset(sc, sc2, nsc)
 		sdk = &SDK{
 			ServiceCatalogClient: svcCatClient,
 		}
@@ -51,12 +54,31 @@ var _ = Describe("Class", func() {
 
 	Describe("RetrieveClasses", func() {
 		It("Calls the generated v1beta1 List method", func() {
-			classes, err := sdk.RetrieveClasses()
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(classes).Should(ConsistOf(*sc, *sc2))
+			Expect(classes).Should(ConsistOf(sc, sc2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
 		})
+		It("Lists the classes of all scopes", func() {
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(sc, sc2, nsc))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(actions[1].Matches("list", "serviceclasses")).To(BeTrue())
+			Expect(actions[1].GetNamespace()).To(Equal("default"))
+		})
+		It("Ignores namespaced classes which are not served", func() {
+			svcCatClient.PrependReactor("list", "serviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewNotFound(v1beta1.Resource("serviceclasses"), "")
+			})
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(sc, sc2))
+		})
 		It("Bubbles up errors", func() {
 			badClient := &fake.Clientset{}
 			errorMessage := "error retrieving list"
@@ -67,7 +89,7 @@ var _ = Describe("Class", func() {
 				ServiceCatalogClient: badClient,
 			}
 
-			_, err := sdk.RetrieveClasses()
+			_, err := sdk.RetrieveClasses(ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -84,7 +106,7 @@ var _ = Describe("Class", func() {
 			sdk = &SDK{
 				ServiceCatalogClient: realClient,
 			}
-			class, err := sdk.RetrieveClassByName(className)
+			class, err := sdk.RetrieveClassByName(className, ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(class).To(Equal(sc))
@@ -104,7 +126,7 @@ var _ = Describe("Class", func() {
 			sdk = &SDK{
 				ServiceCatalogClient: emptyClient,
 			}
-			class, err := sdk.RetrieveClassByName(className)
+			class, err := sdk.RetrieveClassByName(className, ScopeOptions{Scope: ClusterScope})
 
 			Expect(class).To(BeNil())
 			Expect(err).To(HaveOccurred())
@@ -127,9 +149,9 @@ var _ = Describe("Class", func() {
 			sdk = &SDK{
 				ServiceCatalogClient: realClient,
 			}
-			class, err := sdk.RetrieveClassByID(classID)
+			class, err := sdk.RetrieveClassByID(classID, ScopeOptions{Scope: ClusterScope})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(fmt.Sprintf("%v", class.UID)).To(Equal(classID))
+			Expect(class).To(Equal(sc))
 			actions := realClient.Actions()
 			Expect(actions[0].Matches("get", "clusterserviceclasses")).To(BeTrue())
 		})
@@ -142,7 +164,7 @@ var _ = Describe("Class", func() {
 			sdk = &SDK{
 				ServiceCatalogClient: emptyClient,
 			}
-			class, err := sdk.RetrieveClassByID("not_real")
+			class, err := sdk.RetrieveClassByID("not_real", ScopeOptions{Scope: ClusterScope})
 
 			Expect(class).To(BeNil())
 			Expect(err).To(HaveOccurred())
@@ -177,6 +199,25 @@ var _ = Describe("Class", func() {
 			Expect(actions[0].Matches("get", "clusterserviceclasses")).To(BeTrue())
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(sc.Name))
 		})
+		It("Gets the namespaced class of a namespaced plan", func() {
+			classPlan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar_plan",
+					Namespace: nsc.Namespace,
+				},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{
+						Name: nsc.Name,
+					},
+				},
+			}
+			class, err := sdk.RetrieveClassByPlan(classPlan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class).To(Equal(nsc))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "serviceclasses")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(nsc.Namespace))
+		})
 		It("Bubbles up errors", func() {
 			fakeClassName := "not_real"
 			errorMessage := "not found"
@@ -0,0 +1,141 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// SvcatClient holds the operations of the svcat SDK. It is implemented by
+// SDK, and consumers should depend on it instead so that they can substitute
+// the in-memory implementation of the fake package in their tests.
+type SvcatClient interface {
+	// Bindings
+	RetrieveBindings(ns string) (*v1beta1.ServiceBindingList, error)
+	RetrieveBinding(ns, name string) (*v1beta1.ServiceBinding, error)
+	RetrieveBindingContext(ctx context.Context, ns, name string) (*v1beta1.ServiceBinding, error)
+	RetrieveBindingsByInstance(instance *v1beta1.ServiceInstance) ([]v1beta1.ServiceBinding, error)
+	Bind(namespace, bindingName, externalID, instanceName, secretName string,
+		params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error)
+	BindContext(ctx context.Context, namespace, bindingName, externalID, instanceName, secretName string,
+		params interface{}, secrets map[string]string) (*v1beta1.ServiceBinding, error)
+	Unbind(ns, instanceName string) ([]types.NamespacedName, error)
+	UnbindContext(ctx context.Context, ns, instanceName string) ([]types.NamespacedName, error)
+	DeleteBindings(bindings []types.NamespacedName) ([]types.NamespacedName, error)
+	DeleteBindingsConcurrently(bindings []types.NamespacedName, concurrency int) ([]types.NamespacedName, error)
+	DeleteBinding(ns, bindingName string) error
+	DeleteBindingContext(ctx context.Context, ns, bindingName string) error
+	BindingParentHierarchy(binding *v1beta1.ServiceBinding) (*v1beta1.ServiceInstance, Class, Plan, Broker, error)
+	WaitForBinding(ns, name string, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceBinding, error)
+	WaitForBindingContext(ctx context.Context, ns, name string) (*v1beta1.ServiceBinding, error)
+	IsBindingReady(binding *v1beta1.ServiceBinding) bool
+	IsBindingFailed(binding *v1beta1.ServiceBinding) bool
+	BindingHasStatus(binding *v1beta1.ServiceBinding, status v1beta1.ServiceBindingConditionType) bool
+	RetrieveSecretByBinding(binding *v1beta1.ServiceBinding) (*corev1.Secret, error)
+	RetrieveCredentials(ns, bindingName string) (map[string][]byte, error)
+
+	// Brokers
+	RetrieveBrokers(opts ScopeOptions) ([]Broker, error)
+	RetrieveBrokersContext(ctx context.Context, opts ScopeOptions) ([]Broker, error)
+	RetrieveBroker(name string, opts ScopeOptions) (Broker, error)
+	RetrieveBrokerContext(ctx context.Context, name string, opts ScopeOptions) (Broker, error)
+	RetrieveBrokerByClass(class Class) (Broker, error)
+	Sync(name string, retries int) error
+	SyncContext(ctx context.Context, name string, retries int) error
+	Register(name, url string, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error)
+	Deregister(name string, scopeOpts *ScopeOptions) error
+	DeregisterContext(ctx context.Context, name string, scopeOpts *ScopeOptions) error
+
+	// Classes and plans
+	RetrieveClasses(opts ScopeOptions) ([]Class, error)
+	RetrieveClassesContext(ctx context.Context, opts ScopeOptions) ([]Class, error)
+	RetrieveClassByName(name string, opts ScopeOptions) (Class, error)
+	RetrieveClassByID(uuid string, opts ScopeOptions) (Class, error)
+	RetrieveClassByPlan(plan Plan) (Class, error)
+	RetrievePlans(filter *FilterOptions, opts ScopeOptions) ([]Plan, error)
+	RetrievePlansContext(ctx context.Context, filter *FilterOptions, opts ScopeOptions) ([]Plan, error)
+	RetrievePlanByName(name string, opts ScopeOptions) (Plan, error)
+	RetrievePlanByID(uuid string, opts ScopeOptions) (Plan, error)
+	RetrievePlansByClass(class Class) ([]Plan, error)
+	RetrievePlanByClassAndPlanNames(className, planName string, opts ScopeOptions) (Plan, error)
+	RetrieveMarketplace(filter MarketplaceOptions, opts ScopeOptions) ([]Offering, error)
+
+	// Instances
+	RetrieveInstances(ns string) (*v1beta1.ServiceInstanceList, error)
+	RetrieveInstance(ns, name string) (*v1beta1.ServiceInstance, error)
+	RetrieveInstanceContext(ctx context.Context, ns, name string) (*v1beta1.ServiceInstance, error)
+	RetrieveInstanceByBinding(b *v1beta1.ServiceBinding) (*v1beta1.ServiceInstance, error)
+	RetrieveInstancesByPlan(plan Plan) ([]v1beta1.ServiceInstance, error)
+	InstanceParentHierarchy(instance *v1beta1.ServiceInstance) (Class, Plan, Broker, error)
+	InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance) (Class, Plan, error)
+	Provision(namespace, instanceName, externalID, className, planName string,
+		params interface{}, secrets map[string]string, scope Scope) (*v1beta1.ServiceInstance, error)
+	ProvisionContext(ctx context.Context, namespace, instanceName, externalID, className, planName string,
+		params interface{}, secrets map[string]string, scope Scope) (*v1beta1.ServiceInstance, error)
+	Deprovision(namespace, instanceName string) error
+	DeprovisionContext(ctx context.Context, namespace, instanceName string) error
+	TouchInstance(ns, name string, retries int) error
+	UpdateInstance(ns, name string, opts *UpdateInstanceOptions, retries int) (*v1beta1.ServiceInstance, error)
+	WaitForInstance(ns, name string, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceInstance, error)
+	WaitForInstanceGeneration(ns, name string, generation int64, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceInstance, error)
+	WaitForInstanceContext(ctx context.Context, ns, name string) (*v1beta1.ServiceInstance, error)
+	WaitForInstanceGenerationContext(ctx context.Context, ns, name string, generation int64) (*v1beta1.ServiceInstance, error)
+	IsInstanceReady(instance *v1beta1.ServiceInstance) bool
+	IsInstanceFailed(instance *v1beta1.ServiceInstance) bool
+	InstanceHasStatus(instance *v1beta1.ServiceInstance, status v1beta1.ServiceInstanceConditionType) bool
+
+	// Bulk operations
+	SelectInstances(ns, selector string) ([]types.NamespacedName, error)
+	SelectBindings(ns, selector string) ([]types.NamespacedName, error)
+	DeprovisionInstances(instances []types.NamespacedName, concurrency int) ([]types.NamespacedName, error)
+	TouchInstances(instances []types.NamespacedName, retries, concurrency int) ([]types.NamespacedName, error)
+	UpdateInstances(instances []types.NamespacedName, opts *UpdateInstanceOptions, retries, concurrency int) ([]*v1beta1.ServiceInstance, error)
+
+	// Manifests
+	ExportInstance(ns, name string, withBindings bool) (*Manifest, error)
+	ApplyInstance(instance *v1beta1.ServiceInstance, retries int) (*v1beta1.ServiceInstance, ApplyResult, error)
+	ApplyBinding(binding *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, ApplyResult, error)
+
+	// Diagnosis and trees
+	DiagnoseInstance(ns, name string, opts DiagnoseOptions) (*Diagnosis, error)
+	DiagnoseBinding(ns, name string, opts DiagnoseOptions) (*Diagnosis, error)
+	BrokerTree(name string, opts ScopeOptions) (*Tree, error)
+	InstanceTree(ns, name string) (*Tree, error)
+
+	// Waiting and watching
+	WaitForInstanceCondition(ns, name string, cond WaitCondition, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceInstance, error)
+	WaitForInstanceConditionContext(ctx context.Context, ns, name string, cond WaitCondition) (*v1beta1.ServiceInstance, error)
+	WaitForBindingCondition(ns, name string, cond WaitCondition, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceBinding, error)
+	WaitForBindingConditionContext(ctx context.Context, ns, name string, cond WaitCondition) (*v1beta1.ServiceBinding, error)
+	WaitForBrokerCondition(name string, opts ScopeOptions, cond WaitCondition, interval time.Duration, timeout *time.Duration) (Broker, error)
+	WaitForBrokerConditionContext(ctx context.Context, name string, opts ScopeOptions, cond WaitCondition) (Broker, error)
+	WatchInstances(ns, name string) (watch.Interface, error)
+	WatchBindings(ns, name string) (watch.Interface, error)
+	WatchBrokers(name string, opts ScopeOptions) (watch.Interface, error)
+
+	// Server
+	ServerVersion() (*version.Info, error)
+}
+
+var _ SvcatClient = &SDK{}
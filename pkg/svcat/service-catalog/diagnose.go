@@ -0,0 +1,520 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// DefaultReconciliationRetryDuration is the default value of the
+// --reconciliation-retry-duration flag of the controller manager.
+const DefaultReconciliationRetryDuration = 7 * 24 * time.Hour
+
+// maxDiagnosisEvents is the number of recent events kept in a diagnosis.
+const maxDiagnosisEvents = 10
+
+// Severity indicates how much a finding of a diagnosis needs attention.
+type Severity string
+
+const (
+	// SeverityInfo findings explain what is going on.
+	SeverityInfo Severity = "info"
+	// SeverityWarning findings may keep the resource from becoming ready.
+	SeverityWarning Severity = "warning"
+	// SeverityError findings keep the resource from becoming ready.
+	SeverityError Severity = "error"
+)
+
+// Finding is an observation about a resource, with the action suggested to
+// the user when there is one.
+type Finding struct {
+	Severity   Severity `json:"severity"`
+	Summary    string   `json:"summary"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// Diagnosis explains in plain language why an instance or binding is, or is
+// not, ready.
+type Diagnosis struct {
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Reason    string         `json:"reason,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Findings  []Finding      `json:"findings"`
+	Events    []corev1.Event `json:"events,omitempty"`
+}
+
+// DiagnoseOptions holds the settings of the controller manager that a
+// diagnosis depends on.
+type DiagnoseOptions struct {
+	// RetryDuration is the --reconciliation-retry-duration of the controller
+	// manager, after which it stops retrying an operation.
+	RetryDuration time.Duration
+}
+
+// HasProblems returns true when any finding is a warning or an error.
+func (d *Diagnosis) HasProblems() bool {
+	for _, f := range d.Findings {
+		if f.Severity != SeverityInfo {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Diagnosis) add(severity Severity, suggestion string, summary string, a ...interface{}) {
+	d.Findings = append(d.Findings, Finding{
+		Severity:   severity,
+		Summary:    fmt.Sprintf(summary, a...),
+		Suggestion: suggestion,
+	})
+}
+
+// advice explains a condition reason set by the controller. The suggestion
+// may refer to the name of the resource as %[1]s and its namespace as %[2]s.
+type advice struct {
+	severity   Severity
+	summary    string
+	suggestion string
+}
+
+// instanceAdvice explains the reasons of the Ready condition of an instance.
+var instanceAdvice = map[string]advice{
+	"ProvisionRequestInFlight": {SeverityInfo,
+		"The controller sent the provision request to the broker and is waiting for its response.",
+		"If the request does not complete, check that the broker is reachable from the controller manager and look at the controller manager logs."},
+	"UpdateInstanceRequestInFlight": {SeverityInfo,
+		"The controller sent the update request to the broker and is waiting for its response.",
+		"If the request does not complete, check that the broker is reachable from the controller manager and look at the controller manager logs."},
+	"DeprovisionRequestInFlight": {SeverityInfo,
+		"The controller sent the deprovision request to the broker and is waiting for its response.",
+		"If the request does not complete, check that the broker is reachable from the controller manager and look at the controller manager logs."},
+	"Provisioning": {SeverityInfo,
+		"The broker is provisioning the instance asynchronously.",
+		"Run 'svcat wait instance %[1]s -n %[2]s' to wait for the broker to finish."},
+	"UpdatingInstance": {SeverityInfo,
+		"The broker is updating the instance asynchronously.",
+		"Run 'svcat wait instance %[1]s -n %[2]s' to wait for the broker to finish."},
+	"Deprovisioning": {SeverityInfo,
+		"The broker is deprovisioning the instance asynchronously.",
+		""},
+	"ProvisionCallFailed": {SeverityError,
+		"The broker rejected the provision request.",
+		"Read the message from the broker above, fix the plan or parameters with 'svcat update instance %[1]s -n %[2]s', or contact the broker's operator."},
+	"ErrorCallingProvision": {SeverityError,
+		"The controller could not send the provision request to the broker.",
+		"Check that the broker's URL is reachable from the controller manager and that its credentials are valid."},
+	"UpdateInstanceCallFailed": {SeverityError,
+		"The broker rejected the update request.",
+		"Read the message from the broker above and fix the plan or parameters with 'svcat update instance %[1]s -n %[2]s'."},
+	"ErrorCallingUpdateInstance": {SeverityError,
+		"The controller could not send the update request to the broker.",
+		"Check that the broker's URL is reachable from the controller manager and that its credentials are valid."},
+	"DeprovisionCallFailed": {SeverityError,
+		"The broker rejected the deprovision request.",
+		"Read the message from the broker above and contact the broker's operator; the controller keeps retrying until the retry duration elapses."},
+	"DeprovisionBlockedByExistingCredentials": {SeverityError,
+		"The instance cannot be deprovisioned while bindings to it exist.",
+		"Run 'svcat unbind %[1]s -n %[2]s' to delete its bindings."},
+	"ErrorPollingLastOperation": {SeverityWarning,
+		"The controller could not get the state of the asynchronous operation from the broker.",
+		"Check that the broker is reachable; the controller keeps polling until the retry duration elapses."},
+	"ErrorAsyncOperationInProgress": {SeverityWarning,
+		"The broker refused the request because another operation on the instance is in progress.",
+		"Wait for the other operation to finish; the controller retries the request."},
+	"ErrorWithParameters": {SeverityError,
+		"The parameters of the instance could not be built.",
+		"Check that the secrets referenced by parametersFrom exist and hold valid JSON, then run 'svcat update instance %[1]s -n %[2]s'."},
+	"ReferencesNonexistentServiceClass": {SeverityError,
+		"The class of the instance does not exist.",
+		"Run 'svcat get classes' to list the available classes. If the class was just added to the broker, run 'svcat sync broker' to refresh its catalog."},
+	"ReferencesNonexistentServicePlan": {SeverityError,
+		"The plan of the instance does not exist.",
+		"Run 'svcat get plans' to list the available plans and 'svcat update instance %[1]s -n %[2]s --plan PLAN' to pick one of them."},
+	"ReferencesNonexistentBroker": {SeverityError,
+		"The broker offering the class of the instance does not exist.",
+		"Run 'svcat get brokers' and register the broker again with 'svcat register'."},
+	"ReferencesDeletedServiceClass": {SeverityError,
+		"The class of the instance was removed from the broker's catalog, so new instances of it cannot be provisioned.",
+		"Provision the service from a class that the broker still offers."},
+	"ReferencesDeletedServicePlan": {SeverityError,
+		"The plan of the instance was removed from the broker's catalog, so new instances of it cannot be provisioned.",
+		"Run 'svcat update instance %[1]s -n %[2]s --plan PLAN' to move the instance to a plan that is still offered."},
+	"StartingInstanceOrphanMitigation": {SeverityWarning,
+		"The provision request failed with an ambiguous error, so the controller is deprovisioning whatever the broker may have created.",
+		"Wait for orphan mitigation to complete, then check the broker logs for the cause of the failure."},
+	"OrphanMitigationFailed": {SeverityError,
+		"The controller could not clean up after a failed provision request.",
+		"Contact the broker's operator, the broker may still hold resources for this instance."},
+	"InvalidDeprovisionStatus": {SeverityError,
+		"The deprovision status of the instance is invalid.",
+		"Check the deprovisionStatus field with 'svcat describe instance %[1]s -n %[2]s'."},
+	"ErrorReconciliationRetryTimeout": {SeverityError,
+		"The controller stopped retrying because the operation did not succeed within the reconciliation retry duration.",
+		"Fix the cause reported above, then run 'svcat touch instance %[1]s -n %[2]s' to make the controller try again."},
+	"ErrorFindingNamespaceForInstance": {SeverityError,
+		"The controller could not read the namespace of the instance.",
+		"Check that the controller manager is allowed to get namespaces."},
+}
+
+// bindingAdvice explains the reasons of the Ready condition of a binding.
+var bindingAdvice = map[string]advice{
+	"BindingRequestInFlight": {SeverityInfo,
+		"The controller sent the bind request to the broker and is waiting for its response.",
+		"If the request does not complete, check that the broker is reachable from the controller manager and look at the controller manager logs."},
+	"UnbindingRequestInFlight": {SeverityInfo,
+		"The controller sent the unbind request to the broker and is waiting for its response.",
+		"If the request does not complete, check that the broker is reachable from the controller manager and look at the controller manager logs."},
+	"Binding": {SeverityInfo,
+		"The broker is creating the binding asynchronously.",
+		"Run 'svcat wait binding %[1]s -n %[2]s' to wait for the broker to finish."},
+	"Unbinding": {SeverityInfo,
+		"The broker is deleting the binding asynchronously.",
+		""},
+	"ReferencesNonexistentInstance": {SeverityError,
+		"The instance of the binding does not exist.",
+		"Run 'svcat get instances -n %[2]s' and bind to an existing instance."},
+	"ErrorInstanceNotReady": {SeverityWarning,
+		"The binding waits for its instance to become ready.",
+		"Diagnose the instance, the binding is created once it is ready."},
+	"ErrorInstanceRefsUnresolved": {SeverityWarning,
+		"The class and plan of the instance have not been resolved yet.",
+		"Diagnose the instance, the binding is created once its class and plan are resolved."},
+	"ErrorNonbindableServiceClass": {SeverityError,
+		"The class or plan of the instance does not allow bindings.",
+		"Run 'svcat marketplace' to find a bindable plan."},
+	"BindCallFailed": {SeverityError,
+		"The broker rejected the bind request.",
+		"Read the message from the broker above and check the parameters of the binding."},
+	"ErrorInjectingBindResult": {SeverityError,
+		"The controller could not write the credentials returned by the broker into the secret.",
+		"Check that the secret is not owned by another binding and that the controller manager may write secrets in namespace %[2]s."},
+	"ErrorEjectingServiceBinding": {SeverityError,
+		"The controller could not delete the secret of the binding.",
+		"Check that the controller manager may delete secrets in namespace %[2]s."},
+	"UnbindCallFailed": {SeverityError,
+		"The broker rejected the unbind request.",
+		"Read the message from the broker above and contact the broker's operator."},
+	"ServiceBindingNeedsOrphanMitigation": {SeverityWarning,
+		"The bind request failed with an ambiguous error, so the controller is unbinding whatever the broker may have created.",
+		"Wait for orphan mitigation to complete, then check the broker logs for the cause of the failure."},
+	"ErrorPollingLastOperation": {SeverityWarning,
+		"The controller could not get the state of the asynchronous operation from the broker.",
+		"Check that the broker is reachable; the controller keeps polling until the retry duration elapses."},
+	"AsyncOperationTimeout": {SeverityError,
+		"The asynchronous operation did not complete in time.",
+		"Check with the broker's operator why the operation is stuck, then delete the binding and bind again."},
+	"FetchingBindingFailed": {SeverityError,
+		"The controller could not fetch the binding from the broker.",
+		"Check that the broker supports fetching bindings and is reachable."},
+	"ErrorReconciliationRetryTimeout": {SeverityError,
+		"The controller stopped retrying because the operation did not succeed within the reconciliation retry duration.",
+		"Fix the cause reported above, then delete the binding and bind again."},
+}
+
+// addAdvice adds the finding that explains the reason of a condition, if the
+// reason is known.
+func (d *Diagnosis) addAdvice(table map[string]advice, reason string) {
+	a, ok := table[reason]
+	if !ok {
+		return
+	}
+	suggestion := a.suggestion
+	if strings.Contains(suggestion, "%[") {
+		suggestion = fmt.Sprintf(suggestion, d.Name, d.Namespace)
+	}
+	d.add(a.severity, suggestion, "%s", a.summary)
+}
+
+// addOperation explains the operation in progress on a resource and how long
+// the controller keeps retrying it.
+func (d *Diagnosis) addOperation(operation string, start *v1.Time, async, orphanMitigation bool, opts DiagnoseOptions) {
+	if orphanMitigation {
+		d.add(SeverityWarning, "Check the broker logs for the cause of the failure.",
+			"Orphan mitigation is in progress: the controller is cleaning up after a request that failed.")
+	}
+	if operation == "" {
+		return
+	}
+	if start == nil {
+		d.add(SeverityInfo, "", "A %s operation is in progress.", operation)
+		return
+	}
+	d.add(SeverityInfo, "", "A %s operation is in progress since %s.", operation, start.UTC().Format(time.RFC3339))
+	if async {
+		d.add(SeverityInfo, "",
+			"The broker accepted the request asynchronously, the controller polls the broker until the operation completes.")
+	}
+
+	retryDuration := opts.RetryDuration
+	if retryDuration == 0 {
+		retryDuration = DefaultReconciliationRetryDuration
+	}
+	deadline := start.Add(retryDuration)
+	if time.Now().After(deadline) {
+		d.add(SeverityError, d.retrySuggestion(),
+			"The operation has been retried for longer than the reconciliation retry duration of %s, the controller gave up at %s.",
+			retryDuration, deadline.UTC().Format(time.RFC3339))
+		return
+	}
+	d.add(SeverityInfo, "", "The controller retries the operation until %s, %s after it started.",
+		deadline.UTC().Format(time.RFC3339), retryDuration)
+}
+
+// addGeneration reports a spec change that the controller has not processed.
+func (d *Diagnosis) addGeneration(generation, observed int64) {
+	if observed < generation {
+		d.add(SeverityInfo, "Check that the controller manager is running if this persists.",
+			"The controller has not processed the latest change to the %s yet (generation %d, observed %d).",
+			d.noun(), generation, observed)
+	}
+}
+
+// retrySuggestion tells how to make the controller try again once the cause
+// of a failure is fixed.
+func (d *Diagnosis) retrySuggestion() string {
+	if d.Kind == "ServiceBinding" {
+		return "Fix the cause, then delete the binding and bind again."
+	}
+	return fmt.Sprintf("Fix the cause, then run 'svcat touch instance %s -n %s' to make the controller try again.", d.Name, d.Namespace)
+}
+
+// noun returns how findings refer to the diagnosed resource.
+func (d *Diagnosis) noun() string {
+	if d.Kind == "ServiceBinding" {
+		return "binding"
+	}
+	return "instance"
+}
+
+// DiagnoseInstance inspects an instance, its class, plan, broker and recent
+// events to explain why it is not ready and what to do about it.
+func (sdk *SDK) DiagnoseInstance(ns, name string, opts DiagnoseOptions) (*Diagnosis, error) {
+	instance, err := sdk.RetrieveInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Diagnosis{Kind: "ServiceInstance", Namespace: instance.Namespace, Name: instance.Name}
+	if instance.DeletionTimestamp != nil {
+		d.add(SeverityInfo, "", "The instance is being deleted.")
+	}
+
+	var ready, failed *v1beta1.ServiceInstanceCondition
+	for i, cond := range instance.Status.Conditions {
+		switch cond.Type {
+		case v1beta1.ServiceInstanceConditionReady:
+			ready = &instance.Status.Conditions[i]
+		case v1beta1.ServiceInstanceConditionFailed:
+			failed = &instance.Status.Conditions[i]
+		}
+	}
+	switch {
+	case ready == nil:
+		d.add(SeverityWarning, "Check that the controller manager is running.",
+			"The controller has not processed the instance yet.")
+	case ready.Status == v1beta1.ConditionTrue:
+		d.Reason, d.Message = ready.Reason, ready.Message
+		d.add(SeverityInfo, "", "The instance is ready.")
+	default:
+		d.Reason, d.Message = ready.Reason, ready.Message
+		d.addAdvice(instanceAdvice, ready.Reason)
+	}
+	if failed != nil && failed.Status == v1beta1.ConditionTrue {
+		if ready == nil || failed.Reason != ready.Reason {
+			d.addAdvice(instanceAdvice, failed.Reason)
+		}
+		d.add(SeverityError, d.retrySuggestion(), "The instance failed: %s.", failed.Message)
+	}
+
+	d.addGeneration(instance.Generation, instance.Status.ObservedGeneration)
+	d.addOperation(string(instance.Status.CurrentOperation), instance.Status.OperationStartTime,
+		instance.Status.AsyncOpInProgress, instance.Status.OrphanMitigationInProgress, opts)
+
+	if err := sdk.diagnoseInstanceParents(d, instance); err != nil {
+		return nil, err
+	}
+	sdk.addEvents(d)
+	return d, nil
+}
+
+// DiagnoseBinding inspects a binding, its instance, the broker and recent
+// events to explain why it is not ready and what to do about it.
+func (sdk *SDK) DiagnoseBinding(ns, name string, opts DiagnoseOptions) (*Diagnosis, error) {
+	binding, err := sdk.RetrieveBinding(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Diagnosis{Kind: "ServiceBinding", Namespace: binding.Namespace, Name: binding.Name}
+	if binding.DeletionTimestamp != nil {
+		d.add(SeverityInfo, "", "The binding is being deleted.")
+	}
+
+	var ready, failed *v1beta1.ServiceBindingCondition
+	for i, cond := range binding.Status.Conditions {
+		switch cond.Type {
+		case v1beta1.ServiceBindingConditionReady:
+			ready = &binding.Status.Conditions[i]
+		case v1beta1.ServiceBindingConditionFailed:
+			failed = &binding.Status.Conditions[i]
+		}
+	}
+	switch {
+	case ready == nil:
+		d.add(SeverityWarning, "Check that the controller manager is running.",
+			"The controller has not processed the binding yet.")
+	case ready.Status == v1beta1.ConditionTrue:
+		d.Reason, d.Message = ready.Reason, ready.Message
+		d.add(SeverityInfo, "", "The binding is ready, its credentials are in secret '%s'.", binding.Spec.SecretName)
+	default:
+		d.Reason, d.Message = ready.Reason, ready.Message
+		d.addAdvice(bindingAdvice, ready.Reason)
+	}
+	if failed != nil && failed.Status == v1beta1.ConditionTrue {
+		if ready == nil || failed.Reason != ready.Reason {
+			d.addAdvice(bindingAdvice, failed.Reason)
+		}
+		d.add(SeverityError, d.retrySuggestion(), "The binding failed: %s.", failed.Message)
+	}
+
+	d.addGeneration(binding.Generation, binding.Status.ReconciledGeneration)
+	d.addOperation(string(binding.Status.CurrentOperation), binding.Status.OperationStartTime,
+		binding.Status.AsyncOpInProgress, binding.Status.OrphanMitigationInProgress, opts)
+
+	instance, err := sdk.RetrieveInstanceByBinding(binding)
+	switch {
+	case errors.IsNotFound(err):
+		d.add(SeverityError, fmt.Sprintf("Run 'svcat get instances -n %s' and bind to an existing instance.", d.Namespace),
+			"The instance '%s/%s' of the binding does not exist.", binding.Namespace, binding.Spec.ServiceInstanceRef.Name)
+	case err != nil:
+		return nil, err
+	default:
+		if !sdk.IsInstanceReady(instance) {
+			d.add(SeverityWarning,
+				fmt.Sprintf("Run 'svcat diagnose instance %s -n %s' to find out why.", instance.Name, instance.Namespace),
+				"The instance '%s/%s' is not ready.", instance.Namespace, instance.Name)
+		}
+		if err := sdk.diagnoseInstanceParents(d, instance); err != nil {
+			return nil, err
+		}
+	}
+
+	sdk.addEvents(d)
+	return d, nil
+}
+
+// diagnoseInstanceParents reports classes and plans removed from the
+// broker's catalog, and brokers that are missing or not ready.
+func (sdk *SDK) diagnoseInstanceParents(d *Diagnosis, instance *v1beta1.ServiceInstance) error {
+	class, err := sdk.retrieveInstanceClass(instance)
+	if err != nil {
+		if !isMissingParent(err, instance.Spec.ClusterServiceClassRef == nil && instance.Spec.ServiceClassRef == nil) {
+			return err
+		}
+		class = nil
+	}
+	plan, err := sdk.retrieveInstancePlan(instance)
+	if err != nil {
+		if !isMissingParent(err, instance.Spec.ClusterServicePlanRef == nil && instance.Spec.ServicePlanRef == nil) {
+			return err
+		}
+		plan = nil
+	}
+
+	if class != nil && class.GetStatus().RemovedFromBrokerCatalog {
+		d.add(SeverityWarning, "Existing instances keep working, but new instances of the class cannot be provisioned.",
+			"The class '%s' was removed from the catalog of its broker.", class.GetExternalName())
+	}
+	if plan != nil && plan.GetStatus().RemovedFromBrokerCatalog {
+		d.add(SeverityWarning,
+			fmt.Sprintf("Run 'svcat update instance %s -n %s --plan PLAN' to move the instance to a plan that is still offered.", instance.Name, instance.Namespace),
+			"The plan '%s' was removed from the catalog of its broker.", plan.GetExternalName())
+	}
+	if class == nil {
+		return nil
+	}
+
+	broker, err := sdk.RetrieveBrokerByClass(class)
+	if errors.IsNotFound(err) {
+		d.add(SeverityError, "Run 'svcat get brokers' and register the broker again with 'svcat register'.",
+			"The broker '%s' of the class '%s' does not exist.", class.GetServiceBrokerName(), class.GetExternalName())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var ready *v1beta1.ServiceBrokerCondition
+	for i, cond := range broker.GetStatus().Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionReady {
+			ready = &broker.GetStatus().Conditions[i]
+		}
+	}
+	if ready == nil || ready.Status != v1beta1.ConditionTrue {
+		message := "its catalog has not been fetched yet"
+		if ready != nil {
+			message = fmt.Sprintf("%s: %s", ready.Reason, ready.Message)
+		}
+		d.add(SeverityError,
+			fmt.Sprintf("Run 'svcat describe broker %s' and check that %s is reachable from the controller manager.", broker.GetName(), broker.GetURL()),
+			"The broker '%s' is not ready, %s.", broker.GetName(), message)
+		return nil
+	}
+	d.add(SeverityInfo, "", "The broker '%s' is ready.", broker.GetName())
+	return nil
+}
+
+// isMissingParent returns true when the class or plan of an instance could
+// not be retrieved because it does not exist or the instance does not
+// reference it yet. The reason of the Ready condition explains those cases.
+func isMissingParent(err error, unresolved bool) bool {
+	return unresolved || errors.IsNotFound(err)
+}
+
+// addEvents adds the most recent events of the diagnosed resource. Events
+// are optional, failing to list them is reported as a finding.
+func (sdk *SDK) addEvents(d *Diagnosis) {
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.kind", d.Kind),
+		fields.OneTermEqualSelector("involvedObject.name", d.Name),
+	).String()
+	events, err := sdk.Core().Events(d.Namespace).List(v1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		d.add(SeverityInfo, "", "The events of the %s could not be listed: %v", d.noun(), err)
+		return
+	}
+
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].LastTimestamp.Before(&items[j].LastTimestamp)
+	})
+	if len(items) > maxDiagnosisEvents {
+		items = items[len(items)-maxDiagnosisEvents:]
+	}
+	d.Events = items
+}
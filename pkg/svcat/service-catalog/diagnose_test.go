@@ -0,0 +1,180 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diagnose", func() {
+	var (
+		broker *v1beta1.ClusterServiceBroker
+		sc     *v1beta1.ClusterServiceClass
+		sp     *v1beta1.ClusterServicePlan
+		si     *v1beta1.ServiceInstance
+		sbd    *v1beta1.ServiceBinding
+		event  *corev1.Event
+	)
+
+	newSDK := func() *SDK {
+		return &SDK{
+			K8sClient:            k8sfake.NewSimpleClientset(event),
+			ServiceCatalogClient: fake.NewSimpleClientset(broker, sc, sp, si, sbd),
+		}
+	}
+
+	summaries := func(d *Diagnosis, severity Severity) []string {
+		var results []string
+		for _, f := range d.Findings {
+			if f.Severity == severity {
+				results = append(results, f.Summary)
+			}
+		}
+		return results
+	}
+
+	BeforeEach(func() {
+		broker = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar-broker"}}
+		broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{
+			{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue, Reason: "FetchedCatalog"},
+		}
+		sc = &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar-class"}}
+		sc.Spec.ClusterServiceBrokerName = broker.Name
+		sc.Spec.ExternalName = "foobar"
+		sp = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar-plan"}}
+		sp.Spec.ClusterServiceBrokerName = broker.Name
+		sp.Spec.ExternalName = "small"
+		sp.Spec.ClusterServiceClassRef = v1beta1.ClusterObjectReference{Name: sc.Name}
+
+		start := metav1.NewTime(time.Now().Add(-time.Hour))
+		si = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace", Generation: 1},
+			Spec: v1beta1.ServiceInstanceSpec{
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: sc.Name},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: sp.Name},
+			},
+			Status: v1beta1.ServiceInstanceStatus{
+				Conditions: []v1beta1.ServiceInstanceCondition{
+					{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse, Reason: "Provisioning"},
+				},
+				AsyncOpInProgress:  true,
+				CurrentOperation:   v1beta1.ServiceInstanceOperationProvision,
+				OperationStartTime: &start,
+				ObservedGeneration: 1,
+			},
+		}
+		sbd = &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar-binding", Namespace: "foobar_namespace"},
+			Spec: v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: v1beta1.LocalObjectReference{Name: si.Name},
+				SecretName:         "foobar-binding",
+			},
+			Status: v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Reason: "ErrorInstanceNotReady"},
+				},
+			},
+		}
+		event = &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "foobar.1", Namespace: "foobar_namespace"},
+			InvolvedObject: corev1.ObjectReference{Kind: "ServiceInstance", Name: si.Name},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Provisioning",
+		}
+	})
+
+	Describe("DiagnoseInstance", func() {
+		It("Explains an asynchronous operation within the retry duration", func() {
+			d, err := newSDK().DiagnoseInstance(si.Namespace, si.Name, DiagnoseOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(d.Reason).To(Equal("Provisioning"))
+			Expect(d.HasProblems()).To(BeFalse())
+			Expect(summaries(d, SeverityInfo)).To(ContainElement(ContainSubstring("The broker is provisioning the instance asynchronously")))
+			Expect(summaries(d, SeverityInfo)).To(ContainElement(ContainSubstring("The controller retries the operation until")))
+			Expect(summaries(d, SeverityInfo)).To(ContainElement("The broker 'foobar-broker' is ready."))
+			Expect(d.Events).To(HaveLen(1))
+		})
+		It("Reports an operation retried longer than the retry duration", func() {
+			d, err := newSDK().DiagnoseInstance(si.Namespace, si.Name, DiagnoseOptions{RetryDuration: time.Minute})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(summaries(d, SeverityError)).To(ConsistOf(ContainSubstring("longer than the reconciliation retry duration of 1m0s")))
+			Expect(d.Findings).To(ContainElement(Finding{
+				Severity:   SeverityError,
+				Summary:    summaries(d, SeverityError)[0],
+				Suggestion: "Fix the cause, then run 'svcat touch instance foobar -n foobar_namespace' to make the controller try again.",
+			}))
+		})
+		It("Reports plans removed from the catalog and brokers that are not ready", func() {
+			sp.Status.RemovedFromBrokerCatalog = true
+			broker.Status.Conditions[0] = v1beta1.ServiceBrokerCondition{
+				Type:    v1beta1.ServiceBrokerConditionReady,
+				Status:  v1beta1.ConditionFalse,
+				Reason:  "ErrorFetchingCatalog",
+				Message: "connection refused",
+			}
+
+			d, err := newSDK().DiagnoseInstance(si.Namespace, si.Name, DiagnoseOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(summaries(d, SeverityWarning)).To(ConsistOf("The plan 'small' was removed from the catalog of its broker."))
+			Expect(summaries(d, SeverityError)).To(ConsistOf("The broker 'foobar-broker' is not ready, ErrorFetchingCatalog: connection refused."))
+		})
+		It("Explains references to a plan that does not exist", func() {
+			si.Spec.ClusterServicePlanRef = nil
+			si.Status.Conditions[0].Reason = "ReferencesNonexistentServicePlan"
+			si.Status.CurrentOperation = ""
+
+			d, err := newSDK().DiagnoseInstance(si.Namespace, si.Name, DiagnoseOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(summaries(d, SeverityError)).To(ConsistOf("The plan of the instance does not exist."))
+		})
+	})
+
+	Describe("DiagnoseBinding", func() {
+		It("Points to the instance when it is not ready", func() {
+			d, err := newSDK().DiagnoseBinding(sbd.Namespace, sbd.Name, DiagnoseOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(summaries(d, SeverityWarning)).To(ConsistOf(
+				"The binding waits for its instance to become ready.",
+				"The instance 'foobar_namespace/foobar' is not ready.",
+			))
+		})
+		It("Reports a missing instance", func() {
+			sbd.Spec.ServiceInstanceRef.Name = "missing"
+
+			d, err := newSDK().DiagnoseBinding(sbd.Namespace, sbd.Name, DiagnoseOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(summaries(d, SeverityError)).To(ConsistOf("The instance 'foobar_namespace/missing' of the binding does not exist."))
+		})
+	})
+})
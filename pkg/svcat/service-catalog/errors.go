@@ -0,0 +1,158 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// NotFoundError is returned when a resource does not exist.
+type NotFoundError struct {
+	// Kind of the resource, e.g. instance.
+	Kind string
+	// Name of the resource, prefixed with its namespace if any.
+	Name string
+	// Err is the error returned by the API server, if any.
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("unable to get %s '%s' (%s)", e.Kind, e.Name, e.Err)
+	}
+	return fmt.Sprintf("%s '%s' not found", e.Kind, e.Name)
+}
+
+// AmbiguousError is returned when a name matches several resources, e.g. a
+// cluster and a namespaced class.
+type AmbiguousError struct {
+	// Kind of the resources, e.g. class.
+	Kind string
+	// Name matching the resources.
+	Name string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("more than one matching %s found for '%s'", e.Kind, e.Name)
+}
+
+// TimeoutError is returned when a resource does not meet the condition that
+// was waited for in time.
+type TimeoutError struct {
+	// Kind of the resource, e.g. instance.
+	Kind string
+	// Name of the resource, prefixed with its namespace if any.
+	Name string
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %s '%s'", e.Kind, e.Name)
+}
+
+// FailedError is returned when a resource fails, instead of meeting the
+// condition that was waited for.
+type FailedError struct {
+	// Kind of the resource, e.g. instance.
+	Kind string
+	// Name of the resource, prefixed with its namespace if any.
+	Name string
+	// Reason of the Failed condition.
+	Reason string
+	// Message of the Failed condition.
+	Message string
+}
+
+func (e *FailedError) Error() string {
+	return fmt.Sprintf("%s '%s' failed (%s): %s", e.Kind, e.Name, e.Reason, e.Message)
+}
+
+// causer is implemented by the errors wrapped with github.com/pkg/errors.
+type causer interface {
+	Cause() error
+}
+
+// findError returns whether an error, or any error it wraps, matches.
+func findError(err error, matches func(error) bool) bool {
+	for err != nil {
+		if matches(err) {
+			return true
+		}
+		c, ok := err.(causer)
+		if !ok {
+			return false
+		}
+		err = c.Cause()
+	}
+	return false
+}
+
+// IsNotFound returns if the error indicates that a resource does not exist.
+func IsNotFound(err error) bool {
+	return findError(err, func(err error) bool {
+		_, ok := err.(*NotFoundError)
+		return ok || apierrors.IsNotFound(err)
+	})
+}
+
+// IsAmbiguous returns if the error indicates that a name matches several
+// resources.
+func IsAmbiguous(err error) bool {
+	return findError(err, func(err error) bool {
+		_, ok := err.(*AmbiguousError)
+		return ok
+	})
+}
+
+// IsTimeout returns if the error indicates that a resource didn't meet the
+// condition that was waited for in time.
+func IsTimeout(err error) bool {
+	return findError(err, func(err error) bool {
+		_, ok := err.(*TimeoutError)
+		return ok || err == wait.ErrWaitTimeout || err == context.DeadlineExceeded
+	})
+}
+
+// IsFailed returns if the error indicates that a resource failed while it
+// was waited for.
+func IsFailed(err error) bool {
+	return findError(err, func(err error) bool {
+		_, ok := err.(*FailedError)
+		return ok
+	})
+}
+
+// getError converts the error of getting a resource from the API server,
+// returning a NotFoundError when the resource does not exist.
+func getError(kind, name string, err error) error {
+	if apierrors.IsNotFound(err) {
+		return &NotFoundError{Kind: kind, Name: name, Err: err}
+	}
+	return fmt.Errorf("unable to get %s '%s' (%s)", kind, name, err)
+}
+
+// contextError converts the error of a context that is done while waiting
+// for a resource, returning a TimeoutError when its deadline was exceeded.
+func contextError(ctx context.Context, kind, name string) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return &TimeoutError{Kind: kind, Name: name}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Errors", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		si           *v1beta1.ServiceInstance
+		csb          *v1beta1.ClusterServiceBroker
+		sb           *v1beta1.ServiceBroker
+	)
+
+	BeforeEach(func() {
+		si = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"}}
+		csb = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
+		sb = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+		svcCatClient = fake.NewSimpleClientset(si, csb, sb)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("IsNotFound", func() {
+		It("Matches wrapped errors", func() {
+			err := errors.Wrap(&NotFoundError{Kind: "class", Name: "mysql"}, "unable to provision")
+
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(IsAmbiguous(err)).To(BeFalse())
+		})
+		It("Matches the errors of the API server", func() {
+			err := apierrors.NewNotFound(schema.GroupResource{Resource: "serviceinstances"}, "foobar")
+
+			Expect(IsNotFound(err)).To(BeTrue())
+		})
+		It("Is returned when a resource does not exist", func() {
+			_, err := sdk.RetrieveInstance(si.Namespace, "missing")
+
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.(*NotFoundError).Name).To(Equal("foobar_namespace.missing"))
+
+			_, err = sdk.RetrieveBroker("missing", ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("broker 'missing' not found"))
+		})
+		It("Is returned when a resource does not exist in a single scope", func() {
+			_, err := sdk.RetrieveBroker("missing", ScopeOptions{Scope: ClusterScope})
+			Expect(IsNotFound(err)).To(BeTrue())
+
+			_, err = sdk.RetrieveClassByID("missing", ScopeOptions{Scope: ClusterScope})
+			Expect(IsNotFound(err)).To(BeTrue())
+
+			_, err = sdk.RetrieveClassByPlan(&v1beta1.ClusterServicePlan{
+				Spec: v1beta1.ClusterServicePlanSpec{
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "missing"},
+				},
+			})
+			Expect(IsNotFound(err)).To(BeTrue())
+
+			_, err = sdk.RetrievePlanByID("missing", ScopeOptions{Scope: ClusterScope})
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.(*NotFoundError).Kind).To(Equal("plan"))
+		})
+		It("Does not match other errors", func() {
+			Expect(IsNotFound(nil)).To(BeFalse())
+			Expect(IsNotFound(fmt.Errorf("oops"))).To(BeFalse())
+		})
+	})
+	Describe("IsAmbiguous", func() {
+		It("Is returned when a name matches several resources", func() {
+			_, err := sdk.RetrieveBroker(sb.Name, ScopeOptions{Scope: AllScope, Namespace: sb.Namespace})
+
+			Expect(IsAmbiguous(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("more than one matching broker found for 'foobar'"))
+		})
+	})
+	Describe("IsTimeout", func() {
+		It("Matches the timeouts of the wait package and contexts", func() {
+			Expect(IsTimeout(&TimeoutError{Kind: "instance", Name: "foobar"})).To(BeTrue())
+			Expect(IsTimeout(wait.ErrWaitTimeout)).To(BeTrue())
+			Expect(IsTimeout(context.DeadlineExceeded)).To(BeTrue())
+			Expect(IsTimeout(context.Canceled)).To(BeFalse())
+		})
+	})
+	Describe("IsFailed", func() {
+		It("Matches wrapped errors", func() {
+			err := errors.Wrap(&FailedError{Kind: "instance", Name: "foobar", Reason: "ProvisionCallFailed"}, "unable to wait")
+
+			Expect(IsFailed(err)).To(BeTrue())
+			Expect(IsTimeout(err)).To(BeFalse())
+		})
+	})
+	Describe("Context", func() {
+		It("Stops before calling the API server once the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := sdk.RetrieveInstanceContext(ctx, si.Namespace, si.Name)
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.RetrieveBindingContext(ctx, si.Namespace, si.Name)
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.RetrieveBrokerContext(ctx, csb.Name, ScopeOptions{Scope: ClusterScope})
+			Expect(err).To(Equal(context.Canceled))
+			err = sdk.DeprovisionContext(ctx, si.Namespace, si.Name)
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.UnbindContext(ctx, si.Namespace, si.Name)
+			Expect(err).To(Equal(context.Canceled))
+			err = sdk.SyncContext(ctx, csb.Name, 3)
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.RetrieveBrokersContext(ctx, ScopeOptions{Scope: AllScope, Namespace: si.Namespace})
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.RetrieveClassesContext(ctx, ScopeOptions{Scope: AllScope, Namespace: si.Namespace})
+			Expect(err).To(Equal(context.Canceled))
+			_, err = sdk.RetrievePlansContext(ctx, nil, ScopeOptions{Scope: AllScope, Namespace: si.Namespace})
+			Expect(err).To(Equal(context.Canceled))
+
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/testing"
+)
+
+// listSelected lists the resources matching the field selector, which the
+// tracker ignores, unlike the API server.
+func (f *SDK) listSelected(action testing.Action) (bool, runtime.Object, error) {
+	list := action.(testing.ListActionImpl)
+	obj, err := f.tracker.List(action.GetResource(), list.GetKind(), action.GetNamespace())
+	if err != nil {
+		return true, nil, err
+	}
+	selector := list.GetListRestrictions().Fields
+	if selector == nil || selector.Empty() {
+		return true, obj, nil
+	}
+
+	items, err := meta.ExtractList(obj)
+	if err != nil {
+		return true, nil, err
+	}
+	var selected []runtime.Object
+	for _, item := range items {
+		if selector.Matches(objectFields(item)) {
+			selected = append(selected, item)
+		}
+	}
+	if err := meta.SetList(obj, selected); err != nil {
+		return true, nil, err
+	}
+	return true, obj, nil
+}
+
+// objectFields returns the fields of a resource that the API server
+// supports in field selectors.
+func objectFields(obj runtime.Object) fields.Set {
+	set := fields.Set{}
+	if m, err := meta.Accessor(obj); err == nil {
+		set["metadata.name"] = m.GetName()
+		set["metadata.namespace"] = m.GetNamespace()
+	}
+
+	switch o := obj.(type) {
+	case *v1beta1.ClusterServiceClass:
+		set["spec.externalName"] = o.Spec.ExternalName
+		set["spec.clusterServiceBrokerName"] = o.Spec.ClusterServiceBrokerName
+	case *v1beta1.ServiceClass:
+		set["spec.externalName"] = o.Spec.ExternalName
+		set["spec.serviceBrokerName"] = o.Spec.ServiceBrokerName
+	case *v1beta1.ClusterServicePlan:
+		set["spec.externalName"] = o.Spec.ExternalName
+		set["spec.clusterServiceBrokerName"] = o.Spec.ClusterServiceBrokerName
+		set["spec.clusterServiceClassRef.name"] = o.Spec.ClusterServiceClassRef.Name
+	case *v1beta1.ServicePlan:
+		set["spec.externalName"] = o.Spec.ExternalName
+		set["spec.serviceBrokerName"] = o.Spec.ServiceBrokerName
+		set["spec.serviceClassRef.name"] = o.Spec.ServiceClassRef.Name
+	case *v1beta1.ServiceInstance:
+		if ref := o.Spec.ClusterServiceClassRef; ref != nil {
+			set["spec.clusterServiceClassRef.name"] = ref.Name
+		}
+		if ref := o.Spec.ClusterServicePlanRef; ref != nil {
+			set["spec.clusterServicePlanRef.name"] = ref.Name
+		}
+		if ref := o.Spec.ServiceClassRef; ref != nil {
+			set["spec.serviceClassRef.name"] = ref.Name
+		}
+		if ref := o.Spec.ServicePlanRef; ref != nil {
+			set["spec.servicePlanRef.name"] = ref.Name
+		}
+	}
+	return set
+}
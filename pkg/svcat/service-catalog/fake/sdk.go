@@ -0,0 +1,260 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory implementation of the svcat SDK for
+// tests, so that consumers don't need a cluster or recorded responses.
+package fake
+
+import (
+	"sync"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatfake "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	svcatscheme "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/scheme"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+)
+
+var (
+	instancesResource = v1beta1.SchemeGroupVersion.WithResource("serviceinstances")
+	bindingsResource  = v1beta1.SchemeGroupVersion.WithResource("servicebindings")
+)
+
+// SDK is an in-memory svcat SDK. Resources are held by fake clientsets that
+// honor field selectors, and the instances and bindings move through
+// scripted condition transitions, one transition each time they are
+// retrieved, or all of them as soon as they are watched. Errors can be
+// injected by prepending reactors to the clientsets.
+type SDK struct {
+	*servicecatalog.SDK
+
+	// Catalog is the fake clientset holding the Service Catalog resources.
+	Catalog *svcatfake.Clientset
+
+	// Kube is the fake clientset holding the Kubernetes resources, such
+	// as the secrets of the bindings.
+	Kube *k8sfake.Clientset
+
+	// InstanceTransitions are the transitions of the instances that are
+	// created or updated without a script of their own.
+	InstanceTransitions []Transition
+
+	// BindingTransitions are the transitions of the bindings that are
+	// created without a script of their own.
+	BindingTransitions []Transition
+
+	tracker testing.ObjectTracker
+	mu      sync.Mutex
+	scripts map[scriptKey][]Transition
+}
+
+var _ servicecatalog.SvcatClient = &SDK{}
+
+type scriptKey struct {
+	resource  schema.GroupVersionResource
+	namespace string
+	name      string
+}
+
+// NewSDK returns an SDK holding the objects, where new instances and
+// bindings are in progress first, then ready.
+func NewSDK(objects ...runtime.Object) *SDK {
+	tracker := testing.NewObjectTracker(svcatscheme.Scheme, svcatscheme.Codecs.UniversalDecoder())
+	var kubeObjects []runtime.Object
+	for _, obj := range objects {
+		if _, _, err := svcatscheme.Scheme.ObjectKinds(obj); err != nil {
+			kubeObjects = append(kubeObjects, obj)
+			continue
+		}
+		if err := tracker.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	f := &SDK{
+		Catalog:             svcatfake.NewSimpleClientset(),
+		Kube:                k8sfake.NewSimpleClientset(kubeObjects...),
+		InstanceTransitions: []Transition{InProgress(), Ready()},
+		BindingTransitions:  []Transition{InProgress(), Ready()},
+		tracker:             tracker,
+		scripts:             make(map[scriptKey][]Transition),
+	}
+	f.SDK = &servicecatalog.SDK{
+		K8sClient:            f.Kube,
+		ServiceCatalogClient: f.Catalog,
+	}
+
+	// Replace the tracker of the clientset, which isn't accessible, by one
+	// that the scripts can update.
+	f.Catalog.ReactionChain = nil
+	f.Catalog.WatchReactionChain = nil
+	f.Catalog.AddReactor("create", "*", f.scriptCreated)
+	f.Catalog.AddReactor("update", "serviceinstances", f.updateInstance)
+	f.Catalog.AddReactor("get", "*", f.advanceRetrieved)
+	f.Catalog.AddReactor("list", "*", f.listSelected)
+	f.Catalog.AddReactor("delete", "*", f.unscriptDeleted)
+	f.Catalog.AddReactor("*", "*", testing.ObjectReaction(tracker))
+	f.Catalog.AddWatchReactor("*", f.watch)
+
+	return f
+}
+
+// ScriptInstance sets the transitions of an instance, replacing the ones
+// that are left.
+func (f *SDK) ScriptInstance(ns, name string, transitions ...Transition) {
+	f.script(scriptKey{instancesResource, ns, name}, transitions)
+}
+
+// ScriptBinding sets the transitions of a binding, replacing the ones that
+// are left.
+func (f *SDK) ScriptBinding(ns, name string, transitions ...Transition) {
+	f.script(scriptKey{bindingsResource, ns, name}, transitions)
+}
+
+func (f *SDK) script(key scriptKey, transitions []Transition) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scripts[key] = append([]Transition(nil), transitions...)
+}
+
+// scriptCreated scripts the new instances and bindings with the default
+// transitions, unless they are already scripted.
+func (f *SDK) scriptCreated(action testing.Action) (bool, runtime.Object, error) {
+	var transitions []Transition
+	switch action.GetResource() {
+	case instancesResource:
+		transitions = f.InstanceTransitions
+	case bindingsResource:
+		transitions = f.BindingTransitions
+	default:
+		return false, nil, nil
+	}
+
+	m, err := meta.Accessor(action.(testing.CreateAction).GetObject())
+	if err != nil {
+		return false, nil, nil
+	}
+	key := scriptKey{action.GetResource(), action.GetNamespace(), m.GetName()}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.scripts[key]; !ok {
+		f.scripts[key] = append([]Transition(nil), transitions...)
+	}
+	return false, nil, nil
+}
+
+// updateInstance increments the generation of an instance when its spec
+// changes, like the API server does, and scripts it with the default
+// transitions unless it is already scripted.
+func (f *SDK) updateInstance(action testing.Action) (bool, runtime.Object, error) {
+	if action.GetSubresource() != "" {
+		return false, nil, nil
+	}
+	instance := action.(testing.UpdateAction).GetObject().(*v1beta1.ServiceInstance).DeepCopy()
+	obj, err := f.tracker.Get(instancesResource, instance.Namespace, instance.Name)
+	if err != nil {
+		return true, nil, err
+	}
+	current := obj.(*v1beta1.ServiceInstance)
+	if equality.Semantic.DeepEqual(current.Spec, instance.Spec) {
+		return false, nil, nil
+	}
+
+	instance.Generation = current.Generation + 1
+	if err := f.tracker.Update(instancesResource, instance, instance.Namespace); err != nil {
+		return true, nil, err
+	}
+
+	key := scriptKey{instancesResource, instance.Namespace, instance.Name}
+	f.mu.Lock()
+	if len(f.scripts[key]) == 0 {
+		f.scripts[key] = append([]Transition(nil), f.InstanceTransitions...)
+	}
+	f.mu.Unlock()
+
+	obj, err = f.tracker.Get(instancesResource, instance.Namespace, instance.Name)
+	return true, obj, err
+}
+
+// advanceRetrieved applies the next transition of a resource before it is
+// retrieved.
+func (f *SDK) advanceRetrieved(action testing.Action) (bool, runtime.Object, error) {
+	name := action.(testing.GetAction).GetName()
+	f.advance(scriptKey{action.GetResource(), action.GetNamespace(), name}, 1)
+	return false, nil, nil
+}
+
+// unscriptDeleted drops the transitions left to a deleted resource.
+func (f *SDK) unscriptDeleted(action testing.Action) (bool, runtime.Object, error) {
+	name := action.(testing.DeleteAction).GetName()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.scripts, scriptKey{action.GetResource(), action.GetNamespace(), name})
+	return false, nil, nil
+}
+
+// watch watches the tracker, then applies all the transitions left to the
+// resource selected by name, if any.
+func (f *SDK) watch(action testing.Action) (bool, watch.Interface, error) {
+	w, err := f.tracker.Watch(action.GetResource(), action.GetNamespace())
+	if err != nil {
+		return true, nil, err
+	}
+	restrictions := action.(testing.WatchAction).GetWatchRestrictions()
+	if restrictions.Fields != nil {
+		if name, ok := restrictions.Fields.RequiresExactMatch("metadata.name"); ok {
+			go f.advance(scriptKey{action.GetResource(), action.GetNamespace(), name}, -1)
+		}
+	}
+	return true, w, nil
+}
+
+// advance applies up to count transitions of a resource, or all of them
+// when count is negative.
+func (f *SDK) advance(key scriptKey, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ; count != 0 && len(f.scripts[key]) > 0; count-- {
+		transition := f.scripts[key][0]
+		f.scripts[key] = f.scripts[key][1:]
+
+		obj, err := f.tracker.Get(key.resource, key.namespace, key.name)
+		if err != nil {
+			return
+		}
+		switch resource := obj.DeepCopyObject().(type) {
+		case *v1beta1.ServiceInstance:
+			transition.applyToInstance(resource)
+			obj = resource
+		case *v1beta1.ServiceBinding:
+			transition.applyToBinding(resource)
+			obj = resource
+		default:
+			return
+		}
+		if err := f.tracker.Update(key.resource, obj, key.namespace); err != nil {
+			return
+		}
+	}
+}
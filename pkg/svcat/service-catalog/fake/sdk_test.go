@@ -0,0 +1,164 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ns = "default"
+
+func newSDK() *fake.SDK {
+	return fake.NewSDK(
+		&v1beta1.ClusterServiceClass{
+			ObjectMeta: v1.ObjectMeta{Name: "mysql-id"},
+			Spec:       v1beta1.ClusterServiceClassSpec{CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "mysql"}},
+		},
+		&v1beta1.ClusterServiceClass{
+			ObjectMeta: v1.ObjectMeta{Name: "redis-id"},
+			Spec:       v1beta1.ClusterServiceClassSpec{CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "redis"}},
+		},
+		&corev1.Secret{
+			ObjectMeta: v1.ObjectMeta{Name: "mysql-creds", Namespace: ns},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		},
+	)
+}
+
+func TestProvisionTransitions(t *testing.T) {
+	sdk := newSDK()
+	if _, err := sdk.Provision(ns, "mydb", "", "mysql", "free", nil, nil, servicecatalog.AllScope); err != nil {
+		t.Fatalf("provision failed: %s", err)
+	}
+
+	instance, err := sdk.RetrieveInstance(ns, "mydb")
+	if err != nil {
+		t.Fatalf("retrieve failed: %s", err)
+	}
+	if !instance.Status.AsyncOpInProgress || sdk.IsInstanceReady(instance) {
+		t.Errorf("expected the instance to be in progress, got %+v", instance.Status)
+	}
+
+	timeout := time.Second
+	instance, err = sdk.WaitForInstance(ns, "mydb", time.Millisecond, &timeout)
+	if err != nil {
+		t.Fatalf("wait failed: %s", err)
+	}
+	if !sdk.IsInstanceReady(instance) || instance.Status.AsyncOpInProgress {
+		t.Errorf("expected the instance to be ready, got %+v", instance.Status)
+	}
+}
+
+func TestScriptedFailure(t *testing.T) {
+	sdk := newSDK()
+	sdk.ScriptInstance(ns, "mydb", fake.InProgress(), fake.InProgress(), fake.Failed("ProvisionCallFailed", "out of capacity"))
+	if _, err := sdk.Provision(ns, "mydb", "", "mysql", "free", nil, nil, servicecatalog.AllScope); err != nil {
+		t.Fatalf("provision failed: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := sdk.WaitForInstanceConditionContext(ctx, ns, "mydb", servicecatalog.WaitCondition{ConditionType: "Ready"})
+	if !servicecatalog.IsFailed(err) {
+		t.Fatalf("expected a failed error, got %v", err)
+	}
+	want := "instance 'default/mydb' failed (ProvisionCallFailed): out of capacity"
+	if err.Error() != want {
+		t.Errorf("unexpected error\nWANT: %s\nGOT:  %s", want, err)
+	}
+}
+
+func TestBindWatch(t *testing.T) {
+	sdk := newSDK()
+	sdk.InstanceTransitions = []fake.Transition{fake.Ready()}
+	sdk.Provision(ns, "mydb", "", "mysql", "free", nil, nil, servicecatalog.AllScope)
+	if _, err := sdk.Bind(ns, "mydb", "", "mydb", "mysql-creds", nil, nil); err != nil {
+		t.Fatalf("bind failed: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	binding, err := sdk.WaitForBindingContext(ctx, ns, "mydb")
+	if err != nil {
+		t.Fatalf("wait failed: %s", err)
+	}
+	if !sdk.IsBindingReady(binding) {
+		t.Errorf("expected the binding to be ready, got %+v", binding.Status)
+	}
+
+	creds, err := sdk.RetrieveCredentials(ns, "mydb")
+	if err != nil {
+		t.Fatalf("retrieve credentials failed: %s", err)
+	}
+	if string(creds["password"]) != "secret" {
+		t.Errorf("unexpected credentials %v", creds)
+	}
+}
+
+func TestUpdateGeneration(t *testing.T) {
+	sdk := newSDK()
+	sdk.InstanceTransitions = []fake.Transition{fake.Ready()}
+	sdk.Provision(ns, "mydb", "", "mysql", "free", nil, nil, servicecatalog.AllScope)
+	sdk.RetrieveInstance(ns, "mydb")
+
+	sdk.InstanceTransitions = []fake.Transition{fake.InProgress(), fake.Ready()}
+	if err := sdk.TouchInstance(ns, "mydb", 1); err != nil {
+		t.Fatalf("touch failed: %s", err)
+	}
+	instance, err := sdk.RetrieveInstance(ns, "mydb")
+	if err != nil {
+		t.Fatalf("retrieve failed: %s", err)
+	}
+	if instance.Generation != 1 || instance.Status.ObservedGeneration != 1 || !instance.Status.AsyncOpInProgress {
+		t.Errorf("expected the update of generation 1 to be in progress, got %+v", instance)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	instance, err = sdk.WaitForInstanceGenerationContext(ctx, ns, "mydb", instance.Generation)
+	if err != nil {
+		t.Fatalf("wait failed: %s", err)
+	}
+	if instance.Status.ObservedGeneration != 1 || !sdk.IsInstanceReady(instance) {
+		t.Errorf("expected the update to be done, got %+v", instance.Status)
+	}
+}
+
+func TestFieldSelectors(t *testing.T) {
+	sdk := newSDK()
+
+	class, err := sdk.RetrieveClassByName("redis", servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope})
+	if err != nil {
+		t.Fatalf("retrieve failed: %s", err)
+	}
+	if class.GetName() != "redis-id" {
+		t.Errorf("expected the redis class, got %s", class.GetName())
+	}
+
+	_, err = sdk.RetrieveClassByName("postgres", servicecatalog.ScopeOptions{Scope: servicecatalog.ClusterScope})
+	if !servicecatalog.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
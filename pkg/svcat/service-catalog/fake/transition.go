@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Transition is a status that a scripted instance or binding moves to.
+type Transition struct {
+	// Ready sets the Ready condition to true.
+	Ready bool
+
+	// Failed sets the Failed condition to true.
+	Failed bool
+
+	// Reason is the reason of the conditions.
+	Reason string
+
+	// Message is the message of the conditions.
+	Message string
+
+	// AsyncOpInProgress marks an asynchronous operation as in progress.
+	AsyncOpInProgress bool
+
+	// Time is the last transition time of the conditions, or the time the
+	// transition is applied when it is zero.
+	Time metav1.Time
+}
+
+// InProgress is an asynchronous operation that is not done yet.
+func InProgress() Transition {
+	return Transition{
+		Reason:            "InProgress",
+		Message:           "The operation is in progress",
+		AsyncOpInProgress: true,
+	}
+}
+
+// Ready is an operation that succeeded.
+func Ready() Transition {
+	return Transition{
+		Ready:   true,
+		Reason:  "Ready",
+		Message: "The operation succeeded",
+	}
+}
+
+// Failed is an operation that failed.
+func Failed(reason, message string) Transition {
+	return Transition{
+		Failed:  true,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// transitionTime returns the last transition time of the conditions.
+func (t Transition) transitionTime() metav1.Time {
+	if t.Time.IsZero() {
+		return metav1.Now()
+	}
+	return t.Time
+}
+
+// readyStatus returns the status of the Ready condition.
+func (t Transition) readyStatus() v1beta1.ConditionStatus {
+	if t.Ready {
+		return v1beta1.ConditionTrue
+	}
+	return v1beta1.ConditionFalse
+}
+
+func (t Transition) applyToInstance(instance *v1beta1.ServiceInstance) {
+	now := t.transitionTime()
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+		{
+			Type:               v1beta1.ServiceInstanceConditionReady,
+			Status:             t.readyStatus(),
+			LastTransitionTime: now,
+			Reason:             t.Reason,
+			Message:            t.Message,
+		},
+	}
+	if t.Failed {
+		instance.Status.Conditions = append(instance.Status.Conditions, v1beta1.ServiceInstanceCondition{
+			Type:               v1beta1.ServiceInstanceConditionFailed,
+			Status:             v1beta1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             t.Reason,
+			Message:            t.Message,
+		})
+	}
+	instance.Status.AsyncOpInProgress = t.AsyncOpInProgress
+	instance.Status.ObservedGeneration = instance.Generation
+	if t.Ready {
+		instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	}
+}
+
+func (t Transition) applyToBinding(binding *v1beta1.ServiceBinding) {
+	now := t.transitionTime()
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+		{
+			Type:               v1beta1.ServiceBindingConditionReady,
+			Status:             t.readyStatus(),
+			LastTransitionTime: now,
+			Reason:             t.Reason,
+			Message:            t.Message,
+		},
+	}
+	if t.Failed {
+		binding.Status.Conditions = append(binding.Status.Conditions, v1beta1.ServiceBindingCondition{
+			Type:               v1beta1.ServiceBindingConditionFailed,
+			Status:             v1beta1.ConditionTrue,
+			LastTransitionTime: now,
+			Reason:             t.Reason,
+			Message:            t.Message,
+		})
+	}
+	binding.Status.AsyncOpInProgress = t.AsyncOpInProgress
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -25,7 +26,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 const (
@@ -43,11 +46,21 @@ func (sdk *SDK) RetrieveInstances(ns string) (*v1beta1.ServiceInstanceList, erro
 	return instances, nil
 }
 
-// RetrieveInstance gets an instance by its name.
+// RetrieveInstance gets an instance by its name. A NotFoundError is returned
+// when the instance does not exist.
 func (sdk *SDK) RetrieveInstance(ns, name string) (*v1beta1.ServiceInstance, error) {
+	return sdk.RetrieveInstanceContext(context.Background(), ns, name)
+}
+
+// RetrieveInstanceContext is RetrieveInstance, failing with the error of the
+// context if it is done before the request is sent.
+func (sdk *SDK) RetrieveInstanceContext(ctx context.Context, ns, name string) (*v1beta1.ServiceInstance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	instance, err := sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("unable to get instance '%s.%s' (%s)", ns, name, err)
+		return nil, getError("instance", ns+"."+name, err)
 	}
 	return instance, nil
 }
@@ -65,22 +78,36 @@ func (sdk *SDK) RetrieveInstanceByBinding(b *v1beta1.ServiceBinding,
 }
 
 // RetrieveInstancesByPlan retrieves all instances of a plan.
-func (sdk *SDK) RetrieveInstancesByPlan(plan *v1beta1.ClusterServicePlan,
-) ([]v1beta1.ServiceInstance, error) {
-	planOpts := v1.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(FieldServicePlanRef, plan.Name).String(),
+func (sdk *SDK) RetrieveInstancesByPlan(plan Plan) ([]v1beta1.ServiceInstance, error) {
+	if plan.GetNamespace() == "" {
+		planOpts := v1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(FieldServicePlanRef, plan.GetName()).String(),
+		}
+		instances, err := sdk.ServiceCatalog().ServiceInstances("").List(planOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list instances (%s)", err)
+		}
+
+		return instances.Items, nil
 	}
-	instances, err := sdk.ServiceCatalog().ServiceInstances("").List(planOpts)
+
+	// Instances cannot be selected by their namespaced plan reference, so
+	// filter the instances of the plan's namespace instead.
+	instances, err := sdk.ServiceCatalog().ServiceInstances(plan.GetNamespace()).List(v1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to list instances (%s)", err)
 	}
-
-	return instances.Items, nil
+	results := make([]v1beta1.ServiceInstance, 0)
+	for _, instance := range instances.Items {
+		if instance.Spec.ServicePlanRef != nil && instance.Spec.ServicePlanRef.Name == plan.GetName() {
+			results = append(results, instance)
+		}
+	}
+	return results, nil
 }
 
 // InstanceParentHierarchy retrieves all ancestor resources of an instance.
-func (sdk *SDK) InstanceParentHierarchy(instance *v1beta1.ServiceInstance,
-) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, *v1beta1.ClusterServiceBroker, error) {
+func (sdk *SDK) InstanceParentHierarchy(instance *v1beta1.ServiceInstance) (Class, Plan, Broker, error) {
 	class, plan, err := sdk.InstanceToServiceClassAndPlan(instance)
 	if err != nil {
 		return nil, nil, nil, err
@@ -95,13 +122,11 @@ func (sdk *SDK) InstanceParentHierarchy(instance *v1beta1.ServiceInstance,
 }
 
 // InstanceToServiceClassAndPlan retrieves the parent class and plan for an instance.
-func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
-) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, error) {
-	classID := instance.Spec.ClusterServiceClassRef.Name
-	classCh := make(chan *v1beta1.ClusterServiceClass)
+func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance) (Class, Plan, error) {
+	classCh := make(chan Class)
 	classErrCh := make(chan error)
 	go func() {
-		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(classID, v1.GetOptions{})
+		class, err := sdk.retrieveInstanceClass(instance)
 		if err != nil {
 			classErrCh <- err
 			return
@@ -109,11 +134,10 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 		classCh <- class
 	}()
 
-	planID := instance.Spec.ClusterServicePlanRef.Name
-	planCh := make(chan *v1beta1.ClusterServicePlan)
+	planCh := make(chan Plan)
 	planErrCh := make(chan error)
 	go func() {
-		plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(planID, v1.GetOptions{})
+		plan, err := sdk.retrieveInstancePlan(instance)
 		if err != nil {
 			planErrCh <- err
 			return
@@ -121,8 +145,8 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 		planCh <- plan
 	}()
 
-	var class *v1beta1.ClusterServiceClass
-	var plan *v1beta1.ClusterServicePlan
+	var class Class
+	var plan Plan
 	for {
 		select {
 		case cl := <-classCh:
@@ -144,9 +168,44 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	}
 }
 
-// Provision creates an instance of a service class and plan.
+// retrieveInstanceClass gets the class referenced by an instance.
+func (sdk *SDK) retrieveInstanceClass(instance *v1beta1.ServiceInstance) (Class, error) {
+	if instance.Spec.ClusterServiceClassRef != nil {
+		return sdk.ServiceCatalog().ClusterServiceClasses().Get(instance.Spec.ClusterServiceClassRef.Name, v1.GetOptions{})
+	}
+	if instance.Spec.ServiceClassRef != nil {
+		return sdk.ServiceCatalog().ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name, v1.GetOptions{})
+	}
+	return nil, fmt.Errorf("instance '%s/%s' does not reference a class", instance.Namespace, instance.Name)
+}
+
+// retrieveInstancePlan gets the plan referenced by an instance.
+func (sdk *SDK) retrieveInstancePlan(instance *v1beta1.ServiceInstance) (Plan, error) {
+	if instance.Spec.ClusterServicePlanRef != nil {
+		return sdk.ServiceCatalog().ClusterServicePlans().Get(instance.Spec.ClusterServicePlanRef.Name, v1.GetOptions{})
+	}
+	if instance.Spec.ServicePlanRef != nil {
+		return sdk.ServiceCatalog().ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name, v1.GetOptions{})
+	}
+	return nil, fmt.Errorf("instance '%s/%s' does not reference a plan", instance.Namespace, instance.Name)
+}
+
+// Provision creates an instance of a service class and plan. The class and
+// plan names refer to a ServiceClass and ServicePlan in the instance's
+// namespace when scope is NamespaceScope, and to a ClusterServiceClass and
+// ClusterServicePlan otherwise.
 func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planName string,
-	params interface{}, secrets map[string]string) (*v1beta1.ServiceInstance, error) {
+	params interface{}, secrets map[string]string, scope Scope) (*v1beta1.ServiceInstance, error) {
+	return sdk.ProvisionContext(context.Background(), namespace, instanceName, externalID, className, planName, params, secrets, scope)
+}
+
+// ProvisionContext is Provision, failing with the error of the context if it
+// is done before the request is sent.
+func (sdk *SDK) ProvisionContext(ctx context.Context, namespace, instanceName, externalID, className, planName string,
+	params interface{}, secrets map[string]string, scope Scope) (*v1beta1.ServiceInstance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	request := &v1beta1.ServiceInstance{
 		ObjectMeta: v1.ObjectMeta{
@@ -154,15 +213,22 @@ func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planNa
 			Namespace: namespace,
 		},
 		Spec: v1beta1.ServiceInstanceSpec{
-			ExternalID: externalID,
-			PlanReference: v1beta1.PlanReference{
-				ClusterServiceClassExternalName: className,
-				ClusterServicePlanExternalName:  planName,
-			},
+			ExternalID:     externalID,
 			Parameters:     BuildParameters(params),
 			ParametersFrom: BuildParametersFrom(secrets),
 		},
 	}
+	if scope == NamespaceScope {
+		request.Spec.PlanReference = v1beta1.PlanReference{
+			ServiceClassExternalName: className,
+			ServicePlanExternalName:  planName,
+		}
+	} else {
+		request.Spec.PlanReference = v1beta1.PlanReference{
+			ClusterServiceClassExternalName: className,
+			ClusterServicePlanExternalName:  planName,
+		}
+	}
 
 	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
 	if err != nil {
@@ -173,6 +239,15 @@ func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planNa
 
 // Deprovision deletes an instance.
 func (sdk *SDK) Deprovision(namespace, instanceName string) error {
+	return sdk.DeprovisionContext(context.Background(), namespace, instanceName)
+}
+
+// DeprovisionContext is Deprovision, failing with the error of the context
+// if it is done before the request is sent.
+func (sdk *SDK) DeprovisionContext(ctx context.Context, namespace, instanceName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	err := sdk.ServiceCatalog().ServiceInstances(namespace).Delete(instanceName, &v1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("deprovision request failed (%s)", err)
@@ -205,8 +280,176 @@ func (sdk *SDK) TouchInstance(ns, name string, retries int) error {
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
 
+// UpdateInstanceOptions describes the changes to make to an instance. Fields
+// left empty keep their current value on the instance.
+type UpdateInstanceOptions struct {
+	// PlanName is the external name of the plan to switch to.
+	PlanName string
+	// Params replaces the parameters of the instance.
+	Params map[string]interface{}
+	// Secrets replaces the parameters of the instance read from secrets,
+	// mapping a secret name to the key holding the parameters.
+	Secrets map[string]string
+}
+
+// UpdateInstance changes the plan and parameters of an instance. Before
+// sending the update, it verifies that the class allows plan changes and that
+// the parameters match the update parameter schema of the target plan.
+func (sdk *SDK) UpdateInstance(ns, name string, opts *UpdateInstanceOptions, retries int) (*v1beta1.ServiceInstance, error) {
+	if opts == nil {
+		opts = &UpdateInstanceOptions{}
+	}
+
+	for j := 0; j < retries; j++ {
+		inst, err := sdk.RetrieveInstance(ns, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := sdk.applyInstanceUpdate(inst, opts); err != nil {
+			return nil, err
+		}
+
+		result, err := sdk.ServiceCatalog().ServiceInstances(ns).Update(inst)
+		if err == nil {
+			return result, nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("update request failed (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return nil, fmt.Errorf("could not update instance after %d tries", retries)
+}
+
+// applyInstanceUpdate validates the requested changes and applies them to the
+// instance spec.
+func (sdk *SDK) applyInstanceUpdate(inst *v1beta1.ServiceInstance, opts *UpdateInstanceOptions) error {
+	var plan Plan
+	var err error
+	if opts.PlanName != "" {
+		class, err := sdk.retrieveInstanceClass(inst)
+		if err != nil {
+			return fmt.Errorf("unable to get the class of instance '%s/%s' (%s)", inst.Namespace, inst.Name, err)
+		}
+		if !class.GetSpec().PlanUpdatable {
+			return fmt.Errorf("class '%s' does not allow changing the plan of an instance", class.GetExternalName())
+		}
+		plan, err = sdk.retrievePlanOfClass(class, opts.PlanName)
+		if err != nil {
+			return err
+		}
+	} else if len(opts.Params) > 0 {
+		plan, err = sdk.retrieveInstancePlan(inst)
+		if err != nil {
+			return fmt.Errorf("unable to get the plan of instance '%s/%s' (%s)", inst.Namespace, inst.Name, err)
+		}
+	}
+
+	if len(opts.Params) > 0 {
+		schema, err := ParseParameterSchema(plan.GetSpec().ServiceInstanceUpdateParameterSchema)
+		if err != nil {
+			return err
+		}
+		checkRequired := len(opts.Secrets) == 0 && len(inst.Spec.ParametersFrom) == 0
+		if err := schema.Validate(opts.Params, checkRequired); err != nil {
+			return err
+		}
+	}
+
+	if plan != nil && opts.PlanName != "" {
+		if plan.GetNamespace() == "" {
+			inst.Spec.ClusterServicePlanExternalName = plan.GetExternalName()
+			inst.Spec.ClusterServicePlanExternalID = ""
+			inst.Spec.ClusterServicePlanName = ""
+		} else {
+			inst.Spec.ServicePlanExternalName = plan.GetExternalName()
+			inst.Spec.ServicePlanExternalID = ""
+			inst.Spec.ServicePlanName = ""
+		}
+	}
+	if len(opts.Params) > 0 {
+		inst.Spec.Parameters = BuildParameters(opts.Params)
+	}
+	if len(opts.Secrets) > 0 {
+		inst.Spec.ParametersFrom = BuildParametersFrom(opts.Secrets)
+	}
+	return nil
+}
+
 // WaitForInstance waits for the instance to complete the current operation (or fail).
 func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
+	return sdk.waitForInstance(ns, name, interval, timeout, func(*v1beta1.ServiceInstance) bool {
+		return true
+	})
+}
+
+// WaitForInstanceGeneration waits for the instance to complete the operation
+// for the specified generation of its spec (or fail). Use it after an update,
+// when the instance may still report the outcome of an earlier operation.
+func (sdk *SDK) WaitForInstanceGeneration(ns, name string, generation int64, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
+	return sdk.waitForInstance(ns, name, interval, timeout, func(instance *v1beta1.ServiceInstance) bool {
+		return instance.Status.ObservedGeneration >= generation ||
+			instance.Status.ReconciledGeneration >= generation
+	})
+}
+
+// WaitForInstanceContext waits for the instance to complete the current
+// operation, watching it for changes until the context is done. A
+// FailedError is returned when the operation failed, and a TimeoutError when
+// the deadline of the context is exceeded. A nil instance is returned when
+// the instance is deleted.
+func (sdk *SDK) WaitForInstanceContext(ctx context.Context, ns, name string) (*v1beta1.ServiceInstance, error) {
+	return sdk.waitForInstanceContext(ctx, ns, name, func(*v1beta1.ServiceInstance) bool {
+		return true
+	})
+}
+
+// WaitForInstanceGenerationContext is WaitForInstanceContext, for the
+// operation of the specified generation of the instance's spec.
+func (sdk *SDK) WaitForInstanceGenerationContext(ctx context.Context, ns, name string, generation int64) (*v1beta1.ServiceInstance, error) {
+	return sdk.waitForInstanceContext(ctx, ns, name, func(instance *v1beta1.ServiceInstance) bool {
+		return instance.Status.ObservedGeneration >= generation ||
+			instance.Status.ReconciledGeneration >= generation
+	})
+}
+
+func (sdk *SDK) waitForInstanceContext(ctx context.Context, ns, name string,
+	observed func(*v1beta1.ServiceInstance) bool) (instance *v1beta1.ServiceInstance, err error) {
+	err = sdk.watchInstanceUntil(ctx, ns, name, func(i *v1beta1.ServiceInstance) (bool, error) {
+		instance = i
+		if i == nil {
+			return true, nil
+		}
+		if !observed(i) {
+			return false, nil
+		}
+		return operationDone("instance", ns+"/"+name, instanceConditions(i))
+	})
+	return instance, err
+}
+
+// watchInstanceUntil watches an instance for changes until done returns true
+// or an error, or the context is done. done is called with nil once the
+// instance does not exist.
+func (sdk *SDK) watchInstanceUntil(ctx context.Context, ns, name string, done func(*v1beta1.ServiceInstance) (bool, error)) error {
+	return watchUntil(ctx, "instance", ns+"/"+name, name,
+		func() (runtime.Object, error) {
+			return sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
+		},
+		func(opts v1.ListOptions) (watch.Interface, error) {
+			return sdk.ServiceCatalog().ServiceInstances(ns).Watch(opts)
+		},
+		func(obj runtime.Object) (bool, error) {
+			instance, _ := obj.(*v1beta1.ServiceInstance)
+			return done(instance)
+		})
+}
+
+func (sdk *SDK) waitForInstance(ns, name string, interval time.Duration, timeout *time.Duration,
+	observed func(*v1beta1.ServiceInstance) bool) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
 		notimeout := time.Duration(math.MaxInt64)
 		timeout = &notimeout
@@ -216,13 +459,13 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 		func() (bool, error) {
 			instance, err = sdk.RetrieveInstance(ns, name)
 			if nil != err {
-				if apierrors.IsNotFound(err) {
+				if IsNotFound(err) {
 					return true, nil
 				}
 				return false, err
 			}
 
-			if len(instance.Status.Conditions) == 0 {
+			if len(instance.Status.Conditions) == 0 || !observed(instance) {
 				return false, nil
 			}
 
@@ -230,6 +473,9 @@ func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout
 			return isDone, nil
 		},
 	)
+	if err == wait.ErrWaitTimeout {
+		err = &TimeoutError{Kind: "instance", Name: ns + "/" + name}
+	}
 
 	return instance, err
 }
@@ -246,6 +492,10 @@ func (sdk *SDK) IsInstanceFailed(instance *v1beta1.ServiceInstance) bool {
 
 // InstanceHasStatus returns if the instance is in the specified status.
 func (sdk *SDK) InstanceHasStatus(instance *v1beta1.ServiceInstance, status v1beta1.ServiceInstanceConditionType) bool {
+	if instance == nil {
+		return false
+	}
+
 	for _, cond := range instance.Status.Conditions {
 		if cond.Type == status &&
 			cond.Status == v1beta1.ConditionTrue {
@@ -170,6 +170,45 @@ var _ = Describe("Instances", func() {
 			opts := fields.Set{"spec.clusterServicePlanRef.name": plan.Name}
 			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
 		})
+		It("Filters the instances of a namespaced plan's namespace", func() {
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar_plan",
+					Namespace: "foobar_namespace",
+				},
+			}
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar",
+					Namespace: plan.Namespace,
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ServicePlanRef: &v1beta1.LocalObjectReference{
+						Name: plan.Name,
+					},
+				},
+			}
+			other := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other",
+					Namespace: plan.Namespace,
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ClusterServicePlanRef: &v1beta1.ClusterObjectReference{
+						Name: plan.Name,
+					},
+				},
+			}
+			linkedClient := fake.NewSimpleClientset(si, other)
+			sdk.ServiceCatalogClient = linkedClient
+
+			instances, err := sdk.RetrieveInstancesByPlan(plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(ConsistOf(*si))
+			actions := linkedClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(plan.Namespace))
+		})
 		It("Bubbles up errors", func() {
 			badClient := &fake.Clientset{}
 			errorMessage := "no instances found"
@@ -194,7 +233,7 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
 		})
 	})
-	Describe("UpdateInstance", func() {
+	Describe("TouchInstance", func() {
 		It("Properly increments the update requests field", func() {
 			namespace := "cherry_namespace"
 			instanceName := "cherry"
@@ -207,7 +246,7 @@ var _ = Describe("Instances", func() {
 			secrets["password"] = "abc123"
 			retries := 3
 
-			provisionedInstance, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets)
+			provisionedInstance, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets, ClusterScope)
 			Expect(err).To(BeNil())
 			// once for the provision request
 			actions := svcCatClient.Actions()
@@ -246,6 +285,104 @@ var _ = Describe("Instances", func() {
 			Expect(obj.Spec.UpdateRequests).To(Equal(int64(1)))
 		})
 	})
+	Describe("UpdateInstance", func() {
+		var (
+			class   *v1beta1.ClusterServiceClass
+			plan    *v1beta1.ClusterServicePlan
+			premium *v1beta1.ClusterServicePlan
+			inst    *v1beta1.ServiceInstance
+		)
+
+		BeforeEach(func() {
+			class = &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "mysql_class"}}
+			class.Spec.ExternalName = "mysql"
+			class.Spec.PlanUpdatable = true
+			plan = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "basic_plan"}}
+			plan.Spec.ExternalName = "basic"
+			plan.Spec.ClusterServiceClassRef.Name = class.Name
+			premium = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "premium_plan"}}
+			premium.Spec.ExternalName = "premium"
+			premium.Spec.ClusterServiceClassRef.Name = class.Name
+			premium.Spec.ServiceInstanceUpdateParameterSchema = &runtime.RawExtension{Raw: []byte(`{
+				"type": "object",
+				"required": ["size"],
+				"properties": {
+					"size": {"type": "integer"},
+					"tier": {"type": "string", "enum": ["gold", "silver"]}
+				}
+			}`)}
+			inst = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "mysql", Namespace: "foobar_namespace"}}
+			inst.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: class.Name}
+			inst.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: plan.Name}
+			inst.Spec.ClusterServiceClassExternalName = class.Spec.ExternalName
+			inst.Spec.ClusterServicePlanExternalName = plan.Spec.ExternalName
+			svcCatClient = fake.NewSimpleClientset(inst, class, plan, premium)
+			svcCatClient.PrependReactor("list", "clusterserviceplans", filterPlans(plan, premium))
+			sdk.ServiceCatalogClient = svcCatClient
+		})
+
+		It("Changes the plan of the instance", func() {
+			opts := &UpdateInstanceOptions{PlanName: premium.Spec.ExternalName}
+
+			updated, err := sdk.UpdateInstance(inst.Namespace, inst.Name, opts, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Spec.ClusterServicePlanExternalName).To(Equal(premium.Spec.ExternalName))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("get", "serviceinstances")).To(BeTrue())
+			Expect(actions[1].Matches("get", "clusterserviceclasses")).To(BeTrue())
+			Expect(actions[2].Matches("list", "clusterserviceplans")).To(BeTrue())
+			Expect(actions[3].Matches("update", "serviceinstances")).To(BeTrue())
+		})
+		It("Replaces the parameters of the instance", func() {
+			opts := &UpdateInstanceOptions{
+				PlanName: premium.Spec.ExternalName,
+				Params:   map[string]interface{}{"size": "3", "tier": "gold"},
+				Secrets:  map[string]string{"mysecret": "params"},
+			}
+
+			updated, err := sdk.UpdateInstance(inst.Namespace, inst.Name, opts, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(updated.Spec.Parameters.Raw)).To(Equal(`{"size":"3","tier":"gold"}`))
+			Expect(updated.Spec.ParametersFrom).To(HaveLen(1))
+			Expect(updated.Spec.ParametersFrom[0].SecretKeyRef.Name).To(Equal("mysecret"))
+		})
+		It("Rejects plan changes when the class does not allow them", func() {
+			class.Spec.PlanUpdatable = false
+			svcCatClient = fake.NewSimpleClientset(inst, class, plan, premium)
+			svcCatClient.PrependReactor("list", "clusterserviceplans", filterPlans(plan, premium))
+			sdk.ServiceCatalogClient = svcCatClient
+			opts := &UpdateInstanceOptions{PlanName: premium.Spec.ExternalName}
+
+			_, err := sdk.UpdateInstance(inst.Namespace, inst.Name, opts, 3)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not allow changing the plan"))
+			for _, action := range svcCatClient.Actions() {
+				Expect(action.Matches("update", "serviceinstances")).To(BeFalse())
+			}
+		})
+		It("Rejects parameters that do not match the update schema of the plan", func() {
+			opts := &UpdateInstanceOptions{
+				PlanName: premium.Spec.ExternalName,
+				Params:   map[string]interface{}{"tier": "bronze"},
+			}
+
+			_, err := sdk.UpdateInstance(inst.Namespace, inst.Name, opts, 3)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid parameters: size is required; tier must be one of gold, silver"))
+		})
+		It("Bubbles up errors", func() {
+			opts := &UpdateInstanceOptions{PlanName: "unknown"}
+
+			_, err := sdk.UpdateInstance(inst.Namespace, inst.Name, opts, 3)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("plan 'mysql/unknown' not found"))
+		})
+	})
 	Describe("InstanceParentHierarchy", func() {
 		It("calls the v1beta1 generated Get function repeatedly to build the heirarchy of the passed in service isntance", func() {
 			broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker"}}
@@ -281,9 +418,9 @@ var _ = Describe("Instances", func() {
 			sdk.ServiceCatalogClient = linkedClient
 			retClass, retPlan, retBroker, err := sdk.InstanceParentHierarchy(si)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(retClass.Name).To(Equal(class.Name))
-			Expect(retPlan.Name).To(Equal(plan.Name))
-			Expect(retBroker.Name).To(Equal(broker.Name))
+			Expect(retClass.GetName()).To(Equal(class.Name))
+			Expect(retPlan.GetName()).To(Equal(plan.Name))
+			Expect(retBroker.GetName()).To(Equal(broker.Name))
 			actions := linkedClient.Actions()
 			getClass := testing.GetActionImpl{
 				ActionImpl: testing.ActionImpl{
@@ -356,6 +493,41 @@ var _ = Describe("Instances", func() {
 		})
 	})
 	Describe("InstanceToServiceClassAndPlan", func() {
+		It("Gets the namespaced class and plan of an instance", func() {
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar_class",
+					Namespace: "foobar_namespace",
+				},
+			}
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar_plan",
+					Namespace: "foobar_namespace",
+				},
+			}
+			si = &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foobar",
+					Namespace: "foobar_namespace",
+				},
+				Spec: v1beta1.ServiceInstanceSpec{
+					ServicePlanRef: &v1beta1.LocalObjectReference{
+						Name: plan.Name,
+					},
+					ServiceClassRef: &v1beta1.LocalObjectReference{
+						Name: class.Name,
+					},
+				},
+			}
+			linkedClient := fake.NewSimpleClientset(si, class, plan)
+			sdk.ServiceCatalogClient = linkedClient
+
+			retClass, retPlan, err := sdk.InstanceToServiceClassAndPlan(si)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retClass).To(Equal(class))
+			Expect(retPlan).To(Equal(plan))
+		})
 		It("Calls the generated v1beta methods with the names of the class and plan from the passed in instance", func() {
 			class := &v1beta1.ClusterServiceClass{
 				ObjectMeta: metav1.ObjectMeta{
@@ -460,7 +632,7 @@ var _ = Describe("Instances", func() {
 			secrets["username"] = "admin"
 			secrets["password"] = "abc123"
 
-			service, err := sdk.Provision(namespace, instanceName, externalID, className, planName, params, secrets)
+			service, err := sdk.Provision(namespace, instanceName, externalID, className, planName, params, secrets, ClusterScope)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(service.Namespace).To(Equal(namespace))
@@ -492,6 +664,20 @@ var _ = Describe("Instances", func() {
 			Expect(objectFromRequest.Spec.ParametersFrom).Should(ConsistOf(param, param2))
 			Expect(objectFromRequest.Spec.ExternalID).To(Equal(externalID))
 		})
+		It("References a namespaced class and plan", func() {
+			namespace := "cherry_namespace"
+			instanceName := "cherry"
+			className := "cherry_class"
+			planName := "cherry_plan"
+
+			service, err := sdk.Provision(namespace, instanceName, "", className, planName, nil, nil, NamespaceScope)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Spec.PlanReference.ServiceClassExternalName).To(Equal(className))
+			Expect(service.Spec.PlanReference.ServicePlanExternalName).To(Equal(planName))
+			Expect(service.Spec.PlanReference.ClusterServiceClassExternalName).To(BeEmpty())
+			Expect(service.Spec.PlanReference.ClusterServicePlanExternalName).To(BeEmpty())
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error retrieving list"
 			namespace := "cherry_namespace"
@@ -509,7 +695,7 @@ var _ = Describe("Instances", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			service, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets)
+			service, err := sdk.Provision(namespace, instanceName, "", className, planName, params, secrets, ClusterScope)
 			Expect(service).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
@@ -540,3 +726,22 @@ var _ = Describe("Instances", func() {
 		Expect(actions[0].(testing.DeleteActionImpl).Name).To(Equal(si.Name))
 	})
 })
+
+// filterPlans returns a reactor that lists the plans matching the field
+// selector of the request, which the fake clientset otherwise ignores.
+func filterPlans(plans ...*v1beta1.ClusterServicePlan) testing.ReactionFunc {
+	return func(action testing.Action) (bool, runtime.Object, error) {
+		restrictions := action.(testing.ListActionImpl).GetListRestrictions()
+		list := &v1beta1.ClusterServicePlanList{}
+		for _, plan := range plans {
+			planFields := fields.Set{
+				FieldServiceClassRef:  plan.Spec.ClusterServiceClassRef.Name,
+				FieldExternalPlanName: plan.Spec.ExternalName,
+			}
+			if restrictions.Fields.Matches(planFields) {
+				list.Items = append(list.Items, *plan)
+			}
+		}
+		return true, list, nil
+	}
+}
@@ -0,0 +1,421 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// KindInstance is the kind of a service instance in a manifest.
+	KindInstance = "ServiceInstance"
+	// KindBinding is the kind of a service binding in a manifest.
+	KindBinding = "ServiceBinding"
+
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// ApplyResult describes what applying a resource did to the cluster.
+type ApplyResult string
+
+const (
+	// ApplyCreated means that the resource did not exist and was created.
+	ApplyCreated ApplyResult = "created"
+	// ApplyConfigured means that the existing resource was updated.
+	ApplyConfigured ApplyResult = "configured"
+	// ApplyUnchanged means that the existing resource already matched.
+	ApplyUnchanged ApplyResult = "unchanged"
+)
+
+// Manifest is a set of instances and bindings that can be exported from and
+// applied to the cluster.
+type Manifest struct {
+	Instances []v1beta1.ServiceInstance
+	Bindings  []v1beta1.ServiceBinding
+}
+
+// ExportInstance builds a manifest that recreates an instance, and optionally
+// its bindings, stripped of status and of fields set by the server.
+func (sdk *SDK) ExportInstance(ns, name string, withBindings bool) (*Manifest, error) {
+	instance, err := sdk.RetrieveInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Instances: []v1beta1.ServiceInstance{CleanInstance(instance)}}
+	if !withBindings {
+		return m, nil
+	}
+
+	bindings, err := sdk.RetrieveBindingsByInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bindings {
+		m.Bindings = append(m.Bindings, CleanBinding(&bindings[i]))
+	}
+	return m, nil
+}
+
+// CleanInstance returns a copy of an instance holding only the fields that a
+// user would set when creating it.
+func CleanInstance(instance *v1beta1.ServiceInstance) v1beta1.ServiceInstance {
+	return v1beta1.ServiceInstance{
+		TypeMeta:   typeMeta(KindInstance),
+		ObjectMeta: cleanObjectMeta(instance.ObjectMeta),
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference:  instance.Spec.PlanReference,
+			Parameters:     instance.Spec.Parameters,
+			ParametersFrom: instance.Spec.ParametersFrom,
+		},
+	}
+}
+
+// CleanBinding returns a copy of a binding holding only the fields that a
+// user would set when creating it.
+func CleanBinding(binding *v1beta1.ServiceBinding) v1beta1.ServiceBinding {
+	spec := v1beta1.ServiceBindingSpec{
+		ServiceInstanceRef:   binding.Spec.ServiceInstanceRef,
+		Parameters:           binding.Spec.Parameters,
+		ParametersFrom:       binding.Spec.ParametersFrom,
+		SecretName:           binding.Spec.SecretName,
+		SecretTransforms:     binding.Spec.SecretTransforms,
+		SecretTemplate:       binding.Spec.SecretTemplate,
+		SecretAdoptionPolicy: binding.Spec.SecretAdoptionPolicy,
+	}
+	// The secret name defaults to the name of the binding
+	if spec.SecretName == binding.Name {
+		spec.SecretName = ""
+	}
+	return v1beta1.ServiceBinding{
+		TypeMeta:   typeMeta(KindBinding),
+		ObjectMeta: cleanObjectMeta(binding.ObjectMeta),
+		Spec:       spec,
+	}
+}
+
+func typeMeta(kind string) v1.TypeMeta {
+	return v1.TypeMeta{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       kind,
+	}
+}
+
+func cleanObjectMeta(meta v1.ObjectMeta) v1.ObjectMeta {
+	result := v1.ObjectMeta{
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Labels:    meta.Labels,
+	}
+	for k, v := range meta.Annotations {
+		if k == lastAppliedAnnotation {
+			continue
+		}
+		if result.Annotations == nil {
+			result.Annotations = map[string]string{}
+		}
+		result.Annotations[k] = v
+	}
+	return result
+}
+
+// Marshal renders the manifest as a YAML stream, instances first, with one
+// document per resource.
+func (m *Manifest) Marshal() ([]byte, error) {
+	var docs []interface{}
+	for i := range m.Instances {
+		docs = append(docs, &m.Instances[i])
+	}
+	for i := range m.Bindings {
+		docs = append(docs, &m.Bindings[i])
+	}
+
+	var buf bytes.Buffer
+	for i, doc := range docs {
+		obj, err := toManifestObject(doc)
+		if err != nil {
+			return nil, err
+		}
+		y, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(y)
+	}
+	return buf.Bytes(), nil
+}
+
+// toManifestObject converts a resource to a generic object, dropping the
+// status and the fields that are always serialized even when empty.
+func toManifestObject(doc interface{}) (map[string]interface{}, error) {
+	j, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(j, &obj); err != nil {
+		return nil, err
+	}
+
+	delete(obj, "status")
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(meta, "creationTimestamp")
+	}
+	if spec, ok := obj["spec"].(map[string]interface{}); ok {
+		if spec["externalID"] == "" {
+			delete(spec, "externalID")
+		}
+		if n, ok := spec["updateRequests"].(float64); ok && n == 0 {
+			delete(spec, "updateRequests")
+		}
+		if params, ok := spec["parameters"].(map[string]interface{}); ok && len(params) == 0 {
+			delete(spec, "parameters")
+		}
+	}
+	return obj, nil
+}
+
+// ParseManifest reads a YAML or JSON stream of instances and bindings. Resources
+// without a namespace are placed in the default namespace ns.
+func ParseManifest(r io.Reader, ns string) (*Manifest, error) {
+	m := &Manifest{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for i := 1; ; i++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse document %d (%s)", i, err)
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		var meta v1.TypeMeta
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, fmt.Errorf("unable to parse document %d (%s)", i, err)
+		}
+		if meta.APIVersion != v1beta1.SchemeGroupVersion.String() {
+			return nil, fmt.Errorf("document %d has unsupported apiVersion '%s', expected %s",
+				i, meta.APIVersion, v1beta1.SchemeGroupVersion.String())
+		}
+
+		switch meta.Kind {
+		case KindInstance:
+			var instance v1beta1.ServiceInstance
+			if err := json.Unmarshal(raw, &instance); err != nil {
+				return nil, fmt.Errorf("unable to parse document %d (%s)", i, err)
+			}
+			if instance.Namespace == "" {
+				instance.Namespace = ns
+			}
+			m.Instances = append(m.Instances, instance)
+		case KindBinding:
+			var binding v1beta1.ServiceBinding
+			if err := json.Unmarshal(raw, &binding); err != nil {
+				return nil, fmt.Errorf("unable to parse document %d (%s)", i, err)
+			}
+			if binding.Namespace == "" {
+				binding.Namespace = ns
+			}
+			m.Bindings = append(m.Bindings, binding)
+		default:
+			return nil, fmt.Errorf("document %d has unsupported kind '%s', only %s and %s can be applied",
+				i, meta.Kind, KindInstance, KindBinding)
+		}
+	}
+
+	for _, b := range m.Bindings {
+		if b.Name == "" {
+			return nil, fmt.Errorf("%s is missing a name", KindBinding)
+		}
+	}
+	for _, inst := range m.Instances {
+		if inst.Name == "" {
+			return nil, fmt.Errorf("%s is missing a name", KindInstance)
+		}
+	}
+	return m, nil
+}
+
+// ApplyInstance creates an instance, or updates the plan, parameters, labels
+// and annotations of an existing instance to match.
+func (sdk *SDK) ApplyInstance(instance *v1beta1.ServiceInstance, retries int) (*v1beta1.ServiceInstance, ApplyResult, error) {
+	for j := 0; j < retries; j++ {
+		existing, err := sdk.ServiceCatalog().ServiceInstances(instance.Namespace).Get(instance.Name, v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			request := CleanInstance(instance)
+			request.Spec.ExternalID = instance.Spec.ExternalID
+			result, err := sdk.ServiceCatalog().ServiceInstances(instance.Namespace).Create(&request)
+			if err != nil {
+				return nil, "", fmt.Errorf("create request failed (%s)", err)
+			}
+			return result, ApplyCreated, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to get instance '%s/%s' (%s)", instance.Namespace, instance.Name, err)
+		}
+
+		if !mergeInstance(existing, instance) {
+			return existing, ApplyUnchanged, nil
+		}
+
+		result, err := sdk.ServiceCatalog().ServiceInstances(instance.Namespace).Update(existing)
+		if err == nil {
+			return result, ApplyConfigured, nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !apierrors.IsConflict(err) {
+			return nil, "", fmt.Errorf("update request failed (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return nil, "", fmt.Errorf("could not update instance after %d tries", retries)
+}
+
+// mergeInstance copies the user settable fields of desired onto existing and
+// reports whether anything changed.
+func mergeInstance(existing, desired *v1beta1.ServiceInstance) bool {
+	changed := false
+	if !reflect.DeepEqual(existing.Spec.PlanReference, desired.Spec.PlanReference) {
+		existing.Spec.PlanReference = desired.Spec.PlanReference
+		// Let the controller resolve the new references
+		existing.Spec.ClusterServiceClassRef = nil
+		existing.Spec.ClusterServicePlanRef = nil
+		existing.Spec.ServiceClassRef = nil
+		existing.Spec.ServicePlanRef = nil
+		changed = true
+	}
+	if !equalParameters(existing.Spec.Parameters, desired.Spec.Parameters) {
+		existing.Spec.Parameters = desired.Spec.Parameters
+		changed = true
+	}
+	if !equalParametersFrom(existing.Spec.ParametersFrom, desired.Spec.ParametersFrom) {
+		existing.Spec.ParametersFrom = desired.Spec.ParametersFrom
+		changed = true
+	}
+	if mergeMetadata(&existing.ObjectMeta, desired.ObjectMeta) {
+		changed = true
+	}
+	return changed
+}
+
+// mergeMetadata adds the labels and annotations of desired to existing and
+// reports whether anything changed.
+func mergeMetadata(existing *v1.ObjectMeta, desired v1.ObjectMeta) bool {
+	changed := false
+	for k, v := range desired.Labels {
+		if existing.Labels[k] != v {
+			if existing.Labels == nil {
+				existing.Labels = map[string]string{}
+			}
+			existing.Labels[k] = v
+			changed = true
+		}
+	}
+	for k, v := range desired.Annotations {
+		if existing.Annotations[k] != v {
+			if existing.Annotations == nil {
+				existing.Annotations = map[string]string{}
+			}
+			existing.Annotations[k] = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ApplyBinding creates a binding. Bindings cannot be changed once created, so
+// an existing binding is only accepted when it already matches.
+func (sdk *SDK) ApplyBinding(binding *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, ApplyResult, error) {
+	existing, err := sdk.ServiceCatalog().ServiceBindings(binding.Namespace).Get(binding.Name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		request := CleanBinding(binding)
+		request.Spec.ExternalID = binding.Spec.ExternalID
+		result, err := sdk.ServiceCatalog().ServiceBindings(binding.Namespace).Create(&request)
+		if err != nil {
+			return nil, "", fmt.Errorf("create request failed (%s)", err)
+		}
+		return result, ApplyCreated, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get binding '%s/%s' (%s)", binding.Namespace, binding.Name, err)
+	}
+
+	current := CleanBinding(existing)
+	desired := CleanBinding(binding)
+	if !equalBindingSpec(current.Spec, desired.Spec) {
+		return nil, "", fmt.Errorf("binding '%s/%s' already exists with a different spec, bindings cannot be changed, unbind it first",
+			binding.Namespace, binding.Name)
+	}
+	return existing, ApplyUnchanged, nil
+}
+
+func equalBindingSpec(a, b v1beta1.ServiceBindingSpec) bool {
+	return equalParameters(a.Parameters, b.Parameters) &&
+		equalParametersFrom(a.ParametersFrom, b.ParametersFrom) &&
+		a.ServiceInstanceRef == b.ServiceInstanceRef &&
+		a.SecretName == b.SecretName &&
+		reflect.DeepEqual(a.SecretTransforms, b.SecretTransforms) &&
+		reflect.DeepEqual(a.SecretTemplate, b.SecretTemplate) &&
+		a.SecretAdoptionPolicy == b.SecretAdoptionPolicy
+}
+
+// equalParameters compares parameters by value, ignoring formatting.
+func equalParameters(a, b *runtime.RawExtension) bool {
+	var va, vb interface{}
+	if a != nil && len(a.Raw) > 0 {
+		if err := json.Unmarshal(a.Raw, &va); err != nil {
+			return false
+		}
+	}
+	if b != nil && len(b.Raw) > 0 {
+		if err := json.Unmarshal(b.Raw, &vb); err != nil {
+			return false
+		}
+	}
+	if m, ok := va.(map[string]interface{}); ok && len(m) == 0 {
+		va = nil
+	}
+	if m, ok := vb.(map[string]interface{}); ok && len(m) == 0 {
+		vb = nil
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func equalParametersFrom(a, b []v1beta1.ParametersFromSource) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
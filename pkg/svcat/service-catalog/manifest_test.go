@@ -0,0 +1,200 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		si           *v1beta1.ServiceInstance
+		sb           *v1beta1.ServiceBinding
+	)
+
+	BeforeEach(func() {
+		si = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "foobar",
+				Namespace:       "foobar_namespace",
+				UID:             types.UID("abc123"),
+				ResourceVersion: "42",
+				Generation:      3,
+				Finalizers:      []string{"kubernetes-incubator/service-catalog"},
+				Annotations: map[string]string{
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+					"owner": "wordpress",
+				},
+			},
+			Spec: v1beta1.ServiceInstanceSpec{
+				PlanReference: v1beta1.PlanReference{
+					ClusterServiceClassExternalName: "mysql",
+					ClusterServicePlanExternalName:  "small",
+				},
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "mysql-uuid"},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "small-uuid"},
+				Parameters:             &runtime.RawExtension{Raw: []byte(`{"size": 10}`)},
+				ExternalID:             "5a7b1d4e",
+				UserInfo:               &v1beta1.UserInfo{Username: "admin"},
+				UpdateRequests:         2,
+			},
+		}
+		si.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+		}
+		sb = &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar-binding", Namespace: "foobar_namespace"},
+			Spec: v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: v1beta1.LocalObjectReference{Name: "foobar"},
+				SecretName:         "foobar-binding",
+				ExternalID:         "9c3e2f1a",
+			},
+		}
+		svcCatClient = fake.NewSimpleClientset(si, sb)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("ExportInstance", func() {
+		It("Strips status and fields set by the server", func() {
+			m, err := sdk.ExportInstance(si.Namespace, si.Name, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Bindings).To(BeEmpty())
+
+			out, err := m.Marshal()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(out)).To(Equal(`apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceInstance
+metadata:
+  annotations:
+    owner: wordpress
+  name: foobar
+  namespace: foobar_namespace
+spec:
+  clusterServiceClassExternalName: mysql
+  clusterServicePlanExternalName: small
+  parameters:
+    size: 10
+`))
+		})
+		It("Includes the bindings of the instance", func() {
+			m, err := sdk.ExportInstance(si.Namespace, si.Name, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Bindings).To(HaveLen(1))
+			Expect(m.Bindings[0].Spec.SecretName).To(BeEmpty())
+			Expect(m.Bindings[0].Spec.ExternalID).To(BeEmpty())
+		})
+	})
+	Describe("ParseManifest", func() {
+		It("Reads a stream of instances and bindings", func() {
+			m, err := sdk.ExportInstance(si.Namespace, si.Name, true)
+			Expect(err).NotTo(HaveOccurred())
+			out, err := m.Marshal()
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed, err := ParseManifest(strings.NewReader(string(out)), "default")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Instances).To(HaveLen(1))
+			Expect(parsed.Instances[0].Namespace).To(Equal(si.Namespace))
+			Expect(parsed.Bindings).To(HaveLen(1))
+			Expect(parsed.Bindings[0].Spec.ServiceInstanceRef.Name).To(Equal(si.Name))
+		})
+		It("Defaults the namespace", func() {
+			doc := "apiVersion: servicecatalog.k8s.io/v1beta1\nkind: ServiceInstance\nmetadata:\n  name: foo\n"
+			parsed, err := ParseManifest(strings.NewReader(doc), "default")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Instances[0].Namespace).To(Equal("default"))
+		})
+		It("Rejects other kinds", func() {
+			doc := "apiVersion: servicecatalog.k8s.io/v1beta1\nkind: ServiceBroker\nmetadata:\n  name: foo\n"
+			_, err := ParseManifest(strings.NewReader(doc), "default")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unsupported kind 'ServiceBroker'"))
+		})
+	})
+	Describe("ApplyInstance", func() {
+		It("Creates missing instances", func() {
+			desired := CleanInstance(si)
+			desired.Name = "newinstance"
+			result, status, err := sdk.ApplyInstance(&desired, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ApplyCreated))
+			Expect(result.Name).To(Equal("newinstance"))
+			Expect(svcCatClient.Actions()[1].Matches("create", "serviceinstances")).To(BeTrue())
+		})
+		It("Leaves matching instances alone", func() {
+			desired := CleanInstance(si)
+			desired.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"size":10}`)}
+			_, status, err := sdk.ApplyInstance(&desired, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ApplyUnchanged))
+			Expect(svcCatClient.Actions()).To(HaveLen(1))
+		})
+		It("Updates changed instances", func() {
+			desired := CleanInstance(si)
+			desired.Spec.ClusterServicePlanExternalName = "large"
+			result, status, err := sdk.ApplyInstance(&desired, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ApplyConfigured))
+			Expect(result.Spec.ClusterServicePlanExternalName).To(Equal("large"))
+			Expect(result.Spec.ClusterServicePlanRef).To(BeNil())
+			Expect(result.Spec.ExternalID).To(Equal(si.Spec.ExternalID))
+		})
+	})
+	Describe("ApplyBinding", func() {
+		It("Creates missing bindings", func() {
+			desired := CleanBinding(sb)
+			desired.Name = "newbinding"
+			_, status, err := sdk.ApplyBinding(&desired)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ApplyCreated))
+		})
+		It("Accepts matching bindings", func() {
+			desired := CleanBinding(sb)
+			_, status, err := sdk.ApplyBinding(&desired)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(ApplyUnchanged))
+		})
+		It("Rejects changes to bindings", func() {
+			desired := CleanBinding(sb)
+			desired.Spec.SecretName = "other-secret"
+			_, _, err := sdk.ApplyBinding(&desired)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("bindings cannot be changed"))
+		})
+	})
+})
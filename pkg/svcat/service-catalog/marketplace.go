@@ -0,0 +1,166 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"sort"
+	"strings"
+)
+
+// MarketplaceOptions filters the offerings returned by RetrieveMarketplace.
+type MarketplaceOptions struct {
+	// Search matches the names, descriptions and tags of classes and the
+	// names and descriptions of plans, ignoring case.
+	Search string
+
+	// Tags that a class must all have, ignoring case.
+	Tags []string
+
+	// FreeOnly keeps only the free plans.
+	FreeOnly bool
+
+	// Broker keeps only the classes provided by the named broker.
+	Broker string
+}
+
+// Offering is a class with the plans that can be provisioned from it.
+type Offering struct {
+	Class Class  `json:"class"`
+	Plans []Plan `json:"plans"`
+}
+
+// RetrieveMarketplace lists the classes and plans available in the selected
+// scope, grouped by class and sorted by name. Classes and plans that were
+// removed from their broker's catalog are left out.
+func (sdk *SDK) RetrieveMarketplace(filter MarketplaceOptions, opts ScopeOptions) ([]Offering, error) {
+	classes, err := sdk.RetrieveClasses(opts)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := sdk.RetrievePlans(nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plansByClass := make(map[string][]Plan)
+	for _, plan := range plans {
+		if plan.GetStatus().RemovedFromBrokerCatalog {
+			continue
+		}
+		key := plan.GetNamespace() + "/" + plan.GetClassID()
+		plansByClass[key] = append(plansByClass[key], plan)
+	}
+
+	var offerings []Offering
+	for _, class := range classes {
+		if class.GetStatus().RemovedFromBrokerCatalog {
+			continue
+		}
+		offering := Offering{
+			Class: class,
+			Plans: plansByClass[class.GetNamespace()+"/"+class.GetName()],
+		}
+		if offering, ok := filter.apply(offering); ok {
+			offerings = append(offerings, offering)
+		}
+	}
+
+	sort.SliceStable(offerings, func(i, j int) bool {
+		return offerings[i].Class.GetExternalName() < offerings[j].Class.GetExternalName()
+	})
+	for _, offering := range offerings {
+		sort.SliceStable(offering.Plans, func(i, j int) bool {
+			return offering.Plans[i].GetExternalName() < offering.Plans[j].GetExternalName()
+		})
+	}
+	return offerings, nil
+}
+
+// apply filters the plans of an offering and reports whether the offering
+// should be kept.
+func (f MarketplaceOptions) apply(offering Offering) (Offering, bool) {
+	class := offering.Class
+	if f.Broker != "" && class.GetServiceBrokerName() != f.Broker {
+		return offering, false
+	}
+	for _, tag := range f.Tags {
+		if !HasTag(class, tag) {
+			return offering, false
+		}
+	}
+
+	classMatches := f.Search == "" || ClassMatches(class, f.Search)
+	var plans []Plan
+	for _, plan := range offering.Plans {
+		if f.FreeOnly && !plan.GetSpec().Free {
+			continue
+		}
+		if !classMatches && !PlanMatches(plan, f.Search) {
+			continue
+		}
+		plans = append(plans, plan)
+	}
+
+	// A class without plans can only be shown when no plan was filtered out
+	if len(plans) == 0 && (len(offering.Plans) > 0 || !classMatches) {
+		return offering, false
+	}
+	offering.Plans = plans
+	return offering, true
+}
+
+// HasTag reports whether a class has a tag, ignoring case.
+func HasTag(class Class, tag string) bool {
+	for _, t := range class.GetSpec().Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassMatches reports whether the external name, description or tags of a
+// class contain the search text, ignoring case.
+func ClassMatches(class Class, search string) bool {
+	fields := append([]string{class.GetExternalName(), class.GetDescription()}, class.GetSpec().Tags...)
+	return containsFold(fields, search)
+}
+
+// PlanMatches reports whether the external name or description of a plan
+// contain the search text, ignoring case.
+func PlanMatches(plan Plan, search string) bool {
+	return containsFold([]string{plan.GetExternalName(), plan.GetDescription()}, search)
+}
+
+// IsPlanBindable reports whether instances of a plan can be bound, which the
+// plan may override from its class.
+func IsPlanBindable(class Class, plan Plan) bool {
+	if bindable := plan.GetSpec().Bindable; bindable != nil {
+		return *bindable
+	}
+	return class.GetSpec().Bindable
+}
+
+func containsFold(fields []string, search string) bool {
+	search = strings.ToLower(search)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), search) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Marketplace", func() {
+	var (
+		sdk   *SDK
+		mysql *v1beta1.ClusterServiceClass
+		redis *v1beta1.ClusterServiceClass
+		old   *v1beta1.ClusterServiceClass
+		small *v1beta1.ClusterServicePlan
+		large *v1beta1.ClusterServicePlan
+		cache *v1beta1.ClusterServicePlan
+		opts  ScopeOptions
+	)
+
+	class := func(name, broker, description string, tags ...string) *v1beta1.ClusterServiceClass {
+		c := &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: name + "-uuid"}}
+		c.Spec.ExternalName = name
+		c.Spec.Description = description
+		c.Spec.Tags = tags
+		c.Spec.Bindable = true
+		c.Spec.ClusterServiceBrokerName = broker
+		return c
+	}
+	plan := func(name, description string, class *v1beta1.ClusterServiceClass, free bool) *v1beta1.ClusterServicePlan {
+		p := &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: class.Name + "-" + name}}
+		p.Spec.ExternalName = name
+		p.Spec.Description = description
+		p.Spec.Free = free
+		p.Spec.ClusterServiceClassRef = v1beta1.ClusterObjectReference{Name: class.Name}
+		p.Spec.ClusterServiceBrokerName = class.Spec.ClusterServiceBrokerName
+		return p
+	}
+	names := func(offerings []Offering) []string {
+		var results []string
+		for _, o := range offerings {
+			for _, p := range o.Plans {
+				results = append(results, o.Class.GetExternalName()+"/"+p.GetExternalName())
+			}
+		}
+		return results
+	}
+
+	BeforeEach(func() {
+		mysql = class("mysql", "azure", "MySQL database", "database", "SQL")
+		redis = class("redis", "redislabs", "Redis cache", "cache")
+		old = class("legacy", "azure", "Retired service")
+		old.Status.RemovedFromBrokerCatalog = true
+		small = plan("small", "Shared server", mysql, true)
+		large = plan("large", "Dedicated server", mysql, false)
+		notBindable := false
+		large.Spec.Bindable = &notBindable
+		cache = plan("basic", "Small cache", redis, false)
+		sdk = &SDK{
+			ServiceCatalogClient: fake.NewSimpleClientset(mysql, redis, old, small, large, cache),
+		}
+		opts = ScopeOptions{Scope: ClusterScope}
+	})
+
+	Describe("RetrieveMarketplace", func() {
+		It("Groups plans by class and sorts them", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{}, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"mysql/large", "mysql/small", "redis/basic"}))
+		})
+		It("Searches classes and plans", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Search: "DEDICATED"}, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"mysql/large"}))
+
+			offerings, err = sdk.RetrieveMarketplace(MarketplaceOptions{Search: "sql"}, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"mysql/large", "mysql/small"}))
+		})
+		It("Filters by tag", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Tags: []string{"Database", "sql"}}, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"mysql/large", "mysql/small"}))
+
+			offerings, err = sdk.RetrieveMarketplace(MarketplaceOptions{Tags: []string{"database", "cache"}}, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offerings).To(BeEmpty())
+		})
+		It("Filters free plans", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{FreeOnly: true}, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"mysql/small"}))
+		})
+		It("Filters by broker", func() {
+			offerings, err := sdk.RetrieveMarketplace(MarketplaceOptions{Broker: "redislabs"}, opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names(offerings)).To(Equal([]string{"redis/basic"}))
+		})
+	})
+	Describe("IsPlanBindable", func() {
+		It("Lets plans override their class", func() {
+			Expect(IsPlanBindable(mysql, small)).To(BeTrue())
+			Expect(IsPlanBindable(mysql, large)).To(BeFalse())
+		})
+	})
+})
@@ -16,7 +16,51 @@ limitations under the License.
 
 package servicecatalog
 
+import "k8s.io/apimachinery/pkg/api/errors"
+
 // FilterOptions allows for optional filtering fields to be passed to `Retrieve` methods.
 type FilterOptions struct {
 	ClassID string
 }
+
+// Scope is the scope of a resource: cluster-wide or within a namespace.
+type Scope string
+
+const (
+	// ClusterScope selects cluster-scoped resources, e.g. ClusterServiceBroker.
+	ClusterScope Scope = "cluster"
+
+	// NamespaceScope selects namespaced resources, e.g. ServiceBroker.
+	NamespaceScope Scope = "namespace"
+
+	// AllScope selects both cluster-scoped and namespaced resources.
+	AllScope Scope = "all"
+)
+
+// Matches returns true if resources of the given scope are selected by s.
+// An empty scope selects cluster-scoped resources.
+func (s Scope) Matches(value Scope) bool {
+	if s == "" {
+		s = ClusterScope
+	}
+	return s == AllScope || s == value
+}
+
+// skipNotFound returns true if a NotFound error can be ignored because all
+// scopes are searched: the resource may exist in the other scope only, and
+// the namespaced resources are not served at all when the
+// NamespacedServiceBroker feature is disabled.
+func skipNotFound(err error, scope Scope) bool {
+	return scope == AllScope && errors.IsNotFound(err)
+}
+
+// ScopeOptions allows a cluster-scoped or a namespaced resource to be
+// targeted by the SDK methods that support both.
+type ScopeOptions struct {
+	// Scope of the targeted resource.
+	Scope Scope
+
+	// Namespace of the targeted resource when Scope is NamespaceScope, or of
+	// the namespaced resources when Scope is AllScope.
+	Namespace string
+}
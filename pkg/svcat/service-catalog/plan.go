@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -30,93 +31,219 @@ const (
 
 	// FieldServiceClassRef is the jsonpath to a plan's associated class name.
 	FieldServiceClassRef = "spec.clusterServiceClassRef.name"
+
+	// FieldNamespacedServiceClassRef is the jsonpath to a namespaced plan's
+	// associated class name.
+	FieldNamespacedServiceClassRef = "spec.serviceClassRef.name"
 )
 
-// RetrievePlans lists all plans defined in the cluster.
-func (sdk *SDK) RetrievePlans(opts *FilterOptions) ([]v1beta1.ClusterServicePlan, error) {
-	plans, err := sdk.ServiceCatalog().ClusterServicePlans().List(v1.ListOptions{})
+// Plan provides a unified view over ClusterServicePlans and ServicePlans.
+type Plan interface {
+	// GetName returns the plan's name (UUID).
+	GetName() string
+
+	// GetNamespace returns the plan's namespace, or "" if it is
+	// cluster-scoped.
+	GetNamespace() string
+
+	// GetExternalName returns the plan's external name.
+	GetExternalName() string
+
+	// GetDescription returns the plan's description.
+	GetDescription() string
+
+	// GetSpec returns the spec shared by all plans.
+	GetSpec() v1beta1.CommonServicePlanSpec
+
+	// GetStatus returns the status shared by all plans.
+	GetStatus() v1beta1.CommonServicePlanStatus
+
+	// GetServiceBrokerName returns the name of the plan's broker.
+	GetServiceBrokerName() string
+
+	// GetClassID returns the name (UUID) of the plan's class.
+	GetClassID() string
+}
+
+// RetrievePlans lists all plans defined in the selected scope.
+func (sdk *SDK) RetrievePlans(filter *FilterOptions, opts ScopeOptions) ([]Plan, error) {
+	return sdk.RetrievePlansContext(context.Background(), filter, opts)
+}
+
+// RetrievePlansContext is RetrievePlans, failing with the error of the
+// context if it is done before the requests are sent.
+func (sdk *SDK) RetrievePlansContext(ctx context.Context, filter *FilterOptions, opts ScopeOptions) ([]Plan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	plans, err := sdk.listPlans(v1.ListOptions{}, v1.ListOptions{}, opts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list plans (%s)", err)
 	}
 
-	if opts != nil && opts.ClassID != "" {
-		plansFiltered := make([]v1beta1.ClusterServicePlan, 0)
-		for _, p := range plans.Items {
-			if p.Spec.ClusterServiceClassRef.Name == opts.ClassID {
+	if filter != nil && filter.ClassID != "" {
+		plansFiltered := make([]Plan, 0)
+		for _, p := range plans {
+			if p.GetClassID() == filter.ClassID {
 				plansFiltered = append(plansFiltered, p)
 			}
 		}
 		return plansFiltered, nil
 	}
 
-	return plans.Items, nil
+	return plans, nil
 }
 
 // RetrievePlanByName gets a plan by its external name.
-func (sdk *SDK) RetrievePlanByName(name string) (*v1beta1.ClusterServicePlan, error) {
-	opts := v1.ListOptions{
+func (sdk *SDK) RetrievePlanByName(name string, opts ScopeOptions) (Plan, error) {
+	listOpts := v1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector(FieldExternalPlanName, name).String(),
 	}
-	searchResults, err := sdk.ServiceCatalog().ClusterServicePlans().List(opts)
+	searchResults, err := sdk.listPlans(listOpts, listOpts, opts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to search plans by name '%s', (%s)", name, err)
 	}
-	if len(searchResults.Items) == 0 {
-		return nil, fmt.Errorf("plan not found '%s'", name)
+	if len(searchResults) == 0 {
+		return nil, &NotFoundError{Kind: "plan", Name: name}
 	}
-	if len(searchResults.Items) > 1 {
-		return nil, fmt.Errorf("more than one matching plan found for '%s'", name)
+	if len(searchResults) > 1 {
+		return nil, &AmbiguousError{Kind: "plan", Name: name}
 	}
-	return &searchResults.Items[0], nil
+	return searchResults[0], nil
 }
 
 // RetrievePlanByID gets a plan by its UUID.
-func (sdk *SDK) RetrievePlanByID(uuid string) (*v1beta1.ClusterServicePlan, error) {
-	plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(uuid, v1.GetOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("unable to get plan by uuid '%s' (%s)", uuid, err)
+func (sdk *SDK) RetrievePlanByID(uuid string, opts ScopeOptions) (Plan, error) {
+	var found []Plan
+
+	if opts.Scope.Matches(ClusterScope) {
+		plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(uuid, v1.GetOptions{})
+		if err == nil {
+			found = append(found, plan)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("plan", uuid, err)
+		}
 	}
-	return plan, nil
+	if opts.Scope.Matches(NamespaceScope) {
+		plan, err := sdk.ServiceCatalog().ServicePlans(opts.Namespace).Get(uuid, v1.GetOptions{})
+		if err == nil {
+			found = append(found, plan)
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, getError("plan", uuid, err)
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, &NotFoundError{Kind: "plan", Name: uuid}
+	}
+	if len(found) > 1 {
+		return nil, &AmbiguousError{Kind: "plan", Name: uuid}
+	}
+	return found[0], nil
 }
 
 // RetrievePlansByClass retrieves all plans for a class.
-func (sdk *SDK) RetrievePlansByClass(class *v1beta1.ClusterServiceClass,
-) ([]v1beta1.ClusterServicePlan, error) {
+func (sdk *SDK) RetrievePlansByClass(class Class) ([]Plan, error) {
+	var plans []Plan
+	if class.GetNamespace() == "" {
+		planOpts := v1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(FieldServiceClassRef, class.GetName()).String(),
+		}
+		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(planOpts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list plans (%s)", err)
+		}
+		for i := range csp.Items {
+			plans = append(plans, &csp.Items[i])
+		}
+		return plans, nil
+	}
+
 	planOpts := v1.ListOptions{
-		FieldSelector: fields.OneTermEqualSelector(FieldServiceClassRef, class.Name).String(),
+		FieldSelector: fields.OneTermEqualSelector(FieldNamespacedServiceClassRef, class.GetName()).String(),
 	}
-	plans, err := sdk.ServiceCatalog().ClusterServicePlans().List(planOpts)
+	sp, err := sdk.ServiceCatalog().ServicePlans(class.GetNamespace()).List(planOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list plans (%s)", err)
 	}
-
-	return plans.Items, nil
+	for i := range sp.Items {
+		plans = append(plans, &sp.Items[i])
+	}
+	return plans, nil
 }
 
 // RetrievePlanByClassAndPlanNames gets a plan by its class/plan name combination.
-func (sdk *SDK) RetrievePlanByClassAndPlanNames(className, planName string,
-) (*v1beta1.ClusterServicePlan, error) {
-	class, err := sdk.RetrieveClassByName(className)
+func (sdk *SDK) RetrievePlanByClassAndPlanNames(className, planName string, opts ScopeOptions) (Plan, error) {
+	class, err := sdk.RetrieveClassByName(className, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	planOpts := v1.ListOptions{
-		FieldSelector: fields.AndSelectors(
-			fields.OneTermEqualSelector(FieldServiceClassRef, class.Name),
-			fields.OneTermEqualSelector(FieldExternalPlanName, planName),
-		).String(),
+	return sdk.retrievePlanOfClass(class, planName)
+}
+
+// retrievePlanOfClass gets a plan of a class by its external name.
+func (sdk *SDK) retrievePlanOfClass(class Class, planName string) (Plan, error) {
+	className := class.GetExternalName()
+	var searchResults []Plan
+	var err error
+	if class.GetNamespace() == "" {
+		planOpts := v1.ListOptions{
+			FieldSelector: fields.AndSelectors(
+				fields.OneTermEqualSelector(FieldServiceClassRef, class.GetName()),
+				fields.OneTermEqualSelector(FieldExternalPlanName, planName),
+			).String(),
+		}
+		searchResults, err = sdk.listPlans(planOpts, v1.ListOptions{}, ScopeOptions{Scope: ClusterScope})
+	} else {
+		planOpts := v1.ListOptions{
+			FieldSelector: fields.AndSelectors(
+				fields.OneTermEqualSelector(FieldNamespacedServiceClassRef, class.GetName()),
+				fields.OneTermEqualSelector(FieldExternalPlanName, planName),
+			).String(),
+		}
+		searchResults, err = sdk.listPlans(v1.ListOptions{}, planOpts,
+			ScopeOptions{Scope: NamespaceScope, Namespace: class.GetNamespace()})
 	}
-	searchResults, err := sdk.ServiceCatalog().ClusterServicePlans().List(planOpts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to search plans by class/plan name '%s/%s' (%s)", className, planName, err)
 	}
-	if len(searchResults.Items) == 0 {
-		return nil, fmt.Errorf("plan not found '%s/%s'", className, planName)
+	if len(searchResults) == 0 {
+		return nil, &NotFoundError{Kind: "plan", Name: className + "/" + planName}
 	}
-	if len(searchResults.Items) > 1 {
+	if len(searchResults) > 1 {
 		// Note: Should never occur, as class/plan name combo must be unique
-		return nil, fmt.Errorf("more than one matching plan found for '%s/%s'", className, planName)
+		return nil, &AmbiguousError{Kind: "plan", Name: className + "/" + planName}
 	}
-	return &searchResults.Items[0], nil
+	return searchResults[0], nil
+}
+
+// listPlans lists the plans of the selected scope, using clusterOpts for
+// ClusterServicePlans and namespacedOpts for ServicePlans.
+func (sdk *SDK) listPlans(clusterOpts, namespacedOpts v1.ListOptions, opts ScopeOptions) ([]Plan, error) {
+	var plans []Plan
+
+	if opts.Scope.Matches(ClusterScope) {
+		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(clusterOpts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range csp.Items {
+			plans = append(plans, &csp.Items[i])
+		}
+	}
+
+	if opts.Scope.Matches(NamespaceScope) {
+		sp, err := sdk.ServiceCatalog().ServicePlans(opts.Namespace).List(namespacedOpts)
+		if err == nil {
+			for i := range sp.Items {
+				plans = append(plans, &sp.Items[i])
+			}
+		} else if !skipNotFound(err, opts.Scope) {
+			return nil, err
+		}
+	}
+
+	return plans, nil
 }
@@ -38,12 +38,14 @@ var _ = Describe("Plan", func() {
 		svcCatClient *fake.Clientset
 		sp           *v1beta1.ClusterServicePlan
 		sp2          *v1beta1.ClusterServicePlan
+		nsp          *v1beta1.ServicePlan
 	)
 
 	BeforeEach(func() {
 		sp = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
 		sp2 = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "barbaz"}}
-		svcCatClient = fake.NewSimpleClientset(sp, sp2)
+		nsp = &v1beta1.ServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+		svcCatClient = fake.NewSimpleClientset(sp, sp2, nsp)
 		sdk = &SDK{
 			ServiceCatalogClient: svcCatClient,
 		}
@@ -51,12 +53,22 @@ var _ = Describe("Plan", func() {
 
 	Describe("RetrivePlans", func() {
 		It("Calls the generated v1beta1 List method", func() {
-			plans, err := sdk.RetrievePlans(nil)
+			plans, err := sdk.RetrievePlans(nil, ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(plans).Should(ConsistOf(*sp, *sp2))
+			Expect(plans).Should(ConsistOf(sp, sp2))
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceplans")).To(BeTrue())
 		})
+		It("Lists the plans of all scopes", func() {
+			plans, err := sdk.RetrievePlans(nil, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).Should(ConsistOf(sp, sp2, nsp))
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "clusterserviceplans")).To(BeTrue())
+			Expect(actions[1].Matches("list", "serviceplans")).To(BeTrue())
+			Expect(actions[1].GetNamespace()).To(Equal("default"))
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error retrieving list"
 			badClient := &fake.Clientset{}
@@ -64,7 +76,7 @@ var _ = Describe("Plan", func() {
 				return true, nil, fmt.Errorf(errorMessage)
 			})
 			sdk.ServiceCatalogClient = badClient
-			_, err := sdk.RetrievePlans(nil)
+			_, err := sdk.RetrievePlans(nil, ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
@@ -80,10 +92,10 @@ var _ = Describe("Plan", func() {
 			})
 			sdk.ServiceCatalogClient = singleClient
 
-			plan, err := sdk.RetrievePlanByName(planName)
+			plan, err := sdk.RetrievePlanByName(planName, ScopeOptions{Scope: ClusterScope})
 
 			Expect(err).NotTo(HaveOccurred())
-			Expect(plan.Name).To(Equal(planName))
+			Expect(plan.GetName()).To(Equal(planName))
 			actions := singleClient.Actions()
 			Expect(len(actions)).To(Equal(1))
 			Expect(actions[0].Matches("list", "clusterserviceplans")).To(BeTrue())
@@ -99,7 +111,7 @@ var _ = Describe("Plan", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			plan, err := sdk.RetrievePlanByName(planName)
+			plan, err := sdk.RetrievePlanByName(planName, ScopeOptions{Scope: ClusterScope})
 
 			Expect(plan).To(BeNil())
 			Expect(err).To(HaveOccurred())
@@ -114,7 +126,7 @@ var _ = Describe("Plan", func() {
 	Describe("RetrievePlanByID", func() {
 		It("Calls the generated v1beta1 get method with the passed in uuid", func() {
 			planID := sp.Name
-			_, err := sdk.RetrievePlanByID(planID)
+			_, err := sdk.RetrievePlanByID(planID, ScopeOptions{Scope: ClusterScope})
 			Expect(err).NotTo(HaveOccurred())
 			actions := svcCatClient.Actions()
 			Expect(len(actions)).To(Equal(1))
@@ -130,7 +142,7 @@ var _ = Describe("Plan", func() {
 			})
 			sdk.ServiceCatalogClient = badClient
 
-			plan, err := sdk.RetrievePlanByID(planID)
+			plan, err := sdk.RetrievePlanByID(planID, ScopeOptions{Scope: ClusterScope})
 
 			Expect(plan).To(BeNil())
 			Expect(err).To(HaveOccurred())
@@ -165,7 +177,7 @@ var _ = Describe("Plan", func() {
 			})
 			sdk.ServiceCatalogClient = linkedClient
 			retPlans, err := sdk.RetrievePlansByClass(class)
-			Expect(retPlans).To(ConsistOf(*plan))
+			Expect(retPlans).To(ConsistOf(plan))
 			Expect(err).NotTo(HaveOccurred())
 			actions := linkedClient.Actions()
 			Expect(len(actions)).To(Equal(1))
@@ -173,6 +185,39 @@ var _ = Describe("Plan", func() {
 			opts := fields.Set{"spec.clusterServiceClassRef.name": class.Name}
 			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
 		})
+		It("Lists the namespaced plans of a namespaced class", func() {
+			class := &v1beta1.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "durian_class",
+					Namespace: "default",
+				},
+			}
+			plan := &v1beta1.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "durian",
+					Namespace: "default",
+				},
+				Spec: v1beta1.ServicePlanSpec{
+					ServiceClassRef: v1beta1.LocalObjectReference{
+						Name: class.Name,
+					},
+				},
+			}
+			linkedClient := fake.NewSimpleClientset(class, plan)
+			linkedClient.PrependReactor("list", "serviceplans", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServicePlanList{Items: []v1beta1.ServicePlan{*plan}}, nil
+			})
+			sdk.ServiceCatalogClient = linkedClient
+			retPlans, err := sdk.RetrievePlansByClass(class)
+			Expect(retPlans).To(ConsistOf(plan))
+			Expect(err).NotTo(HaveOccurred())
+			actions := linkedClient.Actions()
+			Expect(len(actions)).To(Equal(1))
+			Expect(actions[0].Matches("list", "serviceplans")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal(class.Namespace))
+			opts := fields.Set{"spec.serviceClassRef.name": class.Name}
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.Matches(opts)).To(BeTrue())
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "no plans found"
 			class := &v1beta1.ClusterServiceClass{
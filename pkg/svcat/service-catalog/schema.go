@@ -0,0 +1,281 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ParameterSchema is the subset of a JSON schema, as published by a broker
+// for a plan's parameters, that svcat understands.
+type ParameterSchema struct {
+	Type        string                      `json:"type,omitempty"`
+	Title       string                      `json:"title,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Default     interface{}                 `json:"default,omitempty"`
+	Enum        []interface{}               `json:"enum,omitempty"`
+	Required    []string                    `json:"required,omitempty"`
+	Properties  map[string]*ParameterSchema `json:"properties,omitempty"`
+	Items       *ParameterSchema            `json:"items,omitempty"`
+}
+
+// ParseParameterSchema decodes a parameter schema from a plan. A nil schema
+// is returned when the plan does not define one.
+func ParseParameterSchema(raw *runtime.RawExtension) (*ParameterSchema, error) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil, nil
+	}
+
+	schema := &ParameterSchema{}
+	if err := json.Unmarshal(raw.Raw, schema); err != nil {
+		return nil, fmt.Errorf("unable to parse the parameter schema (%s)", err)
+	}
+	return schema, nil
+}
+
+// Validate checks that the parameters match the schema's property types,
+// enums and required properties. Values passed as strings, e.g. with
+// --param, are accepted for number, integer and boolean properties when they
+// can be converted. Set checkRequired to false when some parameters are
+// provided by secrets that can't be inspected.
+func (s *ParameterSchema) Validate(params map[string]interface{}, checkRequired bool) error {
+	if s == nil {
+		return nil
+	}
+
+	problems := s.validateObject("", params, checkRequired)
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid parameters: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (s *ParameterSchema) validateObject(path string, params map[string]interface{}, checkRequired bool) []string {
+	var problems []string
+	if checkRequired {
+		for _, name := range s.Required {
+			if _, ok := params[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s is required", joinPath(path, name)))
+			}
+		}
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		problems = append(problems, prop.validateValue(joinPath(path, name), params[name], checkRequired)...)
+	}
+	return problems
+}
+
+func (s *ParameterSchema) validateValue(path string, value interface{}, checkRequired bool) []string {
+	if _, ok := value.([]string); ok && s.Type != "array" {
+		// A parameter repeated on the command line
+		return []string{fmt.Sprintf("%s must be a single %s", path, s.typeName())}
+	}
+
+	switch s.Type {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return []string{fmt.Sprintf("%s must be a string", path)}
+		}
+	case "number":
+		if !isNumber(value, false) {
+			return []string{fmt.Sprintf("%s must be a number", path)}
+		}
+	case "integer":
+		if !isNumber(value, true) {
+			return []string{fmt.Sprintf("%s must be an integer", path)}
+		}
+	case "boolean":
+		if !isBoolean(value) {
+			return []string{fmt.Sprintf("%s must be a boolean", path)}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s must be an object", path)}
+		}
+		return s.validateObject(path, obj, checkRequired)
+	case "array":
+		var items []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			items = v
+		case []string:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		case string:
+			items = []interface{}{v}
+		default:
+			return []string{fmt.Sprintf("%s must be an array", path)}
+		}
+		if s.Items == nil {
+			return nil
+		}
+		var problems []string
+		for i, item := range items {
+			problems = append(problems, s.Items.validateValue(fmt.Sprintf("%s[%d]", path, i), item, checkRequired)...)
+		}
+		return problems
+	}
+
+	if len(s.Enum) > 0 && !s.allows(value) {
+		return []string{fmt.Sprintf("%s must be one of %s", path, s.formatEnum())}
+	}
+	return nil
+}
+
+// allows reports if the value is one of the schema's enum values, comparing
+// their string representations so that values passed as strings match.
+func (s *ParameterSchema) allows(value interface{}) bool {
+	for _, allowed := range s.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ParameterSchema) formatEnum() string {
+	values := make([]string, len(s.Enum))
+	for i, v := range s.Enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return strings.Join(values, ", ")
+}
+
+func (s *ParameterSchema) typeName() string {
+	if s.Type == "" {
+		return "value"
+	}
+	return s.Type
+}
+
+func isNumber(value interface{}, integer bool) bool {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case int, int32, int64:
+		return true
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return false
+		}
+		f = parsed
+	default:
+		return false
+	}
+	return !integer || f == float64(int64(f))
+}
+
+func isBoolean(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return true
+	case string:
+		_, err := strconv.ParseBool(v)
+		return err == nil
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// Example builds a skeleton of the parameters described by the schema, that
+// users can edit and pass with --params-json. Each property is set to its
+// default, its first allowed value or the zero value of its type.
+func (s *ParameterSchema) Example() interface{} {
+	if s == nil {
+		return nil
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+
+	switch s.Type {
+	case "object":
+		example := map[string]interface{}{}
+		for name, prop := range s.Properties {
+			example[name] = prop.Example()
+		}
+		return example
+	case "array":
+		if s.Items == nil {
+			return []interface{}{}
+		}
+		return []interface{}{s.Items.Example()}
+	case "string":
+		return ""
+	case "number", "integer":
+		return 0
+	case "boolean":
+		return false
+	}
+	if len(s.Properties) > 0 {
+		return (&ParameterSchema{Type: "object", Properties: s.Properties}).Example()
+	}
+	return nil
+}
+
+// IsRequired returns if the schema requires the named property.
+func (s *ParameterSchema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateValue checks a single value against the schema, using name to
+// identify the value in the error.
+func (s *ParameterSchema) ValidateValue(name string, value interface{}) error {
+	if s == nil {
+		return nil
+	}
+
+	problems := s.validateValue(name, value, true)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
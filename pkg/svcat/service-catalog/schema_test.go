@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParameterSchema", func() {
+	var schema *ParameterSchema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = ParseParameterSchema(&runtime.RawExtension{Raw: []byte(`{
+			"type": "object",
+			"required": ["location"],
+			"properties": {
+				"location": {"type": "string", "description": "Azure region"},
+				"nodes": {"type": "integer", "default": 1},
+				"encrypt": {"type": "boolean"},
+				"tags": {"type": "array", "items": {"type": "string"}},
+				"firewall": {
+					"type": "object",
+					"required": ["name"],
+					"properties": {"name": {"type": "string"}}
+				}
+			}
+		}`)})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ParseParameterSchema", func() {
+		It("Returns nil when there isn't a schema", func() {
+			Expect(ParseParameterSchema(nil)).To(BeNil())
+		})
+		It("Decodes the schema properties", func() {
+			Expect(schema.Required).To(Equal([]string{"location"}))
+			Expect(schema.Properties).To(HaveLen(5))
+			Expect(schema.Properties["location"].Description).To(Equal("Azure region"))
+			Expect(schema.Properties["nodes"].Default).To(BeEquivalentTo(1))
+		})
+		It("Bubbles up errors", func() {
+			_, err := ParseParameterSchema(&runtime.RawExtension{Raw: []byte("{")})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("Accepts string values that can be converted", func() {
+			params := map[string]interface{}{
+				"location": "eastus",
+				"nodes":    "3",
+				"encrypt":  "true",
+				"tags":     []string{"a", "b"},
+			}
+			Expect(schema.Validate(params, true)).To(Succeed())
+		})
+		It("Accepts JSON values", func() {
+			params := map[string]interface{}{
+				"location": "eastus",
+				"nodes":    float64(3),
+				"firewall": map[string]interface{}{"name": "AllowSome"},
+			}
+			Expect(schema.Validate(params, true)).To(Succeed())
+		})
+		It("Reports every problem", func() {
+			params := map[string]interface{}{
+				"nodes":    "1.5",
+				"encrypt":  "maybe",
+				"firewall": map[string]interface{}{},
+			}
+			err := schema.Validate(params, true)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid parameters: location is required; " +
+				"encrypt must be a boolean; firewall.name is required; nodes must be an integer"))
+		})
+		It("Skips required properties when asked", func() {
+			Expect(schema.Validate(map[string]interface{}{}, false)).To(Succeed())
+		})
+	})
+
+	Describe("Example", func() {
+		It("Uses defaults, allowed values and zero values", func() {
+			schema.Properties["location"].Enum = []interface{}{"eastus", "westus"}
+
+			Expect(schema.Example()).To(Equal(map[string]interface{}{
+				"location": "eastus",
+				"nodes":    float64(1),
+				"encrypt":  false,
+				"tags":     []interface{}{""},
+				"firewall": map[string]interface{}{"name": ""},
+			}))
+		})
+	})
+	Describe("IsRequired", func() {
+		It("Checks the required properties", func() {
+			Expect(schema.IsRequired("location")).To(BeTrue())
+			Expect(schema.IsRequired("nodes")).To(BeFalse())
+		})
+	})
+})
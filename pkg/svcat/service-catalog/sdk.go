@@ -24,6 +24,11 @@ import (
 )
 
 // SDK wrapper around the generated Go client for the Kubernetes Service Catalog
+//
+// The generated client does not take a context, so the *Context methods can
+// only check their context before they send a request, and the waits stop
+// watching once it is done. A request that was already sent is not cancelled;
+// it runs until the API server answers or the client's timeout expires.
 type SDK struct {
 	K8sClient            kubernetes.Interface
 	ServiceCatalogClient svcatclient.Interface
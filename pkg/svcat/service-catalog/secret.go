@@ -18,6 +18,9 @@ package servicecatalog
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -41,3 +44,53 @@ func (sdk *SDK) RetrieveSecretByBinding(binding *v1beta1.ServiceBinding) (*corev
 
 	return secret, nil
 }
+
+// RetrieveCredentials gets the decoded credentials that the broker returned
+// for a binding, keyed by the keys of the binding's secret.
+func (sdk *SDK) RetrieveCredentials(ns, bindingName string) (map[string][]byte, error) {
+	binding, err := sdk.RetrieveBinding(ns, bindingName)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := sdk.RetrieveSecretByBinding(binding)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("binding '%s/%s' is not ready, its secret %s has not been created yet",
+			binding.Namespace, binding.Name, binding.Spec.SecretName)
+	}
+	return secret.Data, nil
+}
+
+// CredentialEnvName converts the key of a credential into the name of an
+// environment variable, for example db-password becomes DB_PASSWORD.
+func CredentialEnvName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// CredentialsEnv returns credentials as environment variables in the
+// NAME=value form used by os/exec, sorted by key.
+func CredentialsEnv(creds map[string][]byte) []string {
+	keys := make([]string, 0, len(creds))
+	for key := range creds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	env := make([]string, 0, len(creds))
+	for _, key := range keys {
+		env = append(env, CredentialEnvName(key)+"="+string(creds[key]))
+	}
+	return env
+}
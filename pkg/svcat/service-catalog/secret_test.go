@@ -69,7 +69,10 @@ var _ = Describe("Secret", func() {
 				SecretName: "missing-secret",
 			},
 		}
-		boundSecret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "foobar_namespace"}}
+		boundSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mysecret", Namespace: "foobar_namespace"},
+			Data:       map[string][]byte{"password": []byte("s3cr3t")},
+		}
 		svcCatClient = fake.NewSimpleClientset(readyBinding, unreadyBinding)
 		k8sClient = k8sfake.NewSimpleClientset(boundSecret)
 		sdk = &SDK{
@@ -112,4 +115,30 @@ var _ = Describe("Secret", func() {
 		})
 	})
 
+	Describe("RetrieveCredentials", func() {
+		It("Gets the decoded credentials of a binding", func() {
+			creds, err := sdk.RetrieveCredentials(readyBinding.Namespace, readyBinding.Name)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(creds).To(Equal(map[string][]byte{"password": []byte("s3cr3t")}))
+		})
+		It("Fails when the binding is not ready", func() {
+			_, err := sdk.RetrieveCredentials(unreadyBinding.Namespace, unreadyBinding.Name)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("binding 'foobar_namespace/barbaz' is not ready"))
+		})
+	})
+
+	Describe("CredentialEnvName", func() {
+		It("Converts keys into environment variable names", func() {
+			Expect(CredentialEnvName("db-password")).To(Equal("DB_PASSWORD"))
+			Expect(CredentialEnvName("uri.host")).To(Equal("URI_HOST"))
+			Expect(CredentialEnvName("1st")).To(Equal("_1ST"))
+		})
+		It("Builds the environment of a command", func() {
+			env := CredentialsEnv(map[string][]byte{"uri": []byte("mysql://db"), "db-password": []byte("s3cr3t")})
+			Expect(env).To(Equal([]string{"DB_PASSWORD=s3cr3t", "URI=mysql://db"}))
+		})
+	})
 })
@@ -0,0 +1,244 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	settingsv1alpha1 "k8s.io/api/settings/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podPresetAnnotationPrefix is the prefix of the annotation that the PodPreset
+// admission controller adds to the pods that it modified.
+const podPresetAnnotationPrefix = "podpreset.admission.kubernetes.io/podpreset-"
+
+// Tree is a node in the hierarchy of Service Catalog resources, from brokers
+// down to the pods that consume the secrets of bindings.
+type Tree struct {
+	// Object is a Broker, Class, Plan, *v1beta1.ServiceInstance,
+	// *v1beta1.ServiceBinding, *corev1.Secret or *corev1.Pod.
+	Object   interface{}
+	Children []*Tree
+}
+
+// treeBuilder caches the pods and pod presets of each namespace while a tree
+// is built, since they are needed for every secret in the namespace.
+type treeBuilder struct {
+	sdk        *SDK
+	pods       map[string][]corev1.Pod
+	podPresets map[string][]settingsv1alpha1.PodPreset
+}
+
+func (sdk *SDK) newTreeBuilder() *treeBuilder {
+	return &treeBuilder{
+		sdk:        sdk,
+		pods:       make(map[string][]corev1.Pod),
+		podPresets: make(map[string][]settingsv1alpha1.PodPreset),
+	}
+}
+
+// BrokerTree retrieves a broker with its classes, plans, instances, bindings,
+// secrets and consuming pods.
+func (sdk *SDK) BrokerTree(name string, opts ScopeOptions) (*Tree, error) {
+	broker, err := sdk.RetrieveBroker(name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	classOpts := ScopeOptions{Scope: ClusterScope}
+	if broker.GetNamespace() != "" {
+		classOpts = ScopeOptions{Scope: NamespaceScope, Namespace: broker.GetNamespace()}
+	}
+	classes, err := sdk.RetrieveClasses(classOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	b := sdk.newTreeBuilder()
+	root := &Tree{Object: broker}
+	for _, class := range classes {
+		if class.GetServiceBrokerName() != broker.GetName() {
+			continue
+		}
+		classNode := &Tree{Object: class}
+		plans, err := sdk.RetrievePlansByClass(class)
+		if err != nil {
+			return nil, err
+		}
+		for _, plan := range plans {
+			planNode := &Tree{Object: plan}
+			instances, err := sdk.RetrieveInstancesByPlan(plan)
+			if err != nil {
+				return nil, err
+			}
+			for i := range instances {
+				instanceNode, err := b.instanceTree(&instances[i])
+				if err != nil {
+					return nil, err
+				}
+				planNode.Children = append(planNode.Children, instanceNode)
+			}
+			classNode.Children = append(classNode.Children, planNode)
+		}
+		root.Children = append(root.Children, classNode)
+	}
+	return root, nil
+}
+
+// InstanceTree retrieves the broker, class and plan of an instance along with
+// its bindings, their secrets and the pods consuming them.
+func (sdk *SDK) InstanceTree(ns, name string) (*Tree, error) {
+	instance, err := sdk.RetrieveInstance(ns, name)
+	if err != nil {
+		return nil, err
+	}
+
+	class, plan, broker, err := sdk.InstanceParentHierarchy(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceNode, err := sdk.newTreeBuilder().instanceTree(instance)
+	if err != nil {
+		return nil, err
+	}
+	planNode := &Tree{Object: plan, Children: []*Tree{instanceNode}}
+	classNode := &Tree{Object: class, Children: []*Tree{planNode}}
+	return &Tree{Object: broker, Children: []*Tree{classNode}}, nil
+}
+
+func (b *treeBuilder) instanceTree(instance *v1beta1.ServiceInstance) (*Tree, error) {
+	root := &Tree{Object: instance}
+	bindings, err := b.sdk.RetrieveBindingsByInstance(instance)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bindings {
+		binding := &bindings[i]
+		bindingNode := &Tree{Object: binding}
+		secret, err := b.sdk.RetrieveSecretByBinding(binding)
+		if err != nil {
+			return nil, err
+		}
+		if secret != nil {
+			secretNode := &Tree{Object: secret}
+			pods, err := b.podsBySecret(secret)
+			if err != nil {
+				return nil, err
+			}
+			for i := range pods {
+				secretNode.Children = append(secretNode.Children, &Tree{Object: &pods[i]})
+			}
+			bindingNode.Children = append(bindingNode.Children, secretNode)
+		}
+		root.Children = append(root.Children, bindingNode)
+	}
+	return root, nil
+}
+
+// podsBySecret finds the pods that reference a secret in their environment or
+// volumes, directly or through a PodPreset.
+func (b *treeBuilder) podsBySecret(secret *corev1.Secret) ([]corev1.Pod, error) {
+	ns := secret.Namespace
+	pods, ok := b.pods[ns]
+	if !ok {
+		results, err := b.sdk.Core().Pods(ns).List(v1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list pods in %s (%s)", ns, err)
+		}
+		pods = results.Items
+		b.pods[ns] = pods
+	}
+
+	presets, ok := b.podPresets[ns]
+	if !ok {
+		// PodPresets are an alpha API that is often disabled, in which case
+		// only direct references are found.
+		results, err := b.sdk.K8sClient.SettingsV1alpha1().PodPresets(ns).List(v1.ListOptions{})
+		if err == nil {
+			presets = results.Items
+		}
+		b.podPresets[ns] = presets
+	}
+
+	presetsUsingSecret := make(map[string]bool)
+	for _, preset := range presets {
+		if referencesSecret(secret.Name, preset.Spec.Env, preset.Spec.EnvFrom, preset.Spec.Volumes) {
+			presetsUsingSecret[preset.Name] = true
+		}
+	}
+
+	var results []corev1.Pod
+	for _, pod := range pods {
+		if podReferencesSecret(&pod, secret.Name) || podUsesPreset(&pod, presetsUsingSecret) {
+			results = append(results, pod)
+		}
+	}
+	return results, nil
+}
+
+func podReferencesSecret(pod *corev1.Pod, secretName string) bool {
+	if referencesSecret(secretName, nil, nil, pod.Spec.Volumes) {
+		return true
+	}
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		if referencesSecret(secretName, c.Env, c.EnvFrom, nil) {
+			return true
+		}
+	}
+	return false
+}
+
+func podUsesPreset(pod *corev1.Pod, presets map[string]bool) bool {
+	for k := range pod.Annotations {
+		if strings.HasPrefix(k, podPresetAnnotationPrefix) && presets[strings.TrimPrefix(k, podPresetAnnotationPrefix)] {
+			return true
+		}
+	}
+	return false
+}
+
+func referencesSecret(secretName string, env []corev1.EnvVar, envFrom []corev1.EnvFromSource, volumes []corev1.Volume) bool {
+	for _, e := range env {
+		if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name == secretName {
+			return true
+		}
+	}
+	for _, e := range envFrom {
+		if e.SecretRef != nil && e.SecretRef.Name == secretName {
+			return true
+		}
+	}
+	for _, v := range volumes {
+		if v.Secret != nil && v.Secret.SecretName == secretName {
+			return true
+		}
+		if v.Projected != nil {
+			for _, s := range v.Projected.Sources {
+				if s.Secret != nil && s.Secret.Name == secretName {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	settingsv1alpha1 "k8s.io/api/settings/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tree", func() {
+	var (
+		sdk *SDK
+		sc  *v1beta1.ClusterServiceClass
+		sp  *v1beta1.ClusterServicePlan
+		si  *v1beta1.ServiceInstance
+		sbd *v1beta1.ServiceBinding
+	)
+
+	pod := func(name string, annotations map[string]string, spec corev1.PodSpec) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foobar_namespace", Annotations: annotations},
+			Spec:       spec,
+		}
+	}
+
+	BeforeEach(func() {
+		broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar-broker"}}
+		sc = &v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar-class"}}
+		sc.Spec.ClusterServiceBrokerName = broker.Name
+		sp = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar-plan"}}
+		sp.Spec.ClusterServiceBrokerName = broker.Name
+		sp.Spec.ClusterServiceClassRef = v1beta1.ClusterObjectReference{Name: sc.Name}
+		si = &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "foobar_namespace"},
+			Spec: v1beta1.ServiceInstanceSpec{
+				ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: sc.Name},
+				ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: sp.Name},
+			},
+		}
+		sbd = &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar-binding", Namespace: "foobar_namespace"},
+			Spec: v1beta1.ServiceBindingSpec{
+				ServiceInstanceRef: v1beta1.LocalObjectReference{Name: si.Name},
+				SecretName:         "foobar-secret",
+			},
+		}
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foobar-secret", Namespace: "foobar_namespace"}}
+		preset := &settingsv1alpha1.PodPreset{
+			ObjectMeta: metav1.ObjectMeta{Name: "inject-foobar", Namespace: "foobar_namespace"},
+			Spec: settingsv1alpha1.PodPresetSpec{
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "foobar-secret"}}},
+				},
+			},
+		}
+		envPod := pod("env", nil, corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Env: []corev1.EnvVar{{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "foobar-secret"},
+					Key:                  "password",
+				},
+			}}},
+		}}})
+		volumePod := pod("volume", nil, corev1.PodSpec{Volumes: []corev1.Volume{{
+			Name:         "creds",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "foobar-secret"}},
+		}}})
+		presetPod := pod("preset", map[string]string{"podpreset.admission.kubernetes.io/podpreset-inject-foobar": "12"}, corev1.PodSpec{})
+		otherPod := pod("other", map[string]string{"podpreset.admission.kubernetes.io/podpreset-other": "12"}, corev1.PodSpec{})
+
+		sdk = &SDK{
+			K8sClient:            k8sfake.NewSimpleClientset(secret, preset, envPod, volumePod, presetPod, otherPod),
+			ServiceCatalogClient: fake.NewSimpleClientset(broker, sc, sp, si, sbd),
+		}
+	})
+
+	Describe("InstanceTree", func() {
+		It("Includes the parents and children of the instance", func() {
+			tree, err := sdk.InstanceTree(si.Namespace, si.Name)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tree.Object.(Broker).GetName()).To(Equal("foobar-broker"))
+			classNode := tree.Children[0]
+			Expect(classNode.Object.(Class).GetName()).To(Equal(sc.Name))
+			planNode := classNode.Children[0]
+			Expect(planNode.Object.(Plan).GetName()).To(Equal(sp.Name))
+			instanceNode := planNode.Children[0]
+			Expect(instanceNode.Object.(*v1beta1.ServiceInstance).Name).To(Equal(si.Name))
+			bindingNode := instanceNode.Children[0]
+			Expect(bindingNode.Object.(*v1beta1.ServiceBinding).Name).To(Equal(sbd.Name))
+			secretNode := bindingNode.Children[0]
+			Expect(secretNode.Object.(*corev1.Secret).Name).To(Equal("foobar-secret"))
+
+			var pods []string
+			for _, child := range secretNode.Children {
+				pods = append(pods, child.Object.(*corev1.Pod).Name)
+			}
+			Expect(pods).To(ConsistOf("env", "volume", "preset"))
+		})
+		It("Skips secrets that were not created yet", func() {
+			sdk.K8sClient = k8sfake.NewSimpleClientset()
+			tree, err := sdk.InstanceTree(si.Namespace, si.Name)
+			Expect(err).NotTo(HaveOccurred())
+
+			bindingNode := tree.Children[0].Children[0].Children[0].Children[0]
+			Expect(bindingNode.Children).To(BeEmpty())
+		})
+	})
+	Describe("BrokerTree", func() {
+		It("Includes the classes, plans and instances of the broker", func() {
+			tree, err := sdk.BrokerTree("foobar-broker", ScopeOptions{Scope: ClusterScope})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tree.Children).To(HaveLen(1))
+			Expect(tree.Children[0].Children).To(HaveLen(1))
+			Expect(tree.Children[0].Children[0].Children).To(HaveLen(1))
+		})
+		It("Bubbles up errors", func() {
+			_, err := sdk.BrokerTree("missing-broker", ScopeOptions{Scope: ClusterScope})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
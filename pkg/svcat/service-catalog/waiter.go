@@ -0,0 +1,420 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// WaitForReady waits for the Ready condition of a resource.
+	WaitForReady = "ready"
+	// WaitForFailed waits for the Failed condition of a resource.
+	WaitForFailed = "failed"
+	// WaitForDeleted waits for a resource to be deleted.
+	WaitForDeleted = "deleted"
+
+	waitForConditionPrefix = "condition="
+)
+
+// WaitCondition is the state that a resource is waited for.
+type WaitCondition struct {
+	// ConditionType is the type of the status condition that must be true,
+	// e.g. Ready.
+	ConditionType string
+
+	// Deleted waits for the resource to be deleted instead of a condition.
+	Deleted bool
+}
+
+// ParseWaitCondition parses a condition to wait for: ready, failed, deleted
+// or condition=TYPE.
+func ParseWaitCondition(value string) (WaitCondition, error) {
+	switch strings.ToLower(value) {
+	case WaitForReady:
+		return WaitCondition{ConditionType: string(v1beta1.ServiceInstanceConditionReady)}, nil
+	case WaitForFailed:
+		return WaitCondition{ConditionType: string(v1beta1.ServiceInstanceConditionFailed)}, nil
+	case WaitForDeleted:
+		return WaitCondition{Deleted: true}, nil
+	}
+
+	if strings.HasPrefix(value, waitForConditionPrefix) {
+		conditionType := strings.TrimPrefix(value, waitForConditionPrefix)
+		if conditionType != "" {
+			return WaitCondition{ConditionType: conditionType}, nil
+		}
+	}
+
+	return WaitCondition{}, fmt.Errorf("invalid condition (%s), allowed values are: %s, %s, %s and %sTYPE",
+		value, WaitForReady, WaitForFailed, WaitForDeleted, waitForConditionPrefix)
+}
+
+// String returns the condition in the form accepted by ParseWaitCondition.
+func (c WaitCondition) String() string {
+	if c.Deleted {
+		return WaitForDeleted
+	}
+	return waitForConditionPrefix + c.ConditionType
+}
+
+// WaitFailedError is the former name of FailedError.
+type WaitFailedError = FailedError
+
+// IsWaitFailed returns if the error indicates that the resource failed
+// while it was waited for.
+//
+// Deprecated: use IsFailed.
+func IsWaitFailed(err error) bool {
+	return IsFailed(err)
+}
+
+// IsWaitTimeout returns if the error indicates that the condition wasn't met
+// before the timeout.
+//
+// Deprecated: use IsTimeout.
+func IsWaitTimeout(err error) bool {
+	return IsTimeout(err)
+}
+
+// statusCondition is the part of a status condition shared by every type.
+type statusCondition struct {
+	Type    string
+	Status  v1beta1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+// WaitForInstanceCondition waits until an instance meets the condition,
+// polling it every interval. A FailedError is returned when the instance
+// fails instead, and a TimeoutError when the timeout expires.
+func (sdk *SDK) WaitForInstanceCondition(ns, name string, cond WaitCondition, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceInstance, error) {
+	var instance *v1beta1.ServiceInstance
+	err := pollForCondition("instance", ns+"/"+name, cond, interval, timeout, func() ([]statusCondition, error) {
+		var err error
+		instance, err = sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return instanceConditions(instance), nil
+	})
+	return instance, err
+}
+
+// WaitForInstanceConditionContext waits until an instance meets the
+// condition, watching it for changes until the context is done. A
+// FailedError is returned when the instance fails instead, and a
+// TimeoutError when the deadline of the context is exceeded.
+func (sdk *SDK) WaitForInstanceConditionContext(ctx context.Context, ns, name string, cond WaitCondition) (*v1beta1.ServiceInstance, error) {
+	var instance *v1beta1.ServiceInstance
+	err := sdk.watchInstanceUntil(ctx, ns, name, func(i *v1beta1.ServiceInstance) (bool, error) {
+		instance = i
+		if i == nil {
+			return conditionMet("instance", ns+"/"+name, cond, nil, true)
+		}
+		return conditionMet("instance", ns+"/"+name, cond, instanceConditions(i), false)
+	})
+	return instance, err
+}
+
+// WaitForBindingCondition waits until a binding meets the condition, polling
+// it every interval. A FailedError is returned when the binding fails
+// instead, and a TimeoutError when the timeout expires.
+func (sdk *SDK) WaitForBindingCondition(ns, name string, cond WaitCondition, interval time.Duration, timeout *time.Duration) (*v1beta1.ServiceBinding, error) {
+	var binding *v1beta1.ServiceBinding
+	err := pollForCondition("binding", ns+"/"+name, cond, interval, timeout, func() ([]statusCondition, error) {
+		var err error
+		binding, err = sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return bindingConditions(binding), nil
+	})
+	return binding, err
+}
+
+// WaitForBindingConditionContext waits until a binding meets the condition,
+// watching it for changes until the context is done. A FailedError is
+// returned when the binding fails instead, and a TimeoutError when the
+// deadline of the context is exceeded.
+func (sdk *SDK) WaitForBindingConditionContext(ctx context.Context, ns, name string, cond WaitCondition) (*v1beta1.ServiceBinding, error) {
+	var binding *v1beta1.ServiceBinding
+	err := sdk.watchBindingUntil(ctx, ns, name, func(b *v1beta1.ServiceBinding) (bool, error) {
+		binding = b
+		if b == nil {
+			return conditionMet("binding", ns+"/"+name, cond, nil, true)
+		}
+		return conditionMet("binding", ns+"/"+name, cond, bindingConditions(b), false)
+	})
+	return binding, err
+}
+
+// WaitForBrokerCondition waits until a broker meets the condition, polling
+// it every interval. A FailedError is returned when the broker fails
+// instead, and a TimeoutError when the timeout expires. The scope must
+// select either cluster or namespaced brokers.
+func (sdk *SDK) WaitForBrokerCondition(name string, opts ScopeOptions, cond WaitCondition, interval time.Duration, timeout *time.Duration) (Broker, error) {
+	var broker Broker
+	err := pollForCondition("broker", brokerID(name, opts), cond, interval, timeout, func() ([]statusCondition, error) {
+		var err error
+		if opts.Scope == NamespaceScope {
+			broker, err = sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		} else {
+			broker, err = sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+		}
+		if err != nil {
+			return nil, err
+		}
+		return brokerConditions(broker), nil
+	})
+	return broker, err
+}
+
+// WaitForBrokerConditionContext waits until a broker meets the condition,
+// watching it for changes until the context is done. A FailedError is
+// returned when the broker fails instead, and a TimeoutError when the
+// deadline of the context is exceeded. The scope must select either cluster
+// or namespaced brokers.
+func (sdk *SDK) WaitForBrokerConditionContext(ctx context.Context, name string, opts ScopeOptions, cond WaitCondition) (Broker, error) {
+	var broker Broker
+	id := brokerID(name, opts)
+	get := func() (runtime.Object, error) {
+		return sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+	}
+	watchFn := func(listOpts v1.ListOptions) (watch.Interface, error) {
+		return sdk.ServiceCatalog().ClusterServiceBrokers().Watch(listOpts)
+	}
+	if opts.Scope == NamespaceScope {
+		get = func() (runtime.Object, error) {
+			return sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		}
+		watchFn = func(listOpts v1.ListOptions) (watch.Interface, error) {
+			return sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Watch(listOpts)
+		}
+	}
+
+	err := watchUntil(ctx, "broker", id, name, get, watchFn, func(obj runtime.Object) (bool, error) {
+		broker, _ = obj.(Broker)
+		if broker == nil {
+			return conditionMet("broker", id, cond, nil, true)
+		}
+		return conditionMet("broker", id, cond, brokerConditions(broker), false)
+	})
+	return broker, err
+}
+
+// brokerID identifies a broker in errors, prefixed with its namespace when
+// it is namespaced.
+func brokerID(name string, opts ScopeOptions) string {
+	if opts.Scope == NamespaceScope {
+		return opts.Namespace + "/" + name
+	}
+	return name
+}
+
+// instanceConditions returns the status conditions of an instance, or nil
+// while an operation is in progress as they are not final yet.
+func instanceConditions(instance *v1beta1.ServiceInstance) []statusCondition {
+	if instance.Status.AsyncOpInProgress {
+		return nil
+	}
+	conditions := make([]statusCondition, len(instance.Status.Conditions))
+	for i, c := range instance.Status.Conditions {
+		conditions[i] = statusCondition{string(c.Type), c.Status, c.Reason, c.Message}
+	}
+	return conditions
+}
+
+// bindingConditions returns the status conditions of a binding, or nil
+// while an operation is in progress as they are not final yet.
+func bindingConditions(binding *v1beta1.ServiceBinding) []statusCondition {
+	if binding.Status.AsyncOpInProgress {
+		return nil
+	}
+	conditions := make([]statusCondition, len(binding.Status.Conditions))
+	for i, c := range binding.Status.Conditions {
+		conditions[i] = statusCondition{string(c.Type), c.Status, c.Reason, c.Message}
+	}
+	return conditions
+}
+
+// brokerConditions returns the status conditions of a broker.
+func brokerConditions(broker Broker) []statusCondition {
+	status := broker.GetStatus()
+	conditions := make([]statusCondition, len(status.Conditions))
+	for i, c := range status.Conditions {
+		conditions[i] = statusCondition{string(c.Type), c.Status, c.Reason, c.Message}
+	}
+	return conditions
+}
+
+// conditionMet returns whether the conditions of a resource meet the
+// condition, or a FailedError when the resource failed instead. deleted is
+// set when the resource does not exist.
+func conditionMet(kind, name string, cond WaitCondition, conditions []statusCondition, deleted bool) (bool, error) {
+	if deleted {
+		if cond.Deleted {
+			return true, nil
+		}
+		return false, &NotFoundError{Kind: kind, Name: name}
+	}
+	if cond.Deleted {
+		return false, nil
+	}
+
+	for _, c := range conditions {
+		if c.Status == v1beta1.ConditionTrue && strings.EqualFold(c.Type, cond.ConditionType) {
+			return true, nil
+		}
+	}
+	if failed := failedCondition(conditions); failed != nil {
+		return false, &FailedError{Kind: kind, Name: name, Reason: failed.Reason, Message: failed.Message}
+	}
+	return false, nil
+}
+
+// operationDone returns whether the current operation of a resource
+// completed, or a FailedError when it failed.
+func operationDone(kind, name string, conditions []statusCondition) (bool, error) {
+	if failed := failedCondition(conditions); failed != nil {
+		return false, &FailedError{Kind: kind, Name: name, Reason: failed.Reason, Message: failed.Message}
+	}
+	for _, c := range conditions {
+		if c.Status == v1beta1.ConditionTrue && c.Type == string(v1beta1.ServiceInstanceConditionReady) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// failedCondition returns the Failed condition, if it is true.
+func failedCondition(conditions []statusCondition) *statusCondition {
+	for i, c := range conditions {
+		if c.Status == v1beta1.ConditionTrue && c.Type == string(v1beta1.ServiceInstanceConditionFailed) {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// pollForCondition polls the conditions of a resource until it meets the
+// condition, fails or is deleted.
+func pollForCondition(kind, name string, cond WaitCondition, interval time.Duration, timeout *time.Duration,
+	getConditions func() ([]statusCondition, error)) error {
+	if timeout == nil {
+		notimeout := time.Duration(math.MaxInt64)
+		timeout = &notimeout
+	}
+
+	err := wait.PollImmediate(interval, *timeout,
+		func() (bool, error) {
+			conditions, err := getConditions()
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					return conditionMet(kind, name, cond, nil, true)
+				}
+				return false, err
+			}
+			return conditionMet(kind, name, cond, conditions, false)
+		},
+	)
+	if err == wait.ErrWaitTimeout {
+		return &TimeoutError{Kind: kind, Name: name}
+	}
+	return err
+}
+
+// watchUntil gets a resource, then watches it for changes until done returns
+// true or an error, or the context is done. done is called with nil once the
+// resource does not exist. The watch is established again whenever the
+// server closes it.
+func watchUntil(ctx context.Context, kind, id, name string,
+	get func() (runtime.Object, error),
+	watchFn func(v1.ListOptions) (watch.Interface, error),
+	done func(runtime.Object) (bool, error)) error {
+	for {
+		if ctx.Err() != nil {
+			return contextError(ctx, kind, id)
+		}
+
+		obj, err := get()
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return getError(kind, id, err)
+			}
+			obj = nil
+		}
+		if ok, err := done(obj); ok || err != nil {
+			return err
+		}
+
+		opts := watchOptions(name)
+		if obj != nil {
+			if m, err := meta.Accessor(obj); err == nil {
+				opts.ResourceVersion = m.GetResourceVersion()
+			}
+		}
+		w, err := watchFn(opts)
+		if err != nil {
+			return fmt.Errorf("unable to watch %s '%s' (%s)", kind, id, err)
+		}
+		if ok, err := watchEvents(ctx, w, name, done); ok || err != nil {
+			return err
+		}
+	}
+}
+
+// watchEvents passes the changes of a resource to done until it returns true
+// or an error. It returns false without error once the watch is closed, or
+// the context is done.
+func watchEvents(ctx context.Context, w watch.Interface, name string, done func(runtime.Object) (bool, error)) (bool, error) {
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case event, open := <-w.ResultChan():
+			if !open || event.Type == watch.Error {
+				return false, nil
+			}
+			// Not every server honors the field selector on the name
+			m, err := meta.Accessor(event.Object)
+			if err != nil || m.GetName() != name {
+				continue
+			}
+			obj := event.Object
+			if event.Type == watch.Deleted {
+				obj = nil
+			}
+			if ok, err := done(obj); ok || err != nil {
+				return ok, err
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Waiter", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		ready        *v1beta1.ServiceInstance
+		failed       *v1beta1.ServiceInstance
+		binding      *v1beta1.ServiceBinding
+		broker       *v1beta1.ServiceBroker
+		interval     time.Duration
+		timeout      time.Duration
+	)
+
+	BeforeEach(func() {
+		ready = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "foobar_namespace"}}
+		ready.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+		}
+		failed = &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "foobar_namespace"}}
+		failed.Status.Conditions = []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse},
+			{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue,
+				Reason: "ProvisionCallFailed", Message: "out of capacity"},
+		}
+		binding = &v1beta1.ServiceBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "foobar_namespace"}}
+		binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+			{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+		}
+		binding.Status.AsyncOpInProgress = true
+		broker = &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "foobar_namespace"}}
+		broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{
+			{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue},
+		}
+		svcCatClient = fake.NewSimpleClientset(ready, failed, binding, broker)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+		interval = time.Millisecond
+		timeout = 20 * time.Millisecond
+	})
+
+	Describe("ParseWaitCondition", func() {
+		It("Parses the condition shortcuts", func() {
+			Expect(ParseWaitCondition("ready")).To(Equal(WaitCondition{ConditionType: "Ready"}))
+			Expect(ParseWaitCondition("Failed")).To(Equal(WaitCondition{ConditionType: "Failed"}))
+			Expect(ParseWaitCondition("deleted")).To(Equal(WaitCondition{Deleted: true}))
+		})
+		It("Parses arbitrary conditions", func() {
+			Expect(ParseWaitCondition("condition=OrphanMitigation")).To(Equal(WaitCondition{ConditionType: "OrphanMitigation"}))
+		})
+		It("Rejects unknown conditions", func() {
+			_, err := ParseWaitCondition("condition=")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseWaitCondition("happy")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Describe("WaitForInstanceCondition", func() {
+		It("Returns once the condition is met", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			instance, err := sdk.WaitForInstanceCondition(ready.Namespace, ready.Name, cond, interval, &timeout)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Name).To(Equal(ready.Name))
+		})
+		It("Reports instances that failed", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			_, err := sdk.WaitForInstanceCondition(failed.Namespace, failed.Name, cond, interval, &timeout)
+
+			Expect(IsFailed(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("instance 'foobar_namespace/failed' failed (ProvisionCallFailed): out of capacity"))
+		})
+		It("Waits for failures when asked", func() {
+			cond := WaitCondition{ConditionType: "Failed"}
+			_, err := sdk.WaitForInstanceCondition(failed.Namespace, failed.Name, cond, interval, &timeout)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("Waits for deleted instances", func() {
+			cond := WaitCondition{Deleted: true}
+			_, err := sdk.WaitForInstanceCondition(ready.Namespace, "missing", cond, interval, &timeout)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("Times out", func() {
+			cond := WaitCondition{Deleted: true}
+			_, err := sdk.WaitForInstanceCondition(ready.Namespace, ready.Name, cond, interval, &timeout)
+
+			Expect(IsTimeout(err)).To(BeTrue())
+		})
+		It("Reports instances that don't exist", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			_, err := sdk.WaitForInstanceCondition(ready.Namespace, "missing", cond, interval, &timeout)
+
+			Expect(err).To(HaveOccurred())
+			Expect(IsTimeout(err)).To(BeFalse())
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("instance '" + ready.Namespace + "/missing' not found"))
+		})
+	})
+	Describe("WaitForBindingCondition", func() {
+		It("Waits for asynchronous operations to complete", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			_, err := sdk.WaitForBindingCondition(binding.Namespace, binding.Name, cond, interval, &timeout)
+
+			Expect(IsTimeout(err)).To(BeTrue())
+		})
+	})
+	Describe("WaitForBrokerCondition", func() {
+		It("Waits for namespaced brokers", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			opts := ScopeOptions{Scope: NamespaceScope, Namespace: broker.Namespace}
+			result, err := sdk.WaitForBrokerCondition(broker.Name, opts, cond, interval, &timeout)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.GetNamespace()).To(Equal(broker.Namespace))
+			Expect(svcCatClient.Actions()[0].Matches("get", "servicebrokers")).To(BeTrue())
+		})
+	})
+	Describe("WaitForInstanceConditionContext", func() {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		BeforeEach(func() {
+			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		})
+		AfterEach(func() {
+			cancel()
+		})
+		watching := func() bool {
+			for _, a := range svcCatClient.Actions() {
+				if a.Matches("watch", "serviceinstances") {
+					return true
+				}
+			}
+			return false
+		}
+
+		It("Returns without watching when the condition is met", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			result, err := sdk.WaitForInstanceConditionContext(ctx, ready.Namespace, ready.Name, cond)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Name).To(Equal(ready.Name))
+			Expect(watching()).To(BeFalse())
+		})
+		It("Returns a FailedError when the instance fails", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			_, err := sdk.WaitForInstanceConditionContext(ctx, failed.Namespace, failed.Name, cond)
+
+			Expect(IsFailed(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("instance 'foobar_namespace/failed' failed (ProvisionCallFailed): out of capacity"))
+		})
+		It("Watches the instance until the condition is met", func() {
+			cond := WaitCondition{ConditionType: "Ready"}
+			errs := make(chan error, 1)
+			pending := &v1beta1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: ready.Namespace}}
+			_, err := sdk.ServiceCatalog().ServiceInstances(pending.Namespace).Create(pending)
+			Expect(err).NotTo(HaveOccurred())
+			go func() {
+				_, err := sdk.WaitForInstanceConditionContext(ctx, pending.Namespace, pending.Name, cond)
+				errs <- err
+			}()
+			Eventually(watching).Should(BeTrue())
+
+			pending.Status.Conditions = ready.Status.Conditions
+			_, err = sdk.ServiceCatalog().ServiceInstances(pending.Namespace).Update(pending)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(errs).Should(Receive(BeNil()))
+		})
+		It("Returns a TimeoutError once the deadline is exceeded", func() {
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+			cond := WaitCondition{ConditionType: "OrphanMitigation"}
+			_, err := sdk.WaitForInstanceConditionContext(ctx, ready.Namespace, ready.Name, cond)
+
+			Expect(IsTimeout(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("timed out waiting for instance '" + ready.Namespace + "/ready'"))
+		})
+		It("Returns the error of a cancelled context", func() {
+			cancel()
+			cond := WaitCondition{ConditionType: "Ready"}
+			_, err := sdk.WaitForInstanceConditionContext(ctx, ready.Namespace, ready.Name, cond)
+
+			Expect(err).To(Equal(context.Canceled))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+		It("Returns once the instance is deleted", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := sdk.WaitForInstanceConditionContext(ctx, ready.Namespace, ready.Name, WaitCondition{Deleted: true})
+				errs <- err
+			}()
+			Eventually(watching).Should(BeTrue())
+
+			err := sdk.ServiceCatalog().ServiceInstances(ready.Namespace).Delete(ready.Name, &metav1.DeleteOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(errs).Should(Receive(BeNil()))
+		})
+		It("Watches with a field selector on the name", func() {
+			cond := WaitCondition{ConditionType: "OrphanMitigation"}
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+			sdk.WaitForInstanceConditionContext(ctx, ready.Namespace, ready.Name, cond)
+
+			var restrictions testing.WatchRestrictions
+			for _, a := range svcCatClient.Actions() {
+				if a.Matches("watch", "serviceinstances") {
+					restrictions = a.(testing.WatchActionImpl).GetWatchRestrictions()
+				}
+			}
+			Expect(restrictions.Fields.String()).To(Equal("metadata.name=ready"))
+		})
+	})
+	Describe("WaitForBindingContext", func() {
+		It("Returns a FailedError when the operation fails", func() {
+			binding.Status.AsyncOpInProgress = false
+			binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+				{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue,
+					Reason: "BindCallFailed", Message: "no more credentials"},
+			}
+			_, err := sdk.ServiceCatalog().ServiceBindings(binding.Namespace).Update(binding)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sdk.WaitForBindingContext(context.Background(), binding.Namespace, binding.Name)
+
+			Expect(IsFailed(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("binding 'foobar_namespace/binding' failed (BindCallFailed): no more credentials"))
+		})
+	})
+})
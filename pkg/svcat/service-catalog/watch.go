@@ -0,0 +1,142 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WatchInstances watches the instances in a namespace for changes. When name
+// is specified, only that instance is watched.
+func (sdk *SDK) WatchInstances(ns, name string) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceInstances(ns).Watch(watchOptions(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch instances in %s (%s)", ns, err)
+	}
+	return w, nil
+}
+
+// WatchBindings watches the bindings in a namespace for changes. When name
+// is specified, only that binding is watched.
+func (sdk *SDK) WatchBindings(ns, name string) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceBindings(ns).Watch(watchOptions(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch bindings in %s (%s)", ns, err)
+	}
+	return w, nil
+}
+
+// WatchBrokers watches the brokers of the selected scope for changes. When
+// name is specified, only the brokers with that name are watched.
+func (sdk *SDK) WatchBrokers(name string, opts ScopeOptions) (watch.Interface, error) {
+	var watches []watch.Interface
+
+	if opts.Scope.Matches(ClusterScope) {
+		w, err := sdk.ServiceCatalog().ClusterServiceBrokers().Watch(watchOptions(name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to watch brokers (%s)", err)
+		}
+		watches = append(watches, w)
+	}
+
+	if opts.Scope.Matches(NamespaceScope) {
+		w, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Watch(watchOptions(name))
+		if err == nil {
+			watches = append(watches, w)
+		} else if !skipNotFound(err, opts.Scope) {
+			for _, w := range watches {
+				w.Stop()
+			}
+			return nil, fmt.Errorf("unable to watch brokers in %q (%s)", opts.Namespace, err)
+		}
+	}
+
+	return mergeWatches(watches...), nil
+}
+
+// watchOptions selects the resource to watch by name, or all resources when
+// the name is empty.
+func watchOptions(name string) v1.ListOptions {
+	opts := v1.ListOptions{}
+	if name != "" {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	}
+	return opts
+}
+
+// mergedWatch combines the events of several watches, e.g. for cluster and
+// namespaced resources. Its result channel is closed once all of the watches
+// are closed.
+type mergedWatch struct {
+	watches []watch.Interface
+	result  chan watch.Event
+	stop    chan struct{}
+	once    sync.Once
+}
+
+func mergeWatches(watches ...watch.Interface) watch.Interface {
+	if len(watches) == 1 {
+		return watches[0]
+	}
+
+	m := &mergedWatch{
+		watches: watches,
+		result:  make(chan watch.Event),
+		stop:    make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for _, w := range watches {
+		wg.Add(1)
+		go func(w watch.Interface) {
+			defer wg.Done()
+			for event := range w.ResultChan() {
+				select {
+				case m.result <- event:
+				case <-m.stop:
+					return
+				}
+			}
+		}(w)
+	}
+	go func() {
+		wg.Wait()
+		close(m.result)
+	}()
+
+	return m
+}
+
+// Stop stops all of the merged watches.
+func (m *mergedWatch) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+		for _, w := range m.watches {
+			w.Stop()
+		}
+	})
+}
+
+// ResultChan returns the events of all of the merged watches.
+func (m *mergedWatch) ResultChan() <-chan watch.Event {
+	return m.result
+}
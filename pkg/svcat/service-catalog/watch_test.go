@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"errors"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+	)
+
+	BeforeEach(func() {
+		svcCatClient = fake.NewSimpleClientset()
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("WatchInstances", func() {
+		It("Watches all of the instances in the namespace", func() {
+			w, err := sdk.WatchInstances("foobar_namespace", "")
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("foobar_namespace"))
+			Expect(actions[0].(testing.WatchActionImpl).GetWatchRestrictions().Fields.Empty()).To(BeTrue())
+		})
+		It("Watches a single instance", func() {
+			w, err := sdk.WatchInstances("foobar_namespace", "foobar")
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "serviceinstances")).To(BeTrue())
+			restrictions := actions[0].(testing.WatchActionImpl).GetWatchRestrictions()
+			Expect(restrictions.Fields.Matches(fields.Set{"metadata.name": "foobar"})).To(BeTrue())
+			Expect(restrictions.Fields.Matches(fields.Set{"metadata.name": "barbaz"})).To(BeFalse())
+		})
+	})
+	Describe("WatchBindings", func() {
+		It("Watches the bindings in the namespace", func() {
+			w, err := sdk.WatchBindings("foobar_namespace", "")
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "servicebindings")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("foobar_namespace"))
+		})
+	})
+	Describe("WatchBrokers", func() {
+		It("Merges the events of cluster and namespaced brokers", func() {
+			w, err := sdk.WatchBrokers("", ScopeOptions{Scope: AllScope, Namespace: "default"})
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "clusterservicebrokers")).To(BeTrue())
+			Expect(actions[1].Matches("watch", "servicebrokers")).To(BeTrue())
+
+			csb := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
+			_, err = svcCatClient.ServicecatalogV1beta1().ClusterServiceBrokers().Create(csb)
+			Expect(err).NotTo(HaveOccurred())
+			sb := &v1beta1.ServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar", Namespace: "default"}}
+			_, err = svcCatClient.ServicecatalogV1beta1().ServiceBrokers("default").Create(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			var first, second watch.Event
+			Eventually(w.ResultChan()).Should(Receive(&first))
+			Eventually(w.ResultChan()).Should(Receive(&second))
+			Expect([]interface{}{first.Object, second.Object}).To(ConsistOf(csb, sb))
+		})
+		It("Only watches cluster brokers in the cluster scope", func() {
+			w, err := sdk.WatchBrokers("", ScopeOptions{Scope: ClusterScope})
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("watch", "clusterservicebrokers")).To(BeTrue())
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "error watching brokers"
+			svcCatClient.PrependWatchReactor("servicebrokers", func(action testing.Action) (bool, watch.Interface, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+
+			_, err := sdk.WatchBrokers("", ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
+})
@@ -0,0 +1,209 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// patchOperation is a single RFC 6902 JSON patch operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ServeHTTP handles an AdmissionReview request for a pod and responds with
+// a JSON patch applying the matching PodPresets.
+func (m *Mutator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("unsupported method %s", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review := admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = m.admit(review.Request)
+	review.Response.UID = review.Request.UID
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error encoding AdmissionReview: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// admit computes the admission response for a single request. Requests that
+// are not pod creations, and pods that conflict with their PodPresets, are
+// admitted unchanged so that PodPresets never block workloads.
+func (m *Mutator) admit(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	allowed := &admissionv1beta1.AdmissionResponse{Allowed: true}
+
+	if req.Operation != admissionv1beta1.Create || req.Resource.Resource != "pods" || req.SubResource != "" {
+		return allowed
+	}
+
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, pod); err != nil {
+		return &admissionv1beta1.AdmissionResponse{
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("error decoding pod: %v", err),
+				Reason:  metav1.StatusReasonBadRequest,
+				Code:    http.StatusBadRequest,
+			},
+		}
+	}
+
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = pod.Namespace
+	}
+
+	original := pod.DeepCopy()
+	applied, err := m.Mutate(namespace, pod)
+	if err != nil {
+		glog.Warning(err)
+		return allowed
+	}
+	if len(applied) == 0 {
+		return allowed
+	}
+
+	patch, err := json.Marshal(createPatch(original, pod))
+	if err != nil {
+		glog.Errorf("Error creating patch for pod %s/%s: %v", namespace, pod.GenerateName+pod.Name, err)
+		return allowed
+	}
+
+	glog.V(4).Infof("Applied PodPresets %v to pod %s/%s", applied, namespace, pod.GenerateName+pod.Name)
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// createPatch returns the operations adding what the PodPresets injected
+// into the pod. Only the injected entries are added, so that the fields of
+// the pod unknown to the vendored types are preserved.
+func createPatch(original, pod *corev1.Pod) []patchOperation {
+	var patch []patchOperation
+
+	if len(original.Annotations) == 0 {
+		patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations", Value: pod.Annotations})
+	} else {
+		keys := make([]string, 0, len(pod.Annotations))
+		for k := range pod.Annotations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v, ok := original.Annotations[k]; ok && v == pod.Annotations[k] {
+				continue
+			}
+			patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations/" + escapePath(k), Value: pod.Annotations[k]})
+		}
+	}
+
+	var volumes []interface{}
+	for _, v := range pod.Spec.Volumes[len(original.Spec.Volumes):] {
+		volumes = append(volumes, v)
+	}
+	patch = appendPatch(patch, "/spec/volumes", len(original.Spec.Volumes), volumes)
+
+	for i := range pod.Spec.InitContainers {
+		patch = containerPatch(patch, fmt.Sprintf("/spec/initContainers/%d", i), &original.Spec.InitContainers[i], &pod.Spec.InitContainers[i])
+	}
+	for i := range pod.Spec.Containers {
+		patch = containerPatch(patch, fmt.Sprintf("/spec/containers/%d", i), &original.Spec.Containers[i], &pod.Spec.Containers[i])
+	}
+	return patch
+}
+
+// containerPatch adds the operations appending the env vars, env sources
+// and volume mounts injected into a container. The PodPresets only append
+// to these lists.
+func containerPatch(patch []patchOperation, path string, original, ctr *corev1.Container) []patchOperation {
+	var env, envFrom, volumeMounts []interface{}
+	for _, v := range ctr.Env[len(original.Env):] {
+		env = append(env, v)
+	}
+	for _, v := range ctr.EnvFrom[len(original.EnvFrom):] {
+		envFrom = append(envFrom, v)
+	}
+	for _, v := range ctr.VolumeMounts[len(original.VolumeMounts):] {
+		volumeMounts = append(volumeMounts, v)
+	}
+
+	patch = appendPatch(patch, path+"/env", len(original.Env), env)
+	patch = appendPatch(patch, path+"/envFrom", len(original.EnvFrom), envFrom)
+	return appendPatch(patch, path+"/volumeMounts", len(original.VolumeMounts), volumeMounts)
+}
+
+// appendPatch adds the operations appending items to the array at path,
+// which holds existing items. The array is created when it is empty, as it
+// may be missing from the pod.
+func appendPatch(patch []patchOperation, path string, existing int, items []interface{}) []patchOperation {
+	if len(items) == 0 {
+		return patch
+	}
+	if existing == 0 {
+		return append(patch, patchOperation{Op: "add", Path: path, Value: items})
+	}
+	for _, item := range items {
+		patch = append(patch, patchOperation{Op: "add", Path: path + "/-", Value: item})
+	}
+	return patch
+}
+
+// escapePath escapes a key to be used in a JSON pointer, as specified by
+// RFC 6901.
+func escapePath(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
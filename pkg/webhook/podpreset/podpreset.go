@@ -0,0 +1,310 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podpreset implements a mutating admission webhook that applies
+// settings.servicecatalog.k8s.io PodPresets to pods when they are created.
+package podpreset
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/glog"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// AnnotationPrefix is the prefix of the annotations added to a pod for
+	// every PodPreset that was applied to it. The value of the annotation
+	// is the resource version of the applied PodPreset.
+	AnnotationPrefix = "podpreset.servicecatalog.k8s.io"
+
+	// ExcludeAnnotation can be set to "true" on a pod to prevent any
+	// PodPreset from being applied to it.
+	ExcludeAnnotation = AnnotationPrefix + "/exclude"
+)
+
+// Mutator applies the PodPresets matching a pod to that pod.
+type Mutator struct {
+	lister settingslisters.PodPresetLister
+}
+
+// NewMutator creates a Mutator that looks up PodPresets with the given
+// lister.
+func NewMutator(lister settingslisters.PodPresetLister) *Mutator {
+	return &Mutator{lister: lister}
+}
+
+// Mutate applies all PodPresets in the namespace that select the given pod.
+// It returns the names of the applied PodPresets. If applying the matching
+// PodPresets would result in a conflict, the pod is left unchanged and an
+// error describing the conflicts is returned.
+func (m *Mutator) Mutate(namespace string, pod *corev1.Pod) ([]string, error) {
+	if podExcluded(pod) {
+		glog.V(5).Infof("Pod %s/%s is excluded from PodPreset injection", namespace, pod.GenerateName+pod.Name)
+		return nil, nil
+	}
+
+	list, err := m.lister.PodPresets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("listing PodPresets in namespace %q failed: %v", namespace, err)
+	}
+
+	podPresets, err := filterPodPresets(list, pod)
+	if err != nil {
+		return nil, err
+	}
+	if len(podPresets) == 0 {
+		return nil, nil
+	}
+
+	if err := safeToApplyPodPresetsOnPod(pod, podPresets); err != nil {
+		return nil, fmt.Errorf("conflict while applying PodPresets %s on pod %s/%s: %v", strings.Join(presetNames(podPresets), ", "), namespace, pod.GenerateName+pod.Name, err)
+	}
+
+	applyPodPresetsOnPod(pod, podPresets)
+	return presetNames(podPresets), nil
+}
+
+// podExcluded returns true if the pod opted out of PodPreset injection.
+func podExcluded(pod *corev1.Pod) bool {
+	return pod.Annotations[ExcludeAnnotation] == "true"
+}
+
+// filterPodPresets returns the PodPresets whose selector matches the pod.
+func filterPodPresets(list []*settingsv1alpha1.PodPreset, pod *corev1.Pod) ([]*settingsv1alpha1.PodPreset, error) {
+	var matching []*settingsv1alpha1.PodPreset
+	for _, pp := range list {
+		selector, err := metav1.LabelSelectorAsSelector(&pp.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("label selector conversion failed for PodPreset %q: %v", pp.Name, err)
+		}
+		// An empty selector matches nothing, unlike the usual semantics.
+		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		glog.V(4).Infof("PodPreset %q matches pod labels", pp.Name)
+		matching = append(matching, pp)
+	}
+	return matching, nil
+}
+
+// safeToApplyPodPresetsOnPod determines if there is any conflict in
+// information injected by the given PodPresets into the pod.
+func safeToApplyPodPresetsOnPod(pod *corev1.Pod, podPresets []*settingsv1alpha1.PodPreset) error {
+	var errs []error
+
+	if _, err := mergeVolumes(pod.Spec.Volumes, podPresets); err != nil {
+		errs = append(errs, err)
+	}
+	for i := range pod.Spec.InitContainers {
+		if err := safeToApplyPodPresetsOnContainer(&pod.Spec.InitContainers[i], podPresets); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := range pod.Spec.Containers {
+		if err := safeToApplyPodPresetsOnContainer(&pod.Spec.Containers[i], podPresets); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// safeToApplyPodPresetsOnContainer determines if there is any conflict in
+// information injected by the given PodPresets into the container.
+func safeToApplyPodPresetsOnContainer(ctr *corev1.Container, podPresets []*settingsv1alpha1.PodPreset) error {
+	var errs []error
+	if _, err := mergeEnv(ctr.Env, podPresets); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := mergeVolumeMounts(ctr.VolumeMounts, podPresets); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// mergeEnv merges a list of env vars with the env vars injected by the
+// given PodPresets. It returns an error if an env var with the same name
+// but a different value already exists.
+func mergeEnv(envVars []corev1.EnvVar, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.EnvVar, error) {
+	merged := make([]corev1.EnvVar, 0, len(envVars))
+	orig := map[string]corev1.EnvVar{}
+	for _, v := range envVars {
+		orig[v.Name] = v
+		merged = append(merged, v)
+	}
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.Env {
+			found, ok := orig[v.Name]
+			if !ok {
+				orig[v.Name] = v
+				merged = append(merged, v)
+				continue
+			}
+			if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging env for PodPreset %q: duplicate env var %s: %#v does not match %#v", pp.Name, v.Name, v, found))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// mergeEnvFrom appends the env sources of the given PodPresets to a list
+// of env sources. Env sources cannot conflict with each other.
+func mergeEnvFrom(envSources []corev1.EnvFromSource, podPresets []*settingsv1alpha1.PodPreset) []corev1.EnvFromSource {
+	merged := make([]corev1.EnvFromSource, 0, len(envSources))
+	merged = append(merged, envSources...)
+	for _, pp := range podPresets {
+		merged = append(merged, pp.Spec.EnvFrom...)
+	}
+	return merged
+}
+
+// mergeVolumeMounts merges a list of volume mounts with the volume mounts
+// injected by the given PodPresets. It returns an error if a volume mount
+// with the same name or mount path but different content already exists.
+func mergeVolumeMounts(volumeMounts []corev1.VolumeMount, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.VolumeMount, error) {
+	merged := make([]corev1.VolumeMount, 0, len(volumeMounts))
+	origName := map[string]corev1.VolumeMount{}
+	origPath := map[string]corev1.VolumeMount{}
+	for _, v := range volumeMounts {
+		origName[v.Name] = v
+		origPath[v.MountPath] = v
+		merged = append(merged, v)
+	}
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.VolumeMounts {
+			found, ok := origName[v.Name]
+			if !ok {
+				// If we don't already have it, check that the mount path
+				// is not used by another volume mount.
+				if found, ok := origPath[v.MountPath]; ok && !reflect.DeepEqual(found, v) {
+					errs = append(errs, fmt.Errorf("merging volume mounts for PodPreset %q: conflicting mount paths with name %s: %#v does not match %#v", pp.Name, v.Name, v, found))
+					continue
+				}
+				origName[v.Name] = v
+				origPath[v.MountPath] = v
+				merged = append(merged, v)
+				continue
+			}
+			if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging volume mounts for PodPreset %q: duplicate volume mount %s: %#v does not match %#v", pp.Name, v.Name, v, found))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// mergeVolumes merges a list of volumes with the volumes injected by the
+// given PodPresets. It returns an error if a volume with the same name but
+// different content already exists.
+func mergeVolumes(volumes []corev1.Volume, podPresets []*settingsv1alpha1.PodPreset) ([]corev1.Volume, error) {
+	merged := make([]corev1.Volume, 0, len(volumes))
+	orig := map[string]corev1.Volume{}
+	for _, v := range volumes {
+		orig[v.Name] = v
+		merged = append(merged, v)
+	}
+
+	var errs []error
+	for _, pp := range podPresets {
+		for _, v := range pp.Spec.Volumes {
+			found, ok := orig[v.Name]
+			if !ok {
+				orig[v.Name] = v
+				merged = append(merged, v)
+				continue
+			}
+			if !reflect.DeepEqual(found, v) {
+				errs = append(errs, fmt.Errorf("merging volumes for PodPreset %q: duplicate volume %s: %#v does not match %#v", pp.Name, v.Name, v, found))
+			}
+		}
+	}
+
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, err
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
+
+// applyPodPresetsOnPod updates the pod spec with the information injected
+// by the given PodPresets and records them in the pod's annotations.
+// Conflicts must have been ruled out with safeToApplyPodPresetsOnPod.
+func applyPodPresetsOnPod(pod *corev1.Pod, podPresets []*settingsv1alpha1.PodPreset) {
+	volumes, _ := mergeVolumes(pod.Spec.Volumes, podPresets)
+	pod.Spec.Volumes = volumes
+
+	for i := range pod.Spec.InitContainers {
+		applyPodPresetsOnContainer(&pod.Spec.InitContainers[i], podPresets)
+	}
+	for i := range pod.Spec.Containers {
+		applyPodPresetsOnContainer(&pod.Spec.Containers[i], podPresets)
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	for _, pp := range podPresets {
+		pod.Annotations[annotationForPodPreset(pp.Name)] = pp.ResourceVersion
+	}
+}
+
+// applyPodPresetsOnContainer injects the env, env sources and volume mounts
+// of the given PodPresets into the container.
+func applyPodPresetsOnContainer(ctr *corev1.Container, podPresets []*settingsv1alpha1.PodPreset) {
+	envVars, _ := mergeEnv(ctr.Env, podPresets)
+	ctr.Env = envVars
+
+	volumeMounts, _ := mergeVolumeMounts(ctr.VolumeMounts, podPresets)
+	ctr.VolumeMounts = volumeMounts
+
+	ctr.EnvFrom = mergeEnvFrom(ctr.EnvFrom, podPresets)
+}
+
+// annotationForPodPreset returns the annotation key recording that the
+// PodPreset with the given name was applied to a pod.
+func annotationForPodPreset(name string) string {
+	return fmt.Sprintf("%s/podpreset-%s", AnnotationPrefix, name)
+}
+
+func presetNames(podPresets []*settingsv1alpha1.PodPreset) []string {
+	names := make([]string, len(podPresets))
+	for i, pp := range podPresets {
+		names[i] = pp.Name
+	}
+	return names
+}
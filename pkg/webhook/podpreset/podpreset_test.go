@@ -0,0 +1,298 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+const testNamespace = "test-ns"
+
+func newTestMutator(t *testing.T, podPresets ...*settingsv1alpha1.PodPreset) *Mutator {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pp := range podPresets {
+		if err := indexer.Add(pp); err != nil {
+			t.Fatalf("unexpected error adding PodPreset: %v", err)
+		}
+	}
+	return NewMutator(settingslisters.NewPodPresetLister(indexer))
+}
+
+func newTestPodPreset(name string, env ...corev1.EnvVar) *settingsv1alpha1.PodPreset {
+	return &settingsv1alpha1.PodPreset{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, ResourceVersion: "1"},
+		Spec: settingsv1alpha1.PodPresetSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+			Env:      env,
+			Volumes: []corev1.Volume{
+				{Name: name, VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: name}}},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: name, MountPath: "/etc/" + name},
+			},
+		},
+	}
+}
+
+func newTestPod(env ...corev1.EnvVar) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: testNamespace, Labels: map[string]string{"app": "foo"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ctr", Image: "busybox", Env: env}},
+		},
+	}
+}
+
+func TestMutate(t *testing.T) {
+	dbURL := corev1.EnvVar{Name: "DB_URL", Value: "postgres://db"}
+	cases := []struct {
+		name            string
+		podPresets      []*settingsv1alpha1.PodPreset
+		pod             *corev1.Pod
+		expectedApplied []string
+		expectedError   bool
+		expectedEnv     []corev1.EnvVar
+		expectedMounts  int
+	}{
+		{
+			name:        "no matching presets",
+			podPresets:  []*settingsv1alpha1.PodPreset{},
+			pod:         newTestPod(),
+			expectedEnv: nil,
+		},
+		{
+			name:            "single matching preset",
+			podPresets:      []*settingsv1alpha1.PodPreset{newTestPodPreset("db", dbURL)},
+			pod:             newTestPod(),
+			expectedApplied: []string{"db"},
+			expectedEnv:     []corev1.EnvVar{dbURL},
+			expectedMounts:  1,
+		},
+		{
+			name: "selector does not match",
+			podPresets: func() []*settingsv1alpha1.PodPreset {
+				pp := newTestPodPreset("db", dbURL)
+				pp.Spec.Selector.MatchLabels = map[string]string{"app": "bar"}
+				return []*settingsv1alpha1.PodPreset{pp}
+			}(),
+			pod: newTestPod(),
+		},
+		{
+			name:            "identical env var is not a conflict",
+			podPresets:      []*settingsv1alpha1.PodPreset{newTestPodPreset("db", dbURL)},
+			pod:             newTestPod(dbURL),
+			expectedApplied: []string{"db"},
+			expectedEnv:     []corev1.EnvVar{dbURL},
+			expectedMounts:  1,
+		},
+		{
+			name:          "conflicting env var",
+			podPresets:    []*settingsv1alpha1.PodPreset{newTestPodPreset("db", dbURL)},
+			pod:           newTestPod(corev1.EnvVar{Name: "DB_URL", Value: "mysql://db"}),
+			expectedError: true,
+			expectedEnv:   []corev1.EnvVar{{Name: "DB_URL", Value: "mysql://db"}},
+		},
+		{
+			name: "conflicting mount path",
+			podPresets: func() []*settingsv1alpha1.PodPreset {
+				other := newTestPodPreset("cache")
+				other.Spec.VolumeMounts[0].MountPath = "/etc/db"
+				return []*settingsv1alpha1.PodPreset{newTestPodPreset("db"), other}
+			}(),
+			pod:           newTestPod(),
+			expectedError: true,
+		},
+		{
+			name:       "excluded pod",
+			podPresets: []*settingsv1alpha1.PodPreset{newTestPodPreset("db", dbURL)},
+			pod: func() *corev1.Pod {
+				p := newTestPod()
+				p.Annotations = map[string]string{ExcludeAnnotation: "true"}
+				return p
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		m := newTestMutator(t, tc.podPresets...)
+		applied, err := m.Mutate(testNamespace, tc.pod)
+		if tc.expectedError {
+			if err == nil {
+				t.Errorf("%v: expected error", tc.name)
+			}
+		} else if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(applied, tc.expectedApplied) {
+			t.Errorf("%v: unexpected applied PodPresets; expected: %v; actual: %v", tc.name, tc.expectedApplied, applied)
+		}
+		ctr := tc.pod.Spec.Containers[0]
+		if len(ctr.Env) != 0 || len(tc.expectedEnv) != 0 {
+			if !reflect.DeepEqual(ctr.Env, tc.expectedEnv) {
+				t.Errorf("%v: unexpected env; expected: %v; actual: %v", tc.name, tc.expectedEnv, ctr.Env)
+			}
+		}
+		if len(ctr.VolumeMounts) != tc.expectedMounts {
+			t.Errorf("%v: expected %d volume mounts, got %d", tc.name, tc.expectedMounts, len(ctr.VolumeMounts))
+		}
+		for _, name := range tc.expectedApplied {
+			if _, ok := tc.pod.Annotations[annotationForPodPreset(name)]; !ok {
+				t.Errorf("%v: expected annotation for PodPreset %q", tc.name, name)
+			}
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	m := newTestMutator(t, newTestPodPreset("db", corev1.EnvVar{Name: "DB_URL", Value: "postgres://db"}))
+
+	rawPod, err := json.Marshal(newTestPod())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	review := admissionv1beta1.AdmissionReview{
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       "request-uid",
+			Operation: admissionv1beta1.Create,
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Namespace: testNamespace,
+			Object:    runtime.RawExtension{Raw: rawPod},
+		},
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/mutate-pods", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code %d: %s", rec.Code, rec.Body.String())
+	}
+	result := admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if result.Response == nil || !result.Response.Allowed {
+		t.Fatalf("expected pod to be allowed, got %+v", result.Response)
+	}
+	if result.Response.UID != "request-uid" {
+		t.Errorf("unexpected response UID %q", result.Response.UID)
+	}
+	if result.Response.PatchType == nil || *result.Response.PatchType != admissionv1beta1.PatchTypeJSONPatch {
+		t.Errorf("expected a JSON patch")
+	}
+	var patch []patchOperation
+	if err := json.Unmarshal(result.Response.Patch, &patch); err != nil {
+		t.Fatalf("unexpected error decoding patch: %v", err)
+	}
+	expectedPaths := []string{"/metadata/annotations", "/spec/volumes", "/spec/containers/0/env", "/spec/containers/0/volumeMounts"}
+	var paths []string
+	for _, op := range patch {
+		paths = append(paths, op.Path)
+	}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("unexpected patch: %s", result.Response.Patch)
+	}
+}
+
+func TestAdmitPreservesUnknownFields(t *testing.T) {
+	m := newTestMutator(t, newTestPodPreset("db", corev1.EnvVar{Name: "DB_URL", Value: "postgres://db"}))
+
+	rawPod := []byte(`{
+		"metadata": {"name": "test-pod", "labels": {"app": "foo"}, "annotations": {"a/b": "c"}},
+		"spec": {
+			"futureField": {"enabled": true},
+			"volumes": [{"name": "data", "emptyDir": {}}],
+			"containers": [{
+				"name": "ctr",
+				"image": "busybox",
+				"futureContainerField": "x",
+				"env": [{"name": "FOO", "value": "bar"}]
+			}]
+		}
+	}`)
+	resp := m.admit(&admissionv1beta1.AdmissionRequest{
+		Operation: admissionv1beta1.Create,
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+		Namespace: testNamespace,
+		Object:    runtime.RawExtension{Raw: rawPod},
+	})
+	if !resp.Allowed || resp.Patch == nil {
+		t.Fatalf("expected pod to be allowed with a patch, got %+v", resp)
+	}
+
+	patch, err := jsonpatch.DecodePatch(resp.Patch)
+	if err != nil {
+		t.Fatalf("unexpected error decoding patch: %v", err)
+	}
+	patched, err := patch.Apply(rawPod)
+	if err != nil {
+		t.Fatalf("unexpected error applying patch %s: %v", resp.Patch, err)
+	}
+
+	var pod struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			FutureField map[string]bool   `json:"futureField"`
+			Volumes     []json.RawMessage `json:"volumes"`
+			Containers  []struct {
+				FutureContainerField string            `json:"futureContainerField"`
+				Env                  []corev1.EnvVar   `json:"env"`
+				VolumeMounts         []json.RawMessage `json:"volumeMounts"`
+			} `json:"containers"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(patched, &pod); err != nil {
+		t.Fatalf("unexpected error decoding patched pod: %v", err)
+	}
+	if !pod.Spec.FutureField["enabled"] {
+		t.Errorf("expected the unknown pod spec field to survive: %s", patched)
+	}
+	if ctr := pod.Spec.Containers[0]; ctr.FutureContainerField != "x" {
+		t.Errorf("expected the unknown container field to survive: %s", patched)
+	}
+	if pod.Metadata.Annotations["a/b"] != "c" || pod.Metadata.Annotations[annotationForPodPreset("db")] != "1" {
+		t.Errorf("unexpected annotations: %v", pod.Metadata.Annotations)
+	}
+	expectedEnv := []corev1.EnvVar{{Name: "FOO", Value: "bar"}, {Name: "DB_URL", Value: "postgres://db"}}
+	if !reflect.DeepEqual(pod.Spec.Containers[0].Env, expectedEnv) {
+		t.Errorf("unexpected env: %v", pod.Spec.Containers[0].Env)
+	}
+	if len(pod.Spec.Volumes) != 2 || len(pod.Spec.Containers[0].VolumeMounts) != 1 {
+		t.Errorf("expected the PodPreset volume to be added: %s", patched)
+	}
+}
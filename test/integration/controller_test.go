@@ -770,6 +770,7 @@ func newControllerTestTestController(ct *controllerTest) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		catalogClient.ServicecatalogV1beta1(),
+		catalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
@@ -784,6 +785,7 @@ func newControllerTestTestController(ct *controllerTest) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		0,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)
@@ -930,6 +932,7 @@ func newTestController(t *testing.T) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		catalogClient.ServicecatalogV1beta1(),
+		catalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
 		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
@@ -944,6 +947,7 @@ func newTestController(t *testing.T) (
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		0,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)